@@ -4,5 +4,5 @@ package accounts
 type AdministratorDetails struct {
 	Administrator
 	// Status invitation status.
-	Status string
+	Status AdministratorStatus
 }
@@ -0,0 +1,61 @@
+package accounts
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// AdministratorStatus represents the status of an account administrator.
+type AdministratorStatus uint8
+
+const (
+	// UnknownAdministrator unknown status.
+	UnknownAdministrator AdministratorStatus = iota
+	// ActiveAdministrator active administrator.
+	ActiveAdministrator
+	// InvitedAdministrator the administrator has been invited, but has not accepted the invitation yet.
+	InvitedAdministrator
+)
+
+const (
+	administratorUnknownStr = `unknown`
+	administratorActiveStr  = `active`
+	administratorInvitedStr = `waiting to accept the invitation`
+)
+
+var (
+	administratorStatusToValue = map[string]AdministratorStatus{
+		administratorActiveStr:  ActiveAdministrator,
+		administratorInvitedStr: InvitedAdministrator,
+	}
+
+	administratorStatusFromValue = map[AdministratorStatus]string{
+		ActiveAdministrator:  administratorActiveStr,
+		InvitedAdministrator: administratorInvitedStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (a AdministratorStatus) MarshalJSON() ([]byte, error) {
+	typeStr, ok := administratorStatusFromValue[a]
+	if !ok {
+		return json.Marshal(administratorUnknownStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (a *AdministratorStatus) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	rt, ok := administratorStatusToValue[value]
+	if !ok {
+		rt = UnknownAdministrator
+	}
+	*a = rt
+	return nil
+}
+
+// String Stringer implementation
+func (a AdministratorStatus) String() string {
+	return administratorStatusFromValue[a]
+}
@@ -0,0 +1,90 @@
+package accounts
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAdministratorStatus_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		value    AdministratorStatus
+		expected string
+	}{
+		{
+			title:    "Unknown",
+			expected: fmt.Sprintf("%q", administratorUnknownStr),
+		},
+		{
+			title:    "Active",
+			value:    ActiveAdministrator,
+			expected: fmt.Sprintf("%q", administratorActiveStr),
+		},
+		{
+			title:    "Invited",
+			value:    InvitedAdministrator,
+			expected: fmt.Sprintf("%q", administratorInvitedStr),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.value.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestAdministratorStatus_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected AdministratorStatus
+	}{
+		{
+			title:    "Unknown",
+			input:    `"Unknown"`,
+			expected: UnknownAdministrator,
+		},
+		{
+			title:    "random string",
+			input:    `"random"`,
+			expected: UnknownAdministrator,
+		},
+		{
+			title:    "Active lowercase",
+			input:    `"active"`,
+			expected: ActiveAdministrator,
+		},
+		{
+			title:    "Active title case",
+			input:    `"Active"`,
+			expected: ActiveAdministrator,
+		},
+		{
+			title:    "Invited lowercase",
+			input:    `"waiting to accept the invitation"`,
+			expected: InvitedAdministrator,
+		},
+		{
+			title:    "Invited title case",
+			input:    `"Waiting To Accept The Invitation"`,
+			expected: InvitedAdministrator,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var value AdministratorStatus
+			err := value.UnmarshalJSON([]byte(tC.input))
+			if err != nil {
+				t.Errorf("Expected error: nil, Actual: %q", err)
+			}
+			if tC.expected != value {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, value)
+			}
+		})
+	}
+}
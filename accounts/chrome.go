@@ -0,0 +1,69 @@
+package accounts
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Chrome defines what Campaign Monitor navigation to show within an embedded session.
+type Chrome uint8
+
+const (
+	// UnknownChrome unknown chrome.
+	UnknownChrome Chrome = iota
+	// AllChrome shows the full Campaign Monitor navigation.
+	AllChrome
+	// TabsChrome shows the navigation tabs only.
+	TabsChrome
+	// NoChrome hides the Campaign Monitor navigation.
+	NoChrome
+)
+
+const (
+	chromeAllStr  = `All`
+	chromeTabsStr = `Tabs`
+	chromeNoneStr = `None`
+)
+
+var (
+	chromeToValue = map[string]Chrome{
+		strings.ToLower(chromeAllStr):  AllChrome,
+		strings.ToLower(chromeTabsStr): TabsChrome,
+		strings.ToLower(chromeNoneStr): NoChrome,
+	}
+
+	chromeFromValue = map[Chrome]string{
+		AllChrome:  chromeAllStr,
+		TabsChrome: chromeTabsStr,
+		NoChrome:   chromeNoneStr,
+	}
+)
+
+// ParseChrome returns the chrome with the specified name (case-insensitive), or UnknownChrome.
+func ParseChrome(value string) Chrome {
+	return chromeToValue[strings.ToLower(strings.TrimSpace(value))]
+}
+
+// IsValid returns true if the chrome is one of All, Tabs or None.
+func (c Chrome) IsValid() bool {
+	_, ok := chromeFromValue[c]
+	return ok
+}
+
+// MarshalJSON marshal the object into json bytes.
+//
+// Unknown values are marshalled as an empty string rather than a made-up chrome name.
+func (c Chrome) MarshalJSON() ([]byte, error) {
+	return json.Marshal(chromeFromValue[c])
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (c *Chrome) UnmarshalJSON(b []byte) error {
+	*c = ParseChrome(strings.Trim(string(b), "\""))
+	return nil
+}
+
+// String Stringer implementation
+func (c Chrome) String() string {
+	return chromeFromValue[c]
+}
@@ -0,0 +1,79 @@
+package accounts
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestChrome_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    Chrome
+		expected string
+	}{
+		{title: "unknown", input: UnknownChrome, expected: `""`},
+		{title: "out of range", input: 100, expected: `""`},
+		{title: "all", input: AllChrome, expected: `"All"`},
+		{title: "tabs", input: TabsChrome, expected: `"Tabs"`},
+		{title: "none", input: NoChrome, expected: `"None"`},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := json.Marshal(tC.input)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if string(actual) != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestChrome_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Chrome
+	}{
+		{input: `"All"`, expected: AllChrome},
+		{input: `"tabs"`, expected: TabsChrome},
+		{input: `"NONE"`, expected: NoChrome},
+		{input: `"unknown"`, expected: UnknownChrome},
+		{input: `"invalid"`, expected: UnknownChrome},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			var actual Chrome
+			if err := json.Unmarshal([]byte(tC.input), &actual); err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestChrome_String(t *testing.T) {
+	testCases := []struct {
+		input         Chrome
+		expected      string
+		expectedValid bool
+	}{
+		{input: UnknownChrome, expected: ""},
+		{input: AllChrome, expected: "All", expectedValid: true},
+		{input: TabsChrome, expected: "Tabs", expectedValid: true},
+		{input: NoChrome, expected: "None", expectedValid: true},
+	}
+	for _, tC := range testCases {
+		if actual := tC.input.String(); actual != tC.expected {
+			t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+		}
+		if actual := tC.input.IsValid(); actual != tC.expectedValid {
+			t.Errorf("%q: Expected valid: %v, Actual: %v", tC.expected, tC.expectedValid, actual)
+		}
+		if tC.expectedValid && ParseChrome(" "+tC.expected+" ") != tC.input {
+			t.Errorf("Expected %q to be parsed", tC.expected)
+		}
+	}
+}
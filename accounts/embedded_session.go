@@ -1,17 +1,33 @@
 package accounts
 
+import (
+	"net/url"
+	"strings"
+)
+
 // EmbeddedSession represents a login session for the member with the specified email address.
 //
 // See https://www.campaignmonitor.com/api/account/#single-sign-on for more details.
 type EmbeddedSession struct {
 	// EmailAddress a valid email address for a person's account in the selected Campaign Monitor client.
 	EmailAddress string `json:"Email"`
-	// Chrome defines what Campaign Monitor navigation to show. Valid options are "All", "Tabs", or "None".
-	Chrome string
-	// URL the Campaign Monitor page to load
+	// Chrome defines what Campaign Monitor navigation to show.
+	Chrome Chrome
+	// URL the relative path of the Campaign Monitor page to load (eg. "/subscribers").
 	URL string `json:"Url"`
 	// IntegratorID your integration ID.
 	IntegratorID string
 	// ClientID the client ID of the account you want to access.
 	ClientID string
 }
+
+// IsValidSessionURL returns true if the value is a relative Campaign Monitor path (eg. "/subscribers/search?q=a").
+//
+// Absolute URLs, scheme relative URLs (eg. "//host/path") and relative paths without a leading slash are rejected.
+func IsValidSessionURL(value string) bool {
+	if !strings.HasPrefix(value, "/") || strings.HasPrefix(value, "//") || strings.ContainsAny(value, "\\\r\n") {
+		return false
+	}
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme == "" && u.Host == "" && u.User == nil
+}
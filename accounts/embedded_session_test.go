@@ -0,0 +1,29 @@
+package accounts
+
+import "testing"
+
+func TestIsValidSessionURL(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "/subscribers", expected: true},
+		{input: "/subscribers/search?q=a#top", expected: true},
+		{input: "/", expected: true},
+		{input: "", expected: false},
+		{input: "subscribers", expected: false},
+		{input: "//example.com/subscribers", expected: false},
+		{input: "/\\example.com", expected: false},
+		{input: "https://example.com/subscribers", expected: false},
+		{input: "javascript:alert(1)", expected: false},
+		{input: "/subscribers\r\nLocation: https://example.com", expected: false},
+		{input: "/%zz", expected: false},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			if actual := IsValidSessionURL(tC.input); actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
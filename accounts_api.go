@@ -73,7 +73,11 @@ func (a *accountsAPI) Now() (time.Time, error) {
 		return time.Time{}, err
 	}
 	if result != nil && len(result.SystemDate) > 0 {
-		t, err := time.Parse("2006-01-02 15:04:05", result.SystemDate)
+		location, err := a.client.Location()
+		if err != nil {
+			return time.Time{}, err
+		}
+		t, err := time.ParseInLocation(systemDateLayout, result.SystemDate, location)
 		if err != nil {
 			return time.Time{}, newWrappedClientError("Failed to parse the server date value", err, ErrCodeDataProcessing)
 		}
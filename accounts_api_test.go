@@ -560,28 +560,28 @@ func TestAccountsAPI_Administrators(t *testing.T) {
 			title: "account with admins",
 			response: &http.Response{
 				StatusCode: 200,
-				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"EmailAddress":"e@d.com", "Name":"name", "Status":"status"}]`)),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"EmailAddress":"e@d.com", "Name":"name", "Status":"Active"}]`)),
 			},
 			expected: []*accounts.AdministratorDetails{{
 				Administrator: accounts.Administrator{
 					EmailAddress: "e@d.com",
 					Name:         "name",
 				},
-				Status: "status",
+				Status: accounts.ActiveAdministrator,
 			}},
 		},
 		{
 			title: "oAuth authentication",
 			response: &http.Response{
 				StatusCode: 200,
-				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"EmailAddress":"e@d.com", "Name":"name", "Status":"status"}]`)),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"EmailAddress":"e@d.com", "Name":"name", "Status":"Active"}]`)),
 			},
 			expected: []*accounts.AdministratorDetails{{
 				Administrator: accounts.Administrator{
 					EmailAddress: "e@d.com",
 					Name:         "name",
 				},
-				Status: "status",
+				Status: accounts.ActiveAdministrator,
 			}},
 			oAuthAuthentication: true,
 		},
@@ -648,28 +648,28 @@ func TestAccountsAPI_Administrator(t *testing.T) {
 			title: "administrator found",
 			response: &http.Response{
 				StatusCode: 200,
-				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress":"e@d.com", "Name":"name", "Status":"status"}`)),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress":"e@d.com", "Name":"name", "Status":"Active"}`)),
 			},
 			expected: &accounts.AdministratorDetails{
 				Administrator: accounts.Administrator{
 					EmailAddress: "e@d.com",
 					Name:         "name",
 				},
-				Status: "status",
+				Status: accounts.ActiveAdministrator,
 			},
 		},
 		{
 			title: "oAuth authentication",
 			response: &http.Response{
 				StatusCode: 200,
-				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress":"e@d.com", "Name":"name", "Status":"status"}`)),
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress":"e@d.com", "Name":"name", "Status":"Active"}`)),
 			},
 			expected: &accounts.AdministratorDetails{
 				Administrator: accounts.Administrator{
 					EmailAddress: "e@d.com",
 					Name:         "name",
 				},
-				Status: "status",
+				Status: accounts.ActiveAdministrator,
 			},
 			oAuthAuthentication: true,
 		},
@@ -925,8 +925,8 @@ func TestAccountsAPI_NewEmbeddedSession(t *testing.T) {
 			},
 			input: accounts.EmbeddedSession{
 				EmailAddress: "e@d.com",
-				Chrome:       "chrome",
-				URL:          "url",
+				Chrome:       accounts.TabsChrome,
+				URL:          "/subscribers",
 				IntegratorID: "integration_id",
 				ClientID:     "client_id",
 			},
@@ -940,8 +940,8 @@ func TestAccountsAPI_NewEmbeddedSession(t *testing.T) {
 			},
 			input: accounts.EmbeddedSession{
 				EmailAddress: "e@d.com",
-				Chrome:       "chrome",
-				URL:          "url",
+				Chrome:       accounts.TabsChrome,
+				URL:          "/subscribers",
 				IntegratorID: "integration_id",
 				ClientID:     "client_id",
 			},
@@ -0,0 +1,14 @@
+// Package cache provides the caching primitives used to cache Campaign Monitor API responses.
+package cache
+
+// Cache represents a key-value store for caching API responses.
+//
+// The implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under the key and true, or nil and false if the key does not exist or has expired.
+	Get(key string) (interface{}, bool)
+	// Set stores the value under the key.
+	Set(key string, value interface{})
+	// Delete removes the key from the cache.
+	Delete(key string)
+}
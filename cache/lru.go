@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type entry struct {
+	key     string
+	value   interface{}
+	expires time.Time
+}
+
+// LRU is an in-memory cache which evicts the least recently used entries once the capacity has been reached.
+type LRU struct {
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+	mux      sync.Mutex
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewLRU creates a new in-memory LRU cache.
+//
+// A non-positive capacity means the cache size is unlimited, and a non-positive ttl means the entries never expire.
+func NewLRU(capacity int, ttl time.Duration) *LRU {
+	return &LRU{
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Get returns the value stored under the key and true, or nil and false if the key does not exist or has expired.
+func (l *LRU) Get(key string) (interface{}, bool) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	element, ok := l.items[key]
+	if !ok {
+		return nil, false
+	}
+	e := element.Value.(*entry)
+	if l.expired(e) {
+		l.remove(element)
+		return nil, false
+	}
+	l.order.MoveToFront(element)
+	return e.value, true
+}
+
+// Set stores the value under the key.
+func (l *LRU) Set(key string, value interface{}) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	var expires time.Time
+	if l.ttl > 0 {
+		expires = l.now().Add(l.ttl)
+	}
+	if element, ok := l.items[key]; ok {
+		e := element.Value.(*entry)
+		e.value = value
+		e.expires = expires
+		l.order.MoveToFront(element)
+		return
+	}
+	l.items[key] = l.order.PushFront(&entry{
+		key:     key,
+		value:   value,
+		expires: expires,
+	})
+	if l.capacity > 0 && l.order.Len() > l.capacity {
+		l.remove(l.order.Back())
+	}
+}
+
+// Delete removes the key from the cache.
+func (l *LRU) Delete(key string) {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	if element, ok := l.items[key]; ok {
+		l.remove(element)
+	}
+}
+
+// Len returns the number of the entries in the cache, including the expired entries which have not been evicted yet.
+func (l *LRU) Len() int {
+	l.mux.Lock()
+	defer l.mux.Unlock()
+	return l.order.Len()
+}
+
+func (l *LRU) expired(e *entry) bool {
+	return !e.expires.IsZero() && !l.now().Before(e.expires)
+}
+
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.items, element.Value.(*entry).key)
+}
@@ -0,0 +1,112 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLRU_GetSet(t *testing.T) {
+	c := NewLRU(0, 0)
+	if _, ok := c.Get("key"); ok {
+		t.Error("The key must not exist")
+	}
+	c.Set("key", "value")
+	value, ok := c.Get("key")
+	if !ok || value != "value" {
+		t.Errorf("Expected: value, Actual: %v", value)
+	}
+	c.Set("key", "updated")
+	value, _ = c.Get("key")
+	if value != "updated" {
+		t.Errorf("Expected: updated, Actual: %v", value)
+	}
+	if c.Len() != 1 {
+		t.Errorf("Expected length: 1, Actual: %d", c.Len())
+	}
+}
+
+func TestLRU_Delete(t *testing.T) {
+	c := NewLRU(0, 0)
+	c.Set("key", "value")
+	c.Delete("key")
+	c.Delete("missing")
+	if _, ok := c.Get("key"); ok {
+		t.Error("The key must have been deleted")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Expected length: 0, Actual: %d", c.Len())
+	}
+}
+
+func TestLRU_Eviction(t *testing.T) {
+	c := NewLRU(2, 0)
+	c.Set("a", 1)
+	c.Set("b", 2)
+	// Accessing "a" makes "b" the least recently used entry.
+	c.Get("a")
+	c.Set("c", 3)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("The least recently used entry must have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("The %q key must not have been evicted", key)
+		}
+	}
+	if c.Len() != 2 {
+		t.Errorf("Expected length: 2, Actual: %d", c.Len())
+	}
+}
+
+func TestLRU_Expiry(t *testing.T) {
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewLRU(0, time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set("key", "value")
+	now = now.Add(59 * time.Second)
+	if _, ok := c.Get("key"); !ok {
+		t.Error("The entry must not have been expired")
+	}
+
+	now = now.Add(time.Second)
+	if _, ok := c.Get("key"); ok {
+		t.Error("The entry must have been expired")
+	}
+	if c.Len() != 0 {
+		t.Errorf("The expired entry must have been removed. Actual length: %d", c.Len())
+	}
+
+	c.Set("key", "value")
+	now = now.Add(30 * time.Second)
+	c.Set("key", "refreshed")
+	now = now.Add(45 * time.Second)
+	if value, ok := c.Get("key"); !ok || value != "refreshed" {
+		t.Errorf("Setting a key must reset its expiry. Actual: %v", value)
+	}
+}
+
+func TestLRU_Concurrency(t *testing.T) {
+	c := NewLRU(10, time.Minute)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				key := string(rune('a' + (i+j)%20))
+				c.Set(key, j)
+				c.Get(key)
+				if j%10 == 0 {
+					c.Delete(key)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+	if c.Len() > 10 {
+		t.Errorf("The cache must not grow beyond its capacity. Actual length: %d", c.Len())
+	}
+}
@@ -0,0 +1,250 @@
+package createsend
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cache"
+	"github.com/xitonix/createsend/clients"
+)
+
+const (
+	countriesCacheKey                  = "accounts.Countries"
+	timezonesCacheKey                  = "accounts.Timezones"
+	clientsCacheKey                    = "accounts.Clients"
+	billingCacheKey                    = "accounts.Billing"
+	administratorsCacheKey             = "accounts.Administrators"
+	accountPrimaryContactCacheKey      = "accounts.PrimaryContact"
+	clientDetailsCacheKeyPrefix        = "clients.Get:"
+	peopleCacheKeyPrefix               = "clients.People:"
+	clientPrimaryContactCacheKeyPrefix = "clients.PrimaryContact:"
+)
+
+// responseCache loads the API responses through the cache and invalidates the cached responses.
+//
+// The keys are scoped to the credentials, so that the responses cached for one set of credentials
+// (eg. an account API key) are never served to another (eg. a client session created by ForClient).
+type responseCache struct {
+	cache cache.Cache
+	scope string
+}
+
+// credentialScope returns the cache key prefix of the credentials, which does not reveal the credentials.
+func credentialScope(auth *authentication) string {
+	hash := sha256.Sum256([]byte{byte(auth.method)})
+	hash = sha256.Sum256(append(hash[:], auth.token...))
+	return hex.EncodeToString(hash[:16]) + "/"
+}
+
+// load returns a copy of the cached value of the key, or calls fetch to load the value and caches a copy of it.
+//
+// The values are copied so that the callers can never modify the cached values. Failed calls are not cached.
+func load[T any](r responseCache, key string, fetch func() (T, error), clone func(T) T) (T, error) {
+	if value, ok := r.cache.Get(r.scope + key); ok {
+		return clone(value.(T)), nil
+	}
+	value, err := fetch()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	r.cache.Set(r.scope+key, clone(value))
+	return value, nil
+}
+
+func (r responseCache) invalidate(keys ...string) {
+	for _, key := range keys {
+		r.cache.Delete(r.scope + key)
+	}
+}
+
+// cachedAccountsAPI caches the responses of the read methods of the accounts API.
+type cachedAccountsAPI struct {
+	accounts.API
+	responses responseCache
+}
+
+func newCachedAccountsAPI(api accounts.API, c cache.Cache, scope string) *cachedAccountsAPI {
+	return &cachedAccountsAPI{
+		API:       api,
+		responses: responseCache{cache: c, scope: scope},
+	}
+}
+
+func (a *cachedAccountsAPI) Clients() ([]*accounts.Client, error) {
+	return load(a.responses, clientsCacheKey, a.API.Clients, copyAll[accounts.Client])
+}
+
+func (a *cachedAccountsAPI) Billing() (*accounts.Billing, error) {
+	return load(a.responses, billingCacheKey, a.API.Billing, copyOne[accounts.Billing])
+}
+
+func (a *cachedAccountsAPI) Countries() ([]string, error) {
+	return load(a.responses, countriesCacheKey, a.API.Countries, copyStrings)
+}
+
+func (a *cachedAccountsAPI) Timezones() ([]string, error) {
+	return load(a.responses, timezonesCacheKey, a.API.Timezones, copyStrings)
+}
+
+func (a *cachedAccountsAPI) Administrators() ([]*accounts.AdministratorDetails, error) {
+	return load(a.responses, administratorsCacheKey, a.API.Administrators, copyAll[accounts.AdministratorDetails])
+}
+
+func (a *cachedAccountsAPI) PrimaryContact() (string, error) {
+	return load(a.responses, accountPrimaryContactCacheKey, a.API.PrimaryContact, same[string])
+}
+
+func (a *cachedAccountsAPI) AddAdministrator(administrator accounts.Administrator) error {
+	defer a.responses.invalidate(administratorsCacheKey)
+	return a.API.AddAdministrator(administrator)
+}
+
+func (a *cachedAccountsAPI) UpdateAdministrator(currentEmailAddress string, administrator accounts.Administrator) error {
+	defer a.responses.invalidate(administratorsCacheKey, accountPrimaryContactCacheKey)
+	return a.API.UpdateAdministrator(currentEmailAddress, administrator)
+}
+
+func (a *cachedAccountsAPI) DeleteAdministrator(emailAddress string) error {
+	defer a.responses.invalidate(administratorsCacheKey, accountPrimaryContactCacheKey)
+	return a.API.DeleteAdministrator(emailAddress)
+}
+
+func (a *cachedAccountsAPI) SetAsPrimaryContact(emailAddress string) error {
+	defer a.responses.invalidate(accountPrimaryContactCacheKey)
+	return a.API.SetAsPrimaryContact(emailAddress)
+}
+
+// cachedClientsAPI caches the responses of the read methods of the clients API.
+type cachedClientsAPI struct {
+	clients.API
+	responses responseCache
+}
+
+func newCachedClientsAPI(api clients.API, c cache.Cache, scope string) *cachedClientsAPI {
+	return &cachedClientsAPI{
+		API:       api,
+		responses: responseCache{cache: c, scope: scope},
+	}
+}
+
+func (c *cachedClientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
+	return load(c.responses, clientDetailsCacheKeyPrefix+clientID, func() (*clients.ClientDetails, error) {
+		return c.API.Get(clientID)
+	}, copyClientDetails)
+}
+
+func (c *cachedClientsAPI) People(clientID string) ([]*clients.PersonDetails, error) {
+	return load(c.responses, peopleCacheKeyPrefix+clientID, func() ([]*clients.PersonDetails, error) {
+		return c.API.People(clientID)
+	}, copyAll[clients.PersonDetails])
+}
+
+func (c *cachedClientsAPI) PrimaryContact(clientID string) (string, error) {
+	return load(c.responses, clientPrimaryContactCacheKeyPrefix+clientID, func() (string, error) {
+		return c.API.PrimaryContact(clientID)
+	}, same[string])
+}
+
+func (c *cachedClientsAPI) Create(details clients.BasicDetails) (string, error) {
+	defer c.responses.invalidate(clientsCacheKey)
+	return c.API.Create(details)
+}
+
+func (c *cachedClientsAPI) Update(clientID string, details clients.BasicDetails) error {
+	defer c.responses.invalidate(clientDetailsCacheKeyPrefix+clientID, clientsCacheKey)
+	return c.API.Update(clientID, details)
+}
+
+func (c *cachedClientsAPI) SetPAYGBilling(clientID string, rates clients.PAYGRates) error {
+	defer c.responses.invalidate(clientDetailsCacheKeyPrefix + clientID)
+	return c.API.SetPAYGBilling(clientID, rates)
+}
+
+func (c *cachedClientsAPI) SetMonthlyBilling(clientID string, rates clients.MonthlyRates) error {
+	defer c.responses.invalidate(clientDetailsCacheKeyPrefix + clientID)
+	return c.API.SetMonthlyBilling(clientID, rates)
+}
+
+func (c *cachedClientsAPI) TransferCredits(clientID string, request clients.CreditTransferRequest) (*clients.CreditTransferResult, error) {
+	defer c.responses.invalidate(clientDetailsCacheKeyPrefix+clientID, billingCacheKey)
+	return c.API.TransferCredits(clientID, request)
+}
+
+func (c *cachedClientsAPI) Delete(clientID string) error {
+	defer c.responses.invalidate(
+		clientDetailsCacheKeyPrefix+clientID,
+		peopleCacheKeyPrefix+clientID,
+		clientPrimaryContactCacheKeyPrefix+clientID,
+		clientsCacheKey)
+	return c.API.Delete(clientID)
+}
+
+func (c *cachedClientsAPI) AddPerson(clientID string, person clients.Person) (string, error) {
+	defer c.responses.invalidate(peopleCacheKeyPrefix + clientID)
+	return c.API.AddPerson(clientID, person)
+}
+
+func (c *cachedClientsAPI) UpdatePerson(clientID string, emailAddress string, person clients.Person) (string, error) {
+	defer c.responses.invalidate(peopleCacheKeyPrefix+clientID, clientPrimaryContactCacheKeyPrefix+clientID)
+	return c.API.UpdatePerson(clientID, emailAddress, person)
+}
+
+func (c *cachedClientsAPI) DeletePerson(clientID string, emailAddress string) error {
+	defer c.responses.invalidate(peopleCacheKeyPrefix+clientID, clientPrimaryContactCacheKeyPrefix+clientID)
+	return c.API.DeletePerson(clientID, emailAddress)
+}
+
+func (c *cachedClientsAPI) SetPrimaryContact(clientID string, emailAddress string) (string, error) {
+	defer c.responses.invalidate(clientPrimaryContactCacheKeyPrefix + clientID)
+	return c.API.SetPrimaryContact(clientID, emailAddress)
+}
+
+func same[T any](value T) T {
+	return value
+}
+
+func copyStrings(values []string) []string {
+	return append([]string(nil), values...)
+}
+
+func copyOne[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	c := *value
+	return &c
+}
+
+func copyAll[T any](values []*T) []*T {
+	if values == nil {
+		return nil
+	}
+	c := make([]*T, len(values))
+	for i, value := range values {
+		c[i] = copyOne(value)
+	}
+	return c
+}
+
+func copyClientDetails(details *clients.ClientDetails) *clients.ClientDetails {
+	c := copyOne(details)
+	if c != nil {
+		c.Contact = copyOne(details.Contact)
+		c.Billing = copyBillingDetails(details.Billing)
+	}
+	return c
+}
+
+func copyBillingDetails(billing *clients.BillingDetails) *clients.BillingDetails {
+	c := copyOne(billing)
+	if c != nil {
+		c.PAYG = copyOne(billing.PAYG)
+		if billing.Monthly != nil {
+			c.Monthly = copyOne(billing.Monthly)
+			c.Monthly.Pending = copyBillingDetails(billing.Monthly.Pending)
+		}
+	}
+	return c
+}
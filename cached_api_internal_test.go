@@ -0,0 +1,54 @@
+package createsend
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/money"
+)
+
+func TestCopyClientDetails(t *testing.T) {
+	original := &clients.ClientDetails{
+		APIKey:  "key",
+		Contact: &clients.ContactDetails{Name: "name"},
+		Billing: &clients.BillingDetails{
+			PAYG: &clients.PayAsYouGoBillingDetails{Credits: 10},
+			Monthly: &clients.MonthlyBillingDetails{
+				Tier:    "tier",
+				Rate:    money.MustParse("1.5"),
+				Pending: &clients.BillingDetails{Monthly: &clients.MonthlyBillingDetails{Tier: "pending"}},
+			},
+		},
+	}
+	c := copyClientDetails(original)
+	if diff := cmp.Diff(original, c, cmp.Comparer(func(a, b money.Decimal) bool { return a.Equal(b) })); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+	c.Contact.Name = ""
+	c.Billing.PAYG.Credits = 0
+	c.Billing.Monthly.Tier = ""
+	c.Billing.Monthly.Pending.Monthly.Tier = ""
+	if original.Contact.Name != "name" || original.Billing.PAYG.Credits != 10 || original.Billing.Monthly.Tier != "tier" {
+		t.Errorf("The original details must not be modified: %+v", original)
+	}
+	if original.Billing.Monthly.Pending == c.Billing.Monthly.Pending {
+		t.Error("The pending billing details must be copied")
+	}
+	if copyClientDetails(nil) != nil || copyBillingDetails(nil) != nil {
+		t.Error("Expected nil copies of nil values")
+	}
+}
+
+func TestCredentialScope(t *testing.T) {
+	apiKey := credentialScope(&authentication{token: "token", method: apiKeyAuthentication})
+	oAuth := credentialScope(&authentication{token: "token", method: oAuthAuthentication})
+	other := credentialScope(&authentication{token: "other", method: apiKeyAuthentication})
+	if apiKey == oAuth || apiKey == other {
+		t.Errorf("Expected different scopes, Actual: %s, %s, %s", apiKey, oAuth, other)
+	}
+	if apiKey != credentialScope(&authentication{token: "token", method: apiKeyAuthentication}) {
+		t.Error("Expected the same scope for the same credentials")
+	}
+}
@@ -0,0 +1,534 @@
+package createsend_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cache"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/mock"
+)
+
+type apiCallCounter struct {
+	calls map[string]int
+	err   error
+}
+
+func (a *apiCallCounter) record(method string) error {
+	a.calls[method]++
+	return a.err
+}
+
+type accountsAPICounter struct {
+	accounts.API
+	*apiCallCounter
+}
+
+func (a accountsAPICounter) Clients() ([]*accounts.Client, error) {
+	if err := a.record("Clients"); err != nil {
+		return nil, err
+	}
+	return []*accounts.Client{{ID: "client_id"}}, nil
+}
+
+func (a accountsAPICounter) Billing() (*accounts.Billing, error) {
+	if err := a.record("Billing"); err != nil {
+		return nil, err
+	}
+	return &accounts.Billing{Credits: 10}, nil
+}
+
+func (a accountsAPICounter) Countries() ([]string, error) {
+	if err := a.record("Countries"); err != nil {
+		return nil, err
+	}
+	return []string{"Australia"}, nil
+}
+
+func (a accountsAPICounter) Timezones() ([]string, error) {
+	if err := a.record("Timezones"); err != nil {
+		return nil, err
+	}
+	return []string{"(GMT+10:00) Canberra, Melbourne, Sydney"}, nil
+}
+
+func (a accountsAPICounter) Administrators() ([]*accounts.AdministratorDetails, error) {
+	if err := a.record("Administrators"); err != nil {
+		return nil, err
+	}
+	return []*accounts.AdministratorDetails{}, nil
+}
+
+func (a accountsAPICounter) PrimaryContact() (string, error) {
+	if err := a.record("PrimaryContact"); err != nil {
+		return "", err
+	}
+	return "a@b.com", nil
+}
+
+func (a accountsAPICounter) AddAdministrator(accounts.Administrator) error {
+	return a.record("AddAdministrator")
+}
+
+func (a accountsAPICounter) UpdateAdministrator(string, accounts.Administrator) error {
+	return a.record("UpdateAdministrator")
+}
+
+func (a accountsAPICounter) DeleteAdministrator(string) error {
+	return a.record("DeleteAdministrator")
+}
+
+func (a accountsAPICounter) SetAsPrimaryContact(string) error {
+	return a.record("SetAsPrimaryContact")
+}
+
+type clientsAPICounter struct {
+	clients.API
+	*apiCallCounter
+}
+
+func (c clientsAPICounter) Get(clientID string) (*clients.ClientDetails, error) {
+	if err := c.record("Get"); err != nil {
+		return nil, err
+	}
+	return &clients.ClientDetails{APIKey: "client_key", ID: clientID}, nil
+}
+
+func (c clientsAPICounter) People(string) ([]*clients.PersonDetails, error) {
+	if err := c.record("People"); err != nil {
+		return nil, err
+	}
+	return []*clients.PersonDetails{}, nil
+}
+
+func (c clientsAPICounter) PrimaryContact(string) (string, error) {
+	if err := c.record("PrimaryContact"); err != nil {
+		return "", err
+	}
+	return "a@b.com", nil
+}
+
+func (c clientsAPICounter) Create(clients.BasicDetails) (string, error) {
+	return "client_id", c.record("Create")
+}
+
+func (c clientsAPICounter) Update(string, clients.BasicDetails) error {
+	return c.record("Update")
+}
+
+func (c clientsAPICounter) SetPAYGBilling(string, clients.PAYGRates) error {
+	return c.record("SetPAYGBilling")
+}
+
+func (c clientsAPICounter) SetMonthlyBilling(string, clients.MonthlyRates) error {
+	return c.record("SetMonthlyBilling")
+}
+
+func (c clientsAPICounter) TransferCredits(string, clients.CreditTransferRequest) (*clients.CreditTransferResult, error) {
+	return &clients.CreditTransferResult{}, c.record("TransferCredits")
+}
+
+func (c clientsAPICounter) Delete(string) error {
+	return c.record("Delete")
+}
+
+func (c clientsAPICounter) AddPerson(string, clients.Person) (string, error) {
+	return "a@b.com", c.record("AddPerson")
+}
+
+func (c clientsAPICounter) UpdatePerson(string, string, clients.Person) (string, error) {
+	return "a@b.com", c.record("UpdatePerson")
+}
+
+func (c clientsAPICounter) DeletePerson(string, string) error {
+	return c.record("DeletePerson")
+}
+
+func (c clientsAPICounter) SetPrimaryContact(string, string) (string, error) {
+	return "a@b.com", c.record("SetPrimaryContact")
+}
+
+func (c clientsAPICounter) Lists(string) ([]*clients.List, error) {
+	return []*clients.List{}, c.record("Lists")
+}
+
+func newCachedTestClient(t *testing.T) (*createsend.Client, *apiCallCounter, *apiCallCounter) {
+	t.Helper()
+	accountCalls := &apiCallCounter{calls: make(map[string]int)}
+	clientCalls := &apiCallCounter{calls: make(map[string]int)}
+	client, err := createsend.New(
+		createsend.WithAPIKey("api_key"),
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAccountsAPI(accountsAPICounter{apiCallCounter: accountCalls}),
+		createsend.WithClientsAPI(clientsAPICounter{apiCallCounter: clientCalls}),
+		createsend.WithCache(cache.NewLRU(100, time.Hour)),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return client, accountCalls, clientCalls
+}
+
+func TestCachedAPIs(t *testing.T) {
+	type read struct {
+		accountLevel bool
+		method       string
+		call         func(c *createsend.Client) error
+	}
+
+	readers := map[string]read{
+		"accounts.Clients": {true, "Clients", func(c *createsend.Client) error {
+			_, err := c.Accounts().Clients()
+			return err
+		}},
+		"accounts.Billing": {true, "Billing", func(c *createsend.Client) error {
+			_, err := c.Accounts().Billing()
+			return err
+		}},
+		"accounts.Countries": {true, "Countries", func(c *createsend.Client) error {
+			_, err := c.Accounts().Countries()
+			return err
+		}},
+		"accounts.Timezones": {true, "Timezones", func(c *createsend.Client) error {
+			_, err := c.Accounts().Timezones()
+			return err
+		}},
+		"accounts.Administrators": {true, "Administrators", func(c *createsend.Client) error {
+			_, err := c.Accounts().Administrators()
+			return err
+		}},
+		"accounts.PrimaryContact": {true, "PrimaryContact", func(c *createsend.Client) error {
+			_, err := c.Accounts().PrimaryContact()
+			return err
+		}},
+		"clients.Get": {false, "Get", func(c *createsend.Client) error {
+			_, err := c.Clients().Get("client_id")
+			return err
+		}},
+		"clients.People": {false, "People", func(c *createsend.Client) error {
+			_, err := c.Clients().People("client_id")
+			return err
+		}},
+		"clients.PrimaryContact": {false, "PrimaryContact", func(c *createsend.Client) error {
+			_, err := c.Clients().PrimaryContact("client_id")
+			return err
+		}},
+	}
+
+	testCases := []struct {
+		title        string
+		mutate       func(c *createsend.Client) error
+		invalidates  []string
+		unaffectedBy []string
+	}{
+		{
+			title: "add administrator",
+			mutate: func(c *createsend.Client) error {
+				return c.Accounts().AddAdministrator(accounts.Administrator{})
+			},
+			invalidates:  []string{"accounts.Administrators"},
+			unaffectedBy: []string{"accounts.PrimaryContact", "accounts.Countries"},
+		},
+		{
+			title: "update administrator",
+			mutate: func(c *createsend.Client) error {
+				return c.Accounts().UpdateAdministrator("a@b.com", accounts.Administrator{})
+			},
+			invalidates:  []string{"accounts.Administrators", "accounts.PrimaryContact"},
+			unaffectedBy: []string{"accounts.Timezones"},
+		},
+		{
+			title: "delete administrator",
+			mutate: func(c *createsend.Client) error {
+				return c.Accounts().DeleteAdministrator("a@b.com")
+			},
+			invalidates:  []string{"accounts.Administrators", "accounts.PrimaryContact"},
+			unaffectedBy: []string{"accounts.Clients"},
+		},
+		{
+			title: "set account primary contact",
+			mutate: func(c *createsend.Client) error {
+				return c.Accounts().SetAsPrimaryContact("a@b.com")
+			},
+			invalidates:  []string{"accounts.PrimaryContact"},
+			unaffectedBy: []string{"accounts.Administrators", "clients.PrimaryContact"},
+		},
+		{
+			title: "create client",
+			mutate: func(c *createsend.Client) error {
+				_, err := c.Clients().Create(clients.BasicDetails{})
+				return err
+			},
+			invalidates:  []string{"accounts.Clients"},
+			unaffectedBy: []string{"clients.Get", "accounts.Countries"},
+		},
+		{
+			title: "update client",
+			mutate: func(c *createsend.Client) error {
+				return c.Clients().Update("client_id", clients.BasicDetails{})
+			},
+			invalidates:  []string{"clients.Get", "accounts.Clients"},
+			unaffectedBy: []string{"clients.People"},
+		},
+		{
+			title: "set PAYG billing",
+			mutate: func(c *createsend.Client) error {
+				return c.Clients().SetPAYGBilling("client_id", clients.PAYGRates{})
+			},
+			invalidates:  []string{"clients.Get"},
+			unaffectedBy: []string{"accounts.Billing"},
+		},
+		{
+			title: "set monthly billing",
+			mutate: func(c *createsend.Client) error {
+				return c.Clients().SetMonthlyBilling("client_id", clients.MonthlyRates{})
+			},
+			invalidates:  []string{"clients.Get"},
+			unaffectedBy: []string{"accounts.Billing"},
+		},
+		{
+			title: "transfer credits",
+			mutate: func(c *createsend.Client) error {
+				_, err := c.Clients().TransferCredits("client_id", clients.CreditTransferRequest{})
+				return err
+			},
+			invalidates:  []string{"clients.Get", "accounts.Billing"},
+			unaffectedBy: []string{"accounts.Clients"},
+		},
+		{
+			title: "delete client",
+			mutate: func(c *createsend.Client) error {
+				return c.Clients().Delete("client_id")
+			},
+			invalidates:  []string{"clients.Get", "clients.People", "clients.PrimaryContact", "accounts.Clients"},
+			unaffectedBy: []string{"accounts.Countries", "accounts.Timezones"},
+		},
+		{
+			title: "add person",
+			mutate: func(c *createsend.Client) error {
+				_, err := c.Clients().AddPerson("client_id", clients.Person{})
+				return err
+			},
+			invalidates:  []string{"clients.People"},
+			unaffectedBy: []string{"clients.PrimaryContact"},
+		},
+		{
+			title: "update person",
+			mutate: func(c *createsend.Client) error {
+				_, err := c.Clients().UpdatePerson("client_id", "a@b.com", clients.Person{})
+				return err
+			},
+			invalidates:  []string{"clients.People", "clients.PrimaryContact"},
+			unaffectedBy: []string{"clients.Get"},
+		},
+		{
+			title: "delete person",
+			mutate: func(c *createsend.Client) error {
+				return c.Clients().DeletePerson("client_id", "a@b.com")
+			},
+			invalidates:  []string{"clients.People", "clients.PrimaryContact"},
+			unaffectedBy: []string{"clients.Get"},
+		},
+		{
+			title: "set client primary contact",
+			mutate: func(c *createsend.Client) error {
+				_, err := c.Clients().SetPrimaryContact("client_id", "a@b.com")
+				return err
+			},
+			invalidates:  []string{"clients.PrimaryContact"},
+			unaffectedBy: []string{"clients.People", "accounts.PrimaryContact"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, accountCalls, clientCalls := newCachedTestClient(t)
+			counter := func(r read) int {
+				if r.accountLevel {
+					return accountCalls.calls[r.method]
+				}
+				return clientCalls.calls[r.method]
+			}
+
+			affected := append(append([]string{}, tC.invalidates...), tC.unaffectedBy...)
+			for _, name := range affected {
+				r := readers[name]
+				for i := 0; i < 2; i++ {
+					if err := r.call(client); err != nil {
+						t.Fatalf("Did not expect an error but received: '%v'", err)
+					}
+				}
+				if calls := counter(r); calls != 1 {
+					t.Errorf("%s: the response must have been cached. Expected calls: 1, Actual: %d", name, calls)
+				}
+			}
+
+			if err := tC.mutate(client); err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+
+			for _, name := range affected {
+				r := readers[name]
+				if err := r.call(client); err != nil {
+					t.Fatalf("Did not expect an error but received: '%v'", err)
+				}
+			}
+			for _, name := range tC.invalidates {
+				if calls := counter(readers[name]); calls != 2 {
+					t.Errorf("%s: the cached response must have been invalidated. Expected calls: 2, Actual: %d", name, calls)
+				}
+			}
+			for _, name := range tC.unaffectedBy {
+				if calls := counter(readers[name]); calls != 1 {
+					t.Errorf("%s: the cached response must not have been invalidated. Expected calls: 1, Actual: %d", name, calls)
+				}
+			}
+		})
+	}
+}
+
+func TestCachedAPIs_Failures(t *testing.T) {
+	client, accountCalls, clientCalls := newCachedTestClient(t)
+	accountCalls.err = mock.ErrDeliberate
+	clientCalls.err = mock.ErrDeliberate
+
+	for i := 0; i < 2; i++ {
+		if countries, err := client.Accounts().Countries(); !errors.Is(err, mock.ErrDeliberate) || countries != nil {
+			t.Errorf("Expected '%v' error with no result, Actual: '%v', %v", mock.ErrDeliberate, err, countries)
+		}
+		if details, err := client.Clients().Get("client_id"); !errors.Is(err, mock.ErrDeliberate) || details != nil {
+			t.Errorf("Expected '%v' error with no result, Actual: '%v', %v", mock.ErrDeliberate, err, details)
+		}
+		if contact, err := client.Clients().PrimaryContact("client_id"); !errors.Is(err, mock.ErrDeliberate) || contact != "" {
+			t.Errorf("Expected '%v' error with no result, Actual: '%v', %q", mock.ErrDeliberate, err, contact)
+		}
+		if contact, err := client.Accounts().PrimaryContact(); !errors.Is(err, mock.ErrDeliberate) || contact != "" {
+			t.Errorf("Expected '%v' error with no result, Actual: '%v', %q", mock.ErrDeliberate, err, contact)
+		}
+		for _, call := range []func() error{
+			func() error { _, err := client.Accounts().Clients(); return err },
+			func() error { _, err := client.Accounts().Billing(); return err },
+			func() error { _, err := client.Accounts().Timezones(); return err },
+			func() error { _, err := client.Accounts().Administrators(); return err },
+			func() error { _, err := client.Clients().People("client_id"); return err },
+		} {
+			if err := call(); !errors.Is(err, mock.ErrDeliberate) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", mock.ErrDeliberate, err)
+			}
+		}
+	}
+
+	for method, calls := range accountCalls.calls {
+		if calls != 2 {
+			t.Errorf("accounts.%s: the failed responses must not be cached. Expected calls: 2, Actual: %d", method, calls)
+		}
+	}
+	for method, calls := range clientCalls.calls {
+		if calls != 2 {
+			t.Errorf("clients.%s: the failed responses must not be cached. Expected calls: 2, Actual: %d", method, calls)
+		}
+	}
+}
+
+func TestCachedAPIs_PassThrough(t *testing.T) {
+	client, _, clientCalls := newCachedTestClient(t)
+	for i := 0; i < 2; i++ {
+		if _, err := client.Clients().Lists("client_id"); err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+	}
+	if calls := clientCalls.calls["Lists"]; calls != 2 {
+		t.Errorf("The uncached methods must always call the API. Expected calls: 2, Actual: %d", calls)
+	}
+}
+
+func TestCachedAPIs_SessionsDoNotShareEntries(t *testing.T) {
+	counter := &apiCallCounter{calls: make(map[string]int)}
+	parent, err := createsend.New(
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAPIKey("account_key"),
+		createsend.WithAccountsAPI(accountsAPICounter{apiCallCounter: counter}),
+		createsend.WithClientsAPI(clientsAPICounter{apiCallCounter: counter}),
+		createsend.WithCache(cache.NewLRU(0, 0)),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if _, err := parent.Accounts().Clients(); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if _, err := parent.Clients().Get("other_client"); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	session, err := parent.ForClient("client_id")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := session.Accounts().Clients(); err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+		if _, err := session.Clients().Get("other_client"); err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+	}
+
+	// One call by the parent, one by ForClient and one by the session.
+	if counter.calls["Get"] != 3 {
+		t.Errorf("Expected clients.Get calls: 3, Actual: %d", counter.calls["Get"])
+	}
+	if counter.calls["Clients"] != 2 {
+		t.Errorf("Expected accounts.Clients calls: 2, Actual: %d", counter.calls["Clients"])
+	}
+}
+
+func TestCachedAPIs_ReturnCopies(t *testing.T) {
+	counter := &apiCallCounter{calls: make(map[string]int)}
+	client, err := createsend.New(
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAPIKey("account_key"),
+		createsend.WithAccountsAPI(accountsAPICounter{apiCallCounter: counter}),
+		createsend.WithClientsAPI(clientsAPICounter{apiCallCounter: counter}),
+		createsend.WithCache(cache.NewLRU(0, 0)),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	for i := 0; i < 3; i++ {
+		details, err := client.Clients().Get("client_id")
+		if err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+		if details.APIKey != "client_key" {
+			t.Errorf("Expected the cached API key to be intact, Actual: %q", details.APIKey)
+		}
+		details.APIKey = ""
+
+		list, err := client.Accounts().Clients()
+		if err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+		if len(list) != 1 || list[0].ID != "client_id" {
+			t.Errorf("Expected the cached clients to be intact, Actual: %v", list)
+		}
+		list[0].ID = ""
+		list[0] = nil
+
+		countries, err := client.Accounts().Countries()
+		if err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+		if countries[0] != "Australia" {
+			t.Errorf("Expected the cached countries to be intact, Actual: %v", countries)
+		}
+		countries[0] = ""
+	}
+	if counter.calls["Get"] != 1 || counter.calls["Clients"] != 1 || counter.calls["Countries"] != 1 {
+		t.Errorf("Expected the responses to be cached, Actual calls: %v", counter.calls)
+	}
+}
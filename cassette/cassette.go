@@ -0,0 +1,106 @@
+// Package cassette records real HTTP interactions with Campaign Monitor into cassette files, and replays them in tests.
+//
+// Both the recorder and the replayer implement createsend.HTTPClient, and can be plugged in using createsend.WithHTTPClient.
+package cassette
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/xitonix/createsend/internal"
+)
+
+// Version the current cassette format version.
+const Version = 1
+
+// ErrUnsupportedVersion occurs when the cassette format version is not supported.
+var ErrUnsupportedVersion = errors.New("unsupported cassette version")
+
+// sensitiveQueryKeys the query string parameters which carry credentials.
+var sensitiveQueryKeys = []string{"apikey", "api_key", "access_token", "refresh_token", "password"}
+
+// Cassette represents a list of recorded HTTP interactions.
+type Cassette struct {
+	// Version the cassette format version.
+	Version int
+	// Interactions the recorded interactions in the order they have been recorded.
+	Interactions []*Interaction
+}
+
+// Interaction represents a recorded request/response pair.
+type Interaction struct {
+	// Request the recorded request.
+	Request Request
+	// Response the recorded response.
+	Response Response
+}
+
+// Request represents a recorded HTTP request.
+type Request struct {
+	// Method the HTTP method.
+	Method string
+	// URL the path and the query string of the request URL, excluding the scheme and the host.
+	URL string
+	// Header the request headers with the credentials redacted.
+	Header http.Header `json:",omitempty"`
+	// Body the request body with the credentials redacted.
+	Body string `json:",omitempty"`
+}
+
+// Response represents a recorded HTTP response.
+type Response struct {
+	// StatusCode the HTTP status code.
+	StatusCode int
+	// Header the response headers with the credentials redacted.
+	Header http.Header `json:",omitempty"`
+	// Body the response body with the credentials redacted.
+	Body string `json:",omitempty"`
+}
+
+// Load loads a cassette from the specified file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	if c.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, c.Version)
+	}
+	return &c, nil
+}
+
+// Save saves the cassette into the specified file.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// redactURL returns the path and the query string of the URL with the sensitive query parameters redacted.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	for key := range query {
+		for _, sensitive := range sensitiveQueryKeys {
+			if strings.EqualFold(key, sensitive) {
+				query.Set(key, internal.Redacted)
+			}
+		}
+	}
+	redacted := url.URL{Path: u.Path, RawQuery: query.Encode()}
+	return redacted.String()
+}
+
+func redactBody(body []byte) string {
+	return string(internal.RedactBody(body))
+}
@@ -0,0 +1,275 @@
+package cassette_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cassette"
+	"github.com/xitonix/createsend/mock"
+)
+
+// server simulates Campaign Monitor, and counts the requests.
+type server struct {
+	requests int
+}
+
+func (s *server) Do(request *http.Request) (*http.Response, error) {
+	s.requests++
+	body := ""
+	switch request.URL.Path {
+	case "/api/v3.2/clients.json":
+		body = `[{"ClientID":"client_id","Name":"Client"}]`
+	case "/api/v3.2/clients/client_id.json":
+		body = `{"ApiKey":"client_secret","BasicDetails":{"ClientID":"client_id","CompanyName":"Company"}}`
+	case "/api/v3.2/admins.json":
+	default:
+		return &http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(strings.NewReader(`{"Code":404,"Message":"Not found"}`)),
+		}, nil
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}, "Set-Cookie": []string{"session=secret"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func newClient(t *testing.T, httpClient createsend.HTTPClient) *createsend.Client {
+	t.Helper()
+	client, err := createsend.New(createsend.WithAPIKey("account_secret"), createsend.WithHTTPClient(httpClient))
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return client
+}
+
+func exercise(t *testing.T, client *createsend.Client) {
+	t.Helper()
+	clients, err := client.Accounts().Clients()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff([]*accounts.Client{{ID: "client_id", Name: "Client"}}, clients); diff != "" {
+		t.Errorf("Clients mismatch (-expected +actual):\n%s", diff)
+	}
+	details, err := client.Clients().Get("client_id")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if details.Company != "Company" {
+		t.Errorf("Expected: Company, Actual: %s", details.Company)
+	}
+	if err := client.Accounts().AddAdministrator(accounts.Administrator{EmailAddress: "a@b.com", Name: "A"}); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+}
+
+func record(t *testing.T) (string, *server) {
+	t.Helper()
+	backend := &server{}
+	recorder := cassette.NewRecorder(backend)
+	exercise(t, newClient(t, recorder))
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	if err := recorder.Save(path); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return path, backend
+}
+
+func TestRecordReplay(t *testing.T) {
+	path, backend := record(t)
+	if backend.requests != 3 {
+		t.Fatalf("Expected 3 recorded requests, Actual: %d", backend.requests)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	for _, secret := range []string{"account_secret", "client_secret", "session=secret"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("Expected %q to be redacted from the cassette:\n%s", secret, data)
+		}
+	}
+
+	replayer, err := cassette.Open(path)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	exercise(t, newClient(t, replayer))
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Expected all the interactions to be served, Actual: %d unused", len(unused))
+	}
+	if backend.requests != 3 {
+		t.Errorf("Did not expect the replayer to hit the server, Actual requests: %d", backend.requests)
+	}
+
+	details, err := newClient(t, replayer).Clients().Get("client_id")
+	if err != nil {
+		t.Fatalf("Expected the last matching interaction to be served again, Actual: %v", err)
+	}
+	if details.APIKey != "[REDACTED]" {
+		t.Errorf("Expected the client API key to be redacted, Actual: %s", details.APIKey)
+	}
+}
+
+func TestReplayer_Matching(t *testing.T) {
+	c := &cassette.Cassette{
+		Version: cassette.Version,
+		Interactions: []*cassette.Interaction{
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URL: "/path?a=1&b=2"},
+				Response: cassette.Response{StatusCode: http.StatusOK, Body: "first"},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URL: "/path?a=1&b=2"},
+				Response: cassette.Response{StatusCode: http.StatusOK, Body: "second"},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodPost, URL: "/path", Body: `{"A":1,"B":"x"}`},
+				Response: cassette.Response{StatusCode: http.StatusCreated, Body: "json"},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodPost, URL: "/path", Body: "plain"},
+				Response: cassette.Response{StatusCode: http.StatusAccepted, Body: "plain"},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URL: "/secure?apikey=%5BREDACTED%5D"},
+				Response: cassette.Response{StatusCode: http.StatusOK, Body: "secure"},
+			},
+		},
+	}
+	testCases := []struct {
+		title         string
+		method        string
+		url           string
+		body          string
+		expectedBody  string
+		expectedError error
+	}{
+		{title: "first match", method: http.MethodGet, url: "http://host/path?b=2&a=1", expectedBody: "first"},
+		{title: "next match", method: http.MethodGet, url: "http://other/path?a=1&b=2", expectedBody: "second"},
+		{title: "repeated match", method: http.MethodGet, url: "http://host/path?a=1&b=2", expectedBody: "second"},
+		{title: "json body", method: http.MethodPost, url: "http://host/path", body: `{ "B": "x", "A": 1 }`, expectedBody: "json"},
+		{title: "plain body", method: http.MethodPost, url: "http://host/path", body: "plain", expectedBody: "plain"},
+		{title: "redacted query", method: http.MethodGet, url: "http://host/secure?apikey=real", expectedBody: "secure"},
+		{title: "different method", method: http.MethodPut, url: "http://host/path", expectedError: cassette.ErrNoInteraction},
+		{title: "different query", method: http.MethodGet, url: "http://host/path?a=2", expectedError: cassette.ErrNoInteraction},
+		{title: "different body", method: http.MethodPost, url: "http://host/path", body: `{"A":2}`, expectedError: cassette.ErrNoInteraction},
+		{title: "different path", method: http.MethodGet, url: "http://host/other", expectedError: cassette.ErrNoInteraction},
+	}
+	replayer := cassette.NewReplayer(c)
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var body io.Reader
+			if tC.body != "" {
+				body = strings.NewReader(tC.body)
+			}
+			request, _ := http.NewRequest(tC.method, tC.url, body)
+			response, err := replayer.Do(request)
+			if !errors.Is(err, tC.expectedError) {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			actual, _ := io.ReadAll(response.Body)
+			if string(actual) != tC.expectedBody {
+				t.Errorf("Expected: %s, Actual: %s", tC.expectedBody, actual)
+			}
+		})
+	}
+	if unused := replayer.Unused(); len(unused) != 0 {
+		t.Errorf("Expected all the interactions to be served, Actual: %d unused", len(unused))
+	}
+}
+
+func TestRecorder_Failures(t *testing.T) {
+	recorder := cassette.NewRecorder(mock.NewHTTPClientMock(mock.ForceToFail(true)))
+	request, _ := http.NewRequest(http.MethodGet, "http://host/path", nil)
+	if _, err := recorder.Do(request); err == nil {
+		t.Error("Expected an error, but received nil")
+	}
+
+	request, _ = http.NewRequest(http.MethodPost, "http://host/path", failingReader{})
+	if _, err := recorder.Do(request); !errors.Is(err, errRead) {
+		t.Errorf("Expected error: %v, Actual: %v", errRead, err)
+	}
+
+	recorder = cassette.NewRecorder(createsend.HTTPClientFunc(func(request *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(failingReader{})}, nil
+	}))
+	request, _ = http.NewRequest(http.MethodGet, "http://host/path", nil)
+	if _, err := recorder.Do(request); !errors.Is(err, errRead) {
+		t.Errorf("Expected error: %v, Actual: %v", errRead, err)
+	}
+	if len(recorder.Cassette().Interactions) != 0 {
+		t.Error("Did not expect the failed interactions to be recorded")
+	}
+
+	if err := recorder.Save(filepath.Join(t.TempDir(), "missing", "cassette.json")); err == nil {
+		t.Error("Expected an error, but received nil")
+	}
+	if recorder := cassette.NewRecorder(nil); recorder == nil {
+		t.Error("Expected a recorder with the default client")
+	}
+}
+
+func TestReplayer_ReadFailure(t *testing.T) {
+	replayer := cassette.NewReplayer(&cassette.Cassette{Version: cassette.Version})
+	request, _ := http.NewRequest(http.MethodPost, "http://host/path", failingReader{})
+	if _, err := replayer.Do(request); !errors.Is(err, errRead) {
+		t.Errorf("Expected error: %v, Actual: %v", errRead, err)
+	}
+}
+
+func TestOpen_Failure(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+	_ = os.WriteFile(invalid, []byte("{"), 0o644)
+	unsupported := filepath.Join(dir, "unsupported.json")
+	_ = os.WriteFile(unsupported, []byte(`{"Version":2}`), 0o644)
+
+	testCases := []struct {
+		title         string
+		path          string
+		expectedError error
+	}{
+		{title: "missing file", path: filepath.Join(dir, "missing.json"), expectedError: os.ErrNotExist},
+		{title: "invalid json", path: invalid},
+		{title: "unsupported version", path: unsupported, expectedError: cassette.ErrUnsupportedVersion},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			replayer, err := cassette.Open(tC.path)
+			if err == nil {
+				t.Fatal("Expected an error, but received nil")
+			}
+			if tC.expectedError != nil && !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if replayer != nil {
+				t.Error("Expected a nil replayer")
+			}
+		})
+	}
+}
+
+var errRead = errors.New("read error")
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
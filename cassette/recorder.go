@@ -0,0 +1,91 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/internal"
+)
+
+// Recorder records the HTTP interactions which go through the wrapped client.
+//
+// The Authorization header, the API keys and the other credentials are redacted before being recorded.
+type Recorder struct {
+	client   createsend.HTTPClient
+	lock     sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder creates a new recorder which sends the requests using the specified client.
+//
+// http.DefaultClient will be used if the client is nil.
+func NewRecorder(client createsend.HTTPClient) *Recorder {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Recorder{
+		client:   client,
+		cassette: &Cassette{Version: Version},
+	}
+}
+
+// Do sends the request using the wrapped client, and records the interaction.
+func (r *Recorder) Do(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	response, err := r.client.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: request.Method,
+			URL:    redactURL(request.URL),
+			Header: internal.RedactHeader(request.Header),
+			Body:   redactBody(requestBody),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     internal.RedactHeader(response.Header),
+			Body:       redactBody(responseBody),
+		},
+	})
+	return response, nil
+}
+
+// Cassette returns a copy of the cassette containing the interactions recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return &Cassette{
+		Version:      r.cassette.Version,
+		Interactions: append([]*Interaction(nil), r.cassette.Interactions...),
+	}
+}
+
+// Save saves the interactions recorded so far into the specified file.
+func (r *Recorder) Save(path string) error {
+	return r.Cassette().Save(path)
+}
@@ -0,0 +1,118 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrNoInteraction occurs when none of the recorded interactions matches the request.
+var ErrNoInteraction = errors.New("no matching interaction")
+
+// Replayer serves the recorded responses back without sending the requests to the server.
+//
+// The requests are matched against the recorded interactions on the method, the path, the query string and the body.
+// The interactions are served in the order they have been recorded. Once all the matching interactions have been
+// served, the last matching interaction will be served again.
+type Replayer struct {
+	cassette *Cassette
+	lock     sync.Mutex
+	served   []bool
+}
+
+// NewReplayer creates a new replayer which serves the interactions of the specified cassette.
+func NewReplayer(cassette *Cassette) *Replayer {
+	return &Replayer{
+		cassette: cassette,
+		served:   make([]bool, len(cassette.Interactions)),
+	}
+}
+
+// Open loads the cassette from the specified file, and creates a new replayer.
+func Open(path string) (*Replayer, error) {
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewReplayer(c), nil
+}
+
+// Do returns the recorded response of the first matching interaction which has not been served yet.
+func (r *Replayer) Do(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		_ = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	url := redactURL(request.URL)
+	redactedBody := redactBody(body)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	match := -1
+	for i, interaction := range r.cassette.Interactions {
+		if !matches(interaction.Request, request.Method, url, redactedBody) {
+			continue
+		}
+		match = i
+		if !r.served[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, request.Method, url)
+	}
+	r.served[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewBufferString(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       request,
+	}, nil
+}
+
+// Unused returns the interactions which have not been served yet.
+func (r *Replayer) Unused() []*Interaction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	var unused []*Interaction
+	for i, interaction := range r.cassette.Interactions {
+		if !r.served[i] {
+			unused = append(unused, interaction)
+		}
+	}
+	return unused
+}
+
+func matches(recorded Request, method, url, body string) bool {
+	return recorded.Method == method && recorded.URL == url && equalBodies(recorded.Body, body)
+}
+
+// equalBodies compares the JSON bodies regardless of the formatting, and the other bodies byte by byte.
+func equalBodies(a, b string) bool {
+	if a == b {
+		return true
+	}
+	var x, y interface{}
+	if json.Unmarshal([]byte(a), &x) != nil || json.Unmarshal([]byte(b), &y) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(x)
+	jb, _ := json.Marshal(y)
+	return bytes.Equal(ja, jb)
+}
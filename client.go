@@ -1,8 +1,13 @@
 package createsend
 
 import (
+	"strings"
+	"sync"
+
 	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cache"
 	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/subscribers"
 	"github.com/xitonix/createsend/transactional"
 )
 
@@ -11,6 +16,11 @@ type Client struct {
 	accounts      accounts.API
 	clients       clients.API
 	transactional transactional.API
+	subscribers   subscribers.API
+	options       *Options
+	clientID      string
+	lock          sync.Mutex
+	sessions      map[string]*Client
 }
 
 // New creates a new client.
@@ -19,23 +29,83 @@ func New(options ...Option) (*Client, error) {
 	for _, op := range options {
 		op(opts)
 	}
+	return newClient(opts)
+}
+
+func newClient(opts *Options) (*Client, error) {
+	var inst *instrumentation
+	middlewares := opts.middlewares
+	if opts.tracerProvider != nil || opts.meterProvider != nil {
+		var err error
+		inst, err = newInstrumentation(opts.tracerProvider, opts.meterProvider)
+		if err != nil {
+			return nil, err
+		}
+		middlewares = append(middlewares[:len(middlewares):len(middlewares)], inst.middleware)
+	}
 
-	hc, err := newHTTPClient(opts.ctx, opts.baseURL, opts.client, opts.auth)
+	hc, err := newHTTPClient(opts.ctx, opts.baseURL, opts.client, opts.auth, middlewares...)
 	if err != nil {
 		return nil, err
 	}
+	hc.logger = newRequestLogger(opts)
+	hc.location = newLocationResolver(opts)
 
-	client := &Client{}
-	if opts.accounts == nil {
+	client := &Client{
+		accounts:      opts.accounts,
+		clients:       opts.clients,
+		transactional: opts.transactional,
+		subscribers:   opts.subscribers,
+		options:       opts,
+		sessions:      make(map[string]*Client),
+	}
+
+	if client.accounts == nil {
 		client.accounts = newAccountAPI(hc)
+		if inst != nil {
+			client.accounts = &instrumentedAccountsAPI{client: hc, inst: inst}
+		}
 	}
 
-	if opts.clients == nil {
+	if client.clients == nil {
 		client.clients = newClientsAPI(hc)
+		if inst != nil {
+			client.clients = &instrumentedClientsAPI{client: hc, inst: inst}
+		}
 	}
 
-	if opts.transactional == nil {
+	if client.transactional == nil {
 		client.transactional = newTransactionalAPI(hc)
+		if inst != nil {
+			client.transactional = &instrumentedTransactionalAPI{client: hc, inst: inst}
+		}
+	}
+
+	if client.subscribers == nil {
+		client.subscribers = newSubscribersAPI(hc)
+		if inst != nil {
+			client.subscribers = &instrumentedSubscribersAPI{client: hc, inst: inst}
+		}
+	}
+
+	scope := credentialScope(opts.auth)
+	if opts.cache != nil {
+		client.accounts = newCachedAccountsAPI(client.accounts, opts.cache, scope)
+		client.clients = newCachedClientsAPI(client.clients, opts.cache, scope)
+	}
+
+	if opts.validation {
+		var references *referenceData
+		if opts.referenceDataValidation {
+			references = &referenceData{accounts: client.accounts}
+			if opts.cache == nil {
+				references.accounts = newCachedAccountsAPI(client.accounts, cache.NewLRU(0, 0), scope)
+			}
+		}
+		client.accounts = &validatedAccountsAPI{API: client.accounts}
+		client.clients = &validatedClientsAPI{API: client.clients, references: references}
+		client.transactional = &validatedTransactionalAPI{API: client.transactional}
+		client.subscribers = &validatedSubscribersAPI{API: client.subscribers}
 	}
 
 	return client, nil
@@ -55,3 +125,54 @@ func (c *Client) Clients() clients.API {
 func (c *Client) Transactional() transactional.API {
 	return c.transactional
 }
+
+// Subscribers accesses Campaign Monitor's subscribers API.
+func (c *Client) Subscribers() subscribers.API {
+	return c.subscribers
+}
+
+// ForClient returns a new client which is authenticated using the API key of the specified Campaign Monitor client.
+//
+// The client API key is fetched on the first call, and the session is cached for the lifetime of the current client.
+// The returned client shares the HTTP transport, middlewares and all the other options of the current client,
+// and does not need transactional.WithClientID to access the client's smart emails.
+func (c *Client) ForClient(clientID string) (*Client, error) {
+	c.lock.Lock()
+	session, ok := c.sessions[clientID]
+	c.lock.Unlock()
+	if ok {
+		return session, nil
+	}
+
+	// The lock is not held during the round trip, so that a slow client does not block the sessions of other clients.
+	details, err := c.clients.Get(clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey := strings.TrimSpace(details.APIKey)
+	if len(apiKey) == 0 {
+		return nil, newClientError(ErrCodeClientAPIKeyUnavailable)
+	}
+
+	opts := *c.options
+	opts.auth = &authentication{
+		token:  apiKey,
+		method: apiKeyAuthentication,
+	}
+	opts.clientID = clientID
+
+	session, err = newClient(&opts)
+	if err != nil {
+		return nil, err
+	}
+	session.clientID = clientID
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if existing, ok := c.sessions[clientID]; ok {
+		return existing, nil
+	}
+	c.sessions[clientID] = session
+	return session, nil
+}
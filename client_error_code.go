@@ -22,6 +22,12 @@ const (
 	ErrCodeInvalidJSON ClientErrorCode = -8
 	// ErrCodeInvalidRequestBody the provided request was invalid.
 	ErrCodeInvalidRequestBody ClientErrorCode = -9
+	// ErrCodeUnexpectedResponse the server returned a failure response which was not a Campaign Monitor error.
+	ErrCodeUnexpectedResponse ClientErrorCode = -10
+	// ErrCodeClientAPIKeyUnavailable the API key of the requested client was not returned by the server.
+	ErrCodeClientAPIKeyUnavailable ClientErrorCode = -11
+	// ErrCodeValidationFailed the request was rejected by the client side validation.
+	ErrCodeValidationFailed ClientErrorCode = -12
 )
 
 // String returns the string representation of the error code.
@@ -43,6 +49,12 @@ func (c ClientErrorCode) String() string {
 		return "invalid JSON data"
 	case ErrCodeInvalidRequestBody:
 		return "invalid request body"
+	case ErrCodeUnexpectedResponse:
+		return "unexpected server response"
+	case ErrCodeClientAPIKeyUnavailable:
+		return "the client API key is not available"
+	case ErrCodeValidationFailed:
+		return "validation failed"
 	default:
 		return "data processing error"
 	}
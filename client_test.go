@@ -1,9 +1,16 @@
 package createsend_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"net/http"
 	"testing"
 
 	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
 	"github.com/xitonix/createsend/mock"
 )
 
@@ -48,3 +55,186 @@ func TestNewClient(t *testing.T) {
 		})
 	}
 }
+
+func TestNewClientWithOverriddenAPIs(t *testing.T) {
+	accountsAPI := accountsAPIStub{}
+	client, err := createsend.New(
+		createsend.WithAPIKey("api_key"),
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAccountsAPI(accountsAPI),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if _, ok := client.Accounts().(accountsAPIStub); !ok {
+		t.Errorf("The overridden Accounts API must be used")
+	}
+	if client.Clients() == nil {
+		t.Errorf("Clients API should not be nil")
+	}
+	if client.Transactional() == nil {
+		t.Errorf("Transactional API should not be nil")
+	}
+	if client.Subscribers() == nil {
+		t.Errorf("Subscribers API should not be nil")
+	}
+}
+
+type accountsAPIStub struct {
+	accounts.API
+}
+
+func TestClient_ForClient(t *testing.T) {
+	testCases := []struct {
+		title         string
+		response      *http.Response
+		expectedError error
+		expectedAuth  string
+	}{
+		{
+			title: "client with API key",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"ApiKey":"client_key","BasicDetails":{"ClientID":"client_id"}}`)),
+			},
+			expectedAuth: "Basic " + base64.StdEncoding.EncodeToString([]byte("client_key:client_key")),
+		},
+		{
+			title: "client without API key",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"BasicDetails":{"ClientID":"client_id"}}`)),
+			},
+			expectedError: &createsend.Error{Code: int(createsend.ErrCodeClientAPIKeyUnavailable)},
+		},
+		{
+			title: "server side error",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":203,"Message":"Invalid ClientID"}`)),
+			},
+			expectedError: createsend.ErrClientNotFound,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var middlewareCalls int
+			counter := func(next createsend.HTTPClient) createsend.HTTPClient {
+				return createsend.HTTPClientFunc(func(request *http.Request) (*http.Response, error) {
+					middlewareCalls++
+					return next.Do(request)
+				})
+			}
+
+			var lastAuth string
+			httpClient := mock.NewHTTPClientMock(mock.WhenCalled(func(request *http.Request) {
+				lastAuth = request.Header.Get("Authorization")
+			}))
+			httpClient.SetResponse("clients/client_id.json", tC.response)
+			httpClient.SetResponse("transactional/smartEmail", &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			})
+
+			parent, err := createsend.New(
+				createsend.WithBaseURL("https://base.com"),
+				createsend.WithHTTPClient(httpClient),
+				createsend.WithAPIKey("account_key"),
+				createsend.WithMiddleware(counter),
+			)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+
+			session, err := parent.ForClient("client_id")
+			if tC.expectedError != nil {
+				if !errors.Is(err, tC.expectedError) {
+					t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+				}
+				if session != nil {
+					t.Error("The session must be nil on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+
+			_, err = session.Transactional().SmartEmails()
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if lastAuth != tC.expectedAuth {
+				t.Errorf("Expected authorization header: %q, Actual: %q", tC.expectedAuth, lastAuth)
+			}
+			if middlewareCalls != 2 {
+				t.Errorf("The session must share the parent's middlewares. Expected calls: 2, Actual: %d", middlewareCalls)
+			}
+
+			cached, err := parent.ForClient("client_id")
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if cached != session {
+				t.Error("The client session must have been cached")
+			}
+			if count := httpClient.Count("/clients/client_id.json"); count != 1 {
+				t.Errorf("Expected number of client details calls: 1, Actual: %d", count)
+			}
+		})
+	}
+}
+
+// blockingClientsAPI blocks the client details calls of the specified client until released.
+type blockingClientsAPI struct {
+	clients.API
+	blockedID string
+	entered   chan struct{}
+	release   chan struct{}
+}
+
+func (b *blockingClientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
+	if clientID == b.blockedID {
+		close(b.entered)
+		<-b.release
+	}
+	return &clients.ClientDetails{APIKey: clientID + "_key"}, nil
+}
+
+func TestClient_ForClient_Concurrent(t *testing.T) {
+	api := &blockingClientsAPI{blockedID: "slow", entered: make(chan struct{}), release: make(chan struct{})}
+	parent, err := createsend.New(
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAPIKey("account_key"),
+		createsend.WithClientsAPI(api),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	slow := make(chan *createsend.Client)
+	go func() {
+		session, _ := parent.ForClient("slow")
+		slow <- session
+	}()
+	<-api.entered
+
+	fast, err := parent.ForClient("fast")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if fast == nil {
+		t.Fatal("Expected a session for the fast client")
+	}
+	close(api.release)
+	session := <-slow
+
+	cached, err := parent.ForClient("slow")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if cached != session {
+		t.Error("The client session must have been cached")
+	}
+}
@@ -0,0 +1,101 @@
+package clients
+
+import "strings"
+
+// AccessLevel represents the access level of a person within a client as a combination of access flags.
+type AccessLevel int
+
+const (
+	// UnknownAccessLevel the access level has not been returned by the server.
+	UnknownAccessLevel AccessLevel = -1
+	// NoAccess no access to any features.
+	NoAccess AccessLevel = 0
+)
+
+const (
+	// AccessReports access to the reports.
+	AccessReports AccessLevel = 1 << iota
+	// AccessSubscribers access to the subscribers.
+	AccessSubscribers
+	// AccessCreateSendCampaigns access to create and send campaigns.
+	AccessCreateSendCampaigns
+	// AccessDesignSpamTest access to design and spam tests.
+	AccessDesignSpamTest
+	// AccessImportSubscribers access to import subscribers.
+	AccessImportSubscribers
+	// AccessImportURL access to import from a URL.
+	AccessImportURL
+	// AccessManageLists access to manage the lists.
+	AccessManageLists
+)
+
+const (
+	// ReportsOnlyAccess read only access to the reports.
+	ReportsOnlyAccess = AccessReports
+	// CampaignManagerAccess access to the reports, subscribers, campaigns and subscriber imports.
+	CampaignManagerAccess = AccessReports | AccessSubscribers | AccessCreateSendCampaigns | AccessImportSubscribers
+	// FullAccess access to all the features.
+	FullAccess = AccessReports | AccessSubscribers | AccessCreateSendCampaigns | AccessDesignSpamTest |
+		AccessImportSubscribers | AccessImportURL | AccessManageLists
+)
+
+var accessLevelNames = []struct {
+	flag AccessLevel
+	name string
+}{
+	{AccessReports, "reports"},
+	{AccessSubscribers, "subscribers"},
+	{AccessCreateSendCampaigns, "create/send campaigns"},
+	{AccessDesignSpamTest, "design/spam test"},
+	{AccessImportSubscribers, "import subscribers"},
+	{AccessImportURL, "import URL"},
+	{AccessManageLists, "manage lists"},
+}
+
+// Has returns true if all the specified access flags are granted.
+func (a AccessLevel) Has(flags AccessLevel) bool {
+	if a == UnknownAccessLevel {
+		return false
+	}
+	return a&flags == flags
+}
+
+// With returns a new access level with the specified access flags granted.
+func (a AccessLevel) With(flags AccessLevel) AccessLevel {
+	if a == UnknownAccessLevel {
+		a = NoAccess
+	}
+	return a | flags
+}
+
+// Without returns a new access level with the specified access flags revoked.
+func (a AccessLevel) Without(flags AccessLevel) AccessLevel {
+	if a == UnknownAccessLevel {
+		return NoAccess
+	}
+	return a &^ flags
+}
+
+// IsValid returns true if the access level only consists of the known access flags.
+func (a AccessLevel) IsValid() bool {
+	return a >= NoAccess && a&^FullAccess == 0
+}
+
+// String Stringer implementation
+func (a AccessLevel) String() string {
+	switch {
+	case a == UnknownAccessLevel:
+		return "unknown"
+	case a == NoAccess:
+		return "none"
+	case a == FullAccess:
+		return "full"
+	}
+	names := make([]string, 0, len(accessLevelNames))
+	for _, flag := range accessLevelNames {
+		if a.Has(flag.flag) {
+			names = append(names, flag.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
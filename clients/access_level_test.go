@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAccessLevel_Has(t *testing.T) {
+	testCases := []struct {
+		title    string
+		level    AccessLevel
+		flags    AccessLevel
+		expected bool
+	}{
+		{
+			title:    "single granted flag",
+			level:    CampaignManagerAccess,
+			flags:    AccessReports,
+			expected: true,
+		},
+		{
+			title:    "multiple granted flags",
+			level:    CampaignManagerAccess,
+			flags:    AccessReports | AccessImportSubscribers,
+			expected: true,
+		},
+		{
+			title: "partially granted flags",
+			level: CampaignManagerAccess,
+			flags: AccessReports | AccessManageLists,
+		},
+		{
+			title: "no access",
+			level: NoAccess,
+			flags: AccessReports,
+		},
+		{
+			title:    "full access",
+			level:    FullAccess,
+			flags:    AccessManageLists | AccessImportURL | AccessDesignSpamTest,
+			expected: true,
+		},
+		{
+			title: "unknown access level",
+			level: UnknownAccessLevel,
+			flags: AccessReports,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			if actual := tC.level.Has(tC.flags); actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccessLevel_WithWithout(t *testing.T) {
+	level := NoAccess.With(AccessReports | AccessSubscribers)
+	if level != 3 {
+		t.Errorf("Expected: 3, Actual: %d", level)
+	}
+	if level = level.Without(AccessSubscribers); level != ReportsOnlyAccess {
+		t.Errorf("Expected: %d, Actual: %d", ReportsOnlyAccess, level)
+	}
+	if level = UnknownAccessLevel.With(AccessManageLists); level != AccessManageLists {
+		t.Errorf("Expected: %d, Actual: %d", AccessManageLists, level)
+	}
+	if level = UnknownAccessLevel.Without(AccessManageLists); level != NoAccess {
+		t.Errorf("Expected: %d, Actual: %d", NoAccess, level)
+	}
+}
+
+func TestAccessLevel_IsValid(t *testing.T) {
+	testCases := []struct {
+		level    AccessLevel
+		expected bool
+	}{
+		{NoAccess, true},
+		{CampaignManagerAccess, true},
+		{FullAccess, true},
+		{UnknownAccessLevel, false},
+		{128, false},
+		{FullAccess + 1, false},
+	}
+	for _, tC := range testCases {
+		if actual := tC.level.IsValid(); actual != tC.expected {
+			t.Errorf("%d: Expected: %v, Actual: %v", tC.level, tC.expected, actual)
+		}
+	}
+}
+
+func TestAccessLevel_String(t *testing.T) {
+	testCases := []struct {
+		level    AccessLevel
+		expected string
+	}{
+		{UnknownAccessLevel, "unknown"},
+		{NoAccess, "none"},
+		{FullAccess, "full"},
+		{ReportsOnlyAccess, "reports"},
+		{CampaignManagerAccess, "reports, subscribers, create/send campaigns, import subscribers"},
+		{AccessImportURL | AccessManageLists | AccessDesignSpamTest, "design/spam test, import URL, manage lists"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.expected, func(t *testing.T) {
+			if actual := tC.level.String(); actual != tC.expected {
+				t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAccessLevel_JSON(t *testing.T) {
+	var person PersonBasicDetails
+	if err := json.Unmarshal([]byte(`{"AccessLevel":23}`), &person); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if person.AccessLevel != CampaignManagerAccess {
+		t.Errorf("Expected: %d, Actual: %d", CampaignManagerAccess, person.AccessLevel)
+	}
+	marshalled, err := json.Marshal(person)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if expected := `{"EmailAddress":"","Name":"","AccessLevel":23}`; string(marshalled) != expected {
+		t.Errorf("Expected: %s, Actual: %s", expected, marshalled)
+	}
+}
@@ -1,5 +1,7 @@
 package clients
 
+import "github.com/xitonix/createsend/money"
+
 // BillingMode billing mode.
 type BillingMode int8
 
@@ -21,7 +23,7 @@ type BillingDetails struct {
 	// ClientPays is true if the client pays for itself.
 	ClientPays bool
 	// Currency the current billing currency.
-	Currency string
+	Currency money.Currency
 }
 
 // MonthlyBillingDetails represents monthly billing details if the Client is on a monthly plan.
@@ -31,7 +33,7 @@ type MonthlyBillingDetails struct {
 	// Scheme the current scheme (eg. Unlimited, Basic, etc).
 	Scheme string
 	// Rate the current rate.
-	Rate float64
+	Rate money.Decimal
 	// MarkupPercentage the current markup percentage value.
 	MarkupPercentage int64
 	// Pending returns the plan pending for approval (if any).
@@ -45,15 +47,15 @@ type PayAsYouGoBillingDetails struct {
 	// Credits the number of Campaign Monitor credits.
 	Credits int64
 	// MarkupOnDesignSpamTest the current markup value on design and spam testing.
-	MarkupOnDesignSpamTest float64
+	MarkupOnDesignSpamTest money.Decimal
 	// BaseRatePerRecipient the current rate per recipient.
-	BaseRatePerRecipient float64
+	BaseRatePerRecipient money.Decimal
 	// MarkupPerRecipient the current value of markup per recipient.
-	MarkupPerRecipient float64
+	MarkupPerRecipient money.Decimal
 	// MarkupOnDelivery the current value of markup on delivery.
-	MarkupOnDelivery float64
+	MarkupOnDelivery money.Decimal
 	// BaseDeliveryRate the current value of base delivery rate.
-	BaseDeliveryRate float64
+	BaseDeliveryRate money.Decimal
 	// BaseDesignSpamTestRate the base rate for design & spam tests.
-	BaseDesignSpamTestRate float64
+	BaseDesignSpamTestRate money.Decimal
 }
@@ -1,9 +1,11 @@
 package clients
 
+import "github.com/xitonix/createsend/money"
+
 // PAYGRates represents PAYG billing rates.
 type PAYGRates struct {
 	// Currency the billing currency.
-	Currency string
+	Currency money.Currency
 	// CanPurchaseCredits is true if the Client is allowed to purchase credit.
 	CanPurchaseCredits bool
 	// ClientPays is true if the client pays for itself.
@@ -11,17 +13,17 @@ type PAYGRates struct {
 	// MarkupPercentage markup percentage value.
 	MarkupPercentage int64
 	// MarkupOnDelivery markup on delivery.
-	MarkupOnDelivery float64
+	MarkupOnDelivery money.Decimal
 	// MarkupPerRecipient markup per recipient.
-	MarkupPerRecipient float64
+	MarkupPerRecipient money.Decimal
 	// MarkupOnDesignSpamTest markup value on design and spam testing.
-	MarkupOnDesignSpamTest float64
+	MarkupOnDesignSpamTest money.Decimal
 }
 
 // MonthlyRates represents monthly billing rates.
 type MonthlyRates struct {
 	// Currency the billing currency.
-	Currency string
+	Currency money.Currency
 	// ClientPays is true if the client pays for itself.
 	ClientPays bool
 	// MarkupPercentage markup percentage value.
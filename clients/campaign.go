@@ -3,6 +3,8 @@ package clients
 import (
 	"net/mail"
 	"time"
+
+	"github.com/xitonix/createsend/timezone"
 )
 
 // Campaign represents a Campaign.
@@ -42,7 +44,7 @@ type ScheduledCampaign struct {
 	// DateScheduled the time when the Campaign will be sent.
 	DateScheduled time.Time
 	// Timezone schedule timezone.
-	Timezone string
+	Timezone timezone.Timezone
 }
 
 // DraftCampaign represents a draft Campaign.
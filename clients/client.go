@@ -1,5 +1,7 @@
 package clients
 
+import "github.com/xitonix/createsend/timezone"
+
 // BasicDetails represents a client's basic details.
 type BasicDetails struct {
 	// Company company name.
@@ -7,5 +9,5 @@ type BasicDetails struct {
 	// Country country.
 	Country string
 	// Timezone client timezone (eg. "(GMT+10:00) Canberra, Melbourne, Sydney").
-	Timezone string `json:"TimeZone"`
+	Timezone timezone.Timezone `json:"TimeZone"`
 }
@@ -1,5 +1,7 @@
 package clients
 
+import "github.com/xitonix/createsend/timezone"
+
 // ContactDetails represents the contact details of a Person within a Client.
 type ContactDetails struct {
 	// Name contact's name.
@@ -7,7 +9,7 @@ type ContactDetails struct {
 	// EmailAddress contact's email address.
 	EmailAddress string
 	// AccessLevel access level
-	AccessLevel int
+	AccessLevel AccessLevel
 	// Username username.
 	Username string
 }
@@ -23,7 +25,7 @@ type ClientDetails struct {
 	// Country country
 	Country string
 	// Timezone timezone
-	Timezone string
+	Timezone timezone.Timezone
 	// Contact the contact details and access level of the Person within the client.
 	//
 	// If there are multiple Persons in this Client, or no Persons at all, this will be nil.
@@ -13,7 +13,7 @@ type List struct {
 // Subscriber represents a subscriber.
 type Subscriber struct {
 	// State the subscription state.
-	State string
+	State SubscriberState
 	// DateAdded the date the subscriber has subscribed in the client’s timezone.
 	DateAdded time.Time
 }
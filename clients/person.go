@@ -7,14 +7,14 @@ type PersonBasicDetails struct {
 	// Name the name.
 	Name string
 	// AccessLevel access level.
-	AccessLevel int
+	AccessLevel AccessLevel
 }
 
 // PersonDetails represents a person's details.
 type PersonDetails struct {
 	PersonBasicDetails
 	// Status the person's status (eg. Active)
-	Status string
+	Status PersonStatus
 }
 
 // Person represents a person.
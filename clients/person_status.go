@@ -0,0 +1,61 @@
+package clients
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// PersonStatus represents the status of a person within a client.
+type PersonStatus uint8
+
+const (
+	// UnknownPerson unknown status.
+	UnknownPerson PersonStatus = iota
+	// ActivePerson active person.
+	ActivePerson
+	// InvitedPerson the person has been invited, but has not accepted the invitation yet.
+	InvitedPerson
+)
+
+const (
+	personUnknownStr = `unknown`
+	personActiveStr  = `active`
+	personInvitedStr = `waiting to accept the invitation`
+)
+
+var (
+	personStatusToValue = map[string]PersonStatus{
+		personActiveStr:  ActivePerson,
+		personInvitedStr: InvitedPerson,
+	}
+
+	personStatusFromValue = map[PersonStatus]string{
+		ActivePerson:  personActiveStr,
+		InvitedPerson: personInvitedStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (p PersonStatus) MarshalJSON() ([]byte, error) {
+	typeStr, ok := personStatusFromValue[p]
+	if !ok {
+		return json.Marshal(personUnknownStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (p *PersonStatus) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	rt, ok := personStatusToValue[value]
+	if !ok {
+		rt = UnknownPerson
+	}
+	*p = rt
+	return nil
+}
+
+// String Stringer implementation
+func (p PersonStatus) String() string {
+	return personStatusFromValue[p]
+}
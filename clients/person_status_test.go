@@ -0,0 +1,90 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPersonStatus_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		value    PersonStatus
+		expected string
+	}{
+		{
+			title:    "Unknown",
+			expected: fmt.Sprintf("%q", personUnknownStr),
+		},
+		{
+			title:    "Active",
+			value:    ActivePerson,
+			expected: fmt.Sprintf("%q", personActiveStr),
+		},
+		{
+			title:    "Invited",
+			value:    InvitedPerson,
+			expected: fmt.Sprintf("%q", personInvitedStr),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.value.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestPersonStatus_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected PersonStatus
+	}{
+		{
+			title:    "Unknown",
+			input:    `"Unknown"`,
+			expected: UnknownPerson,
+		},
+		{
+			title:    "random string",
+			input:    `"random"`,
+			expected: UnknownPerson,
+		},
+		{
+			title:    "Active lowercase",
+			input:    `"active"`,
+			expected: ActivePerson,
+		},
+		{
+			title:    "Active title case",
+			input:    `"Active"`,
+			expected: ActivePerson,
+		},
+		{
+			title:    "Invited lowercase",
+			input:    `"waiting to accept the invitation"`,
+			expected: InvitedPerson,
+		},
+		{
+			title:    "Invited title case",
+			input:    `"Waiting To Accept The Invitation"`,
+			expected: InvitedPerson,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var value PersonStatus
+			err := value.UnmarshalJSON([]byte(tC.input))
+			if err != nil {
+				t.Errorf("Expected error: nil, Actual: %q", err)
+			}
+			if tC.expected != value {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, value)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package clients
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SubscriberState represents the state of a subscriber within a list.
+type SubscriberState uint8
+
+const (
+	// UnknownSubscriber unknown state.
+	UnknownSubscriber SubscriberState = iota
+	// ActiveSubscriber active subscriber.
+	ActiveSubscriber
+	// UnconfirmedSubscriber the subscriber has not confirmed the subscription yet.
+	UnconfirmedSubscriber
+	// UnsubscribedSubscriber the subscriber has unsubscribed from the list.
+	UnsubscribedSubscriber
+	// BouncedSubscriber the emails sent to the subscriber have bounced.
+	BouncedSubscriber
+	// DeletedSubscriber the subscriber has been deleted from the list.
+	DeletedSubscriber
+)
+
+const (
+	subscriberUnknownStr      = `unknown`
+	subscriberActiveStr       = `active`
+	subscriberUnconfirmedStr  = `unconfirmed`
+	subscriberUnsubscribedStr = `unsubscribed`
+	subscriberBouncedStr      = `bounced`
+	subscriberDeletedStr      = `deleted`
+)
+
+var (
+	subscriberStateToValue = map[string]SubscriberState{
+		subscriberActiveStr:       ActiveSubscriber,
+		subscriberUnconfirmedStr:  UnconfirmedSubscriber,
+		subscriberUnsubscribedStr: UnsubscribedSubscriber,
+		subscriberBouncedStr:      BouncedSubscriber,
+		subscriberDeletedStr:      DeletedSubscriber,
+	}
+
+	subscriberStateFromValue = map[SubscriberState]string{
+		ActiveSubscriber:       subscriberActiveStr,
+		UnconfirmedSubscriber:  subscriberUnconfirmedStr,
+		UnsubscribedSubscriber: subscriberUnsubscribedStr,
+		BouncedSubscriber:      subscriberBouncedStr,
+		DeletedSubscriber:      subscriberDeletedStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (s SubscriberState) MarshalJSON() ([]byte, error) {
+	typeStr, ok := subscriberStateFromValue[s]
+	if !ok {
+		return json.Marshal(subscriberUnknownStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (s *SubscriberState) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	rt, ok := subscriberStateToValue[value]
+	if !ok {
+		rt = UnknownSubscriber
+	}
+	*s = rt
+	return nil
+}
+
+// String Stringer implementation
+func (s SubscriberState) String() string {
+	return subscriberStateFromValue[s]
+}
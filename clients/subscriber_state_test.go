@@ -0,0 +1,135 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubscriberState_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		value    SubscriberState
+		expected string
+	}{
+		{
+			title:    "Unknown",
+			expected: fmt.Sprintf("%q", subscriberUnknownStr),
+		},
+		{
+			title:    "Active",
+			value:    ActiveSubscriber,
+			expected: fmt.Sprintf("%q", subscriberActiveStr),
+		},
+		{
+			title:    "Unconfirmed",
+			value:    UnconfirmedSubscriber,
+			expected: fmt.Sprintf("%q", subscriberUnconfirmedStr),
+		},
+		{
+			title:    "Unsubscribed",
+			value:    UnsubscribedSubscriber,
+			expected: fmt.Sprintf("%q", subscriberUnsubscribedStr),
+		},
+		{
+			title:    "Bounced",
+			value:    BouncedSubscriber,
+			expected: fmt.Sprintf("%q", subscriberBouncedStr),
+		},
+		{
+			title:    "Deleted",
+			value:    DeletedSubscriber,
+			expected: fmt.Sprintf("%q", subscriberDeletedStr),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.value.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestSubscriberState_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected SubscriberState
+	}{
+		{
+			title:    "Unknown",
+			input:    `"Unknown"`,
+			expected: UnknownSubscriber,
+		},
+		{
+			title:    "random string",
+			input:    `"random"`,
+			expected: UnknownSubscriber,
+		},
+		{
+			title:    "Active lowercase",
+			input:    `"active"`,
+			expected: ActiveSubscriber,
+		},
+		{
+			title:    "Active title case",
+			input:    `"Active"`,
+			expected: ActiveSubscriber,
+		},
+		{
+			title:    "Unconfirmed lowercase",
+			input:    `"unconfirmed"`,
+			expected: UnconfirmedSubscriber,
+		},
+		{
+			title:    "Unconfirmed title case",
+			input:    `"Unconfirmed"`,
+			expected: UnconfirmedSubscriber,
+		},
+		{
+			title:    "Unsubscribed lowercase",
+			input:    `"unsubscribed"`,
+			expected: UnsubscribedSubscriber,
+		},
+		{
+			title:    "Unsubscribed title case",
+			input:    `"Unsubscribed"`,
+			expected: UnsubscribedSubscriber,
+		},
+		{
+			title:    "Bounced lowercase",
+			input:    `"bounced"`,
+			expected: BouncedSubscriber,
+		},
+		{
+			title:    "Bounced title case",
+			input:    `"Bounced"`,
+			expected: BouncedSubscriber,
+		},
+		{
+			title:    "Deleted lowercase",
+			input:    `"deleted"`,
+			expected: DeletedSubscriber,
+		},
+		{
+			title:    "Deleted title case",
+			input:    `"Deleted"`,
+			expected: DeletedSubscriber,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var value SubscriberState
+			err := value.UnmarshalJSON([]byte(tC.input))
+			if err != nil {
+				t.Errorf("Expected error: nil, Actual: %q", err)
+			}
+			if tC.expected != value {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, value)
+			}
+		})
+	}
+}
@@ -9,13 +9,13 @@ import (
 // SuppressionDetails represents a suppression list item.
 type SuppressionDetails struct {
 	// Reason reason for suppression.
-	Reason string
+	Reason SuppressionReason
 	// EmailAddress the suppressed email address.
 	EmailAddress string
 	// Date the date when the email address has been added to the suppression list.
 	Date time.Time
 	// State the state of the suppressed email address.
-	State string
+	State SuppressionState
 }
 
 // SuppressionList represents client suppression list.
@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SuppressionReason represents the reason for which an email address has been suppressed.
+type SuppressionReason uint8
+
+const (
+	// UnknownSuppressionReason unknown reason.
+	UnknownSuppressionReason SuppressionReason = iota
+	// UnsubscribedSuppression the recipient has unsubscribed.
+	UnsubscribedSuppression
+	// BouncedSuppression the emails sent to the recipient have bounced.
+	BouncedSuppression
+	// SpamComplaintSuppression the recipient has marked an email as spam.
+	SpamComplaintSuppression
+	// ManualSuppression the email address has been suppressed manually.
+	ManualSuppression
+)
+
+const (
+	suppressionReasonUnknownStr       = `unknown`
+	suppressionReasonUnsubscribedStr  = `unsubscribed`
+	suppressionReasonBouncedStr       = `bounced`
+	suppressionReasonSpamComplaintStr = `spam complaint`
+	suppressionReasonManualStr        = `manually suppressed`
+)
+
+var (
+	suppressionReasonToValue = map[string]SuppressionReason{
+		suppressionReasonUnsubscribedStr:  UnsubscribedSuppression,
+		suppressionReasonBouncedStr:       BouncedSuppression,
+		suppressionReasonSpamComplaintStr: SpamComplaintSuppression,
+		suppressionReasonManualStr:        ManualSuppression,
+	}
+
+	suppressionReasonFromValue = map[SuppressionReason]string{
+		UnsubscribedSuppression:  suppressionReasonUnsubscribedStr,
+		BouncedSuppression:       suppressionReasonBouncedStr,
+		SpamComplaintSuppression: suppressionReasonSpamComplaintStr,
+		ManualSuppression:        suppressionReasonManualStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (s SuppressionReason) MarshalJSON() ([]byte, error) {
+	typeStr, ok := suppressionReasonFromValue[s]
+	if !ok {
+		return json.Marshal(suppressionReasonUnknownStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (s *SuppressionReason) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	rt, ok := suppressionReasonToValue[value]
+	if !ok {
+		rt = UnknownSuppressionReason
+	}
+	*s = rt
+	return nil
+}
+
+// String Stringer implementation
+func (s SuppressionReason) String() string {
+	return suppressionReasonFromValue[s]
+}
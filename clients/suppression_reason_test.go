@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSuppressionReason_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		value    SuppressionReason
+		expected string
+	}{
+		{
+			title:    "Unknown",
+			expected: fmt.Sprintf("%q", suppressionReasonUnknownStr),
+		},
+		{
+			title:    "Unsubscribed",
+			value:    UnsubscribedSuppression,
+			expected: fmt.Sprintf("%q", suppressionReasonUnsubscribedStr),
+		},
+		{
+			title:    "Bounced",
+			value:    BouncedSuppression,
+			expected: fmt.Sprintf("%q", suppressionReasonBouncedStr),
+		},
+		{
+			title:    "SpamComplaint",
+			value:    SpamComplaintSuppression,
+			expected: fmt.Sprintf("%q", suppressionReasonSpamComplaintStr),
+		},
+		{
+			title:    "Manual",
+			value:    ManualSuppression,
+			expected: fmt.Sprintf("%q", suppressionReasonManualStr),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.value.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestSuppressionReason_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected SuppressionReason
+	}{
+		{
+			title:    "Unknown",
+			input:    `"Unknown"`,
+			expected: UnknownSuppressionReason,
+		},
+		{
+			title:    "random string",
+			input:    `"random"`,
+			expected: UnknownSuppressionReason,
+		},
+		{
+			title:    "Unsubscribed lowercase",
+			input:    `"unsubscribed"`,
+			expected: UnsubscribedSuppression,
+		},
+		{
+			title:    "Unsubscribed title case",
+			input:    `"Unsubscribed"`,
+			expected: UnsubscribedSuppression,
+		},
+		{
+			title:    "Bounced lowercase",
+			input:    `"bounced"`,
+			expected: BouncedSuppression,
+		},
+		{
+			title:    "Bounced title case",
+			input:    `"Bounced"`,
+			expected: BouncedSuppression,
+		},
+		{
+			title:    "SpamComplaint lowercase",
+			input:    `"spam complaint"`,
+			expected: SpamComplaintSuppression,
+		},
+		{
+			title:    "SpamComplaint title case",
+			input:    `"Spam Complaint"`,
+			expected: SpamComplaintSuppression,
+		},
+		{
+			title:    "Manual lowercase",
+			input:    `"manually suppressed"`,
+			expected: ManualSuppression,
+		},
+		{
+			title:    "Manual title case",
+			input:    `"Manually Suppressed"`,
+			expected: ManualSuppression,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var value SuppressionReason
+			err := value.UnmarshalJSON([]byte(tC.input))
+			if err != nil {
+				t.Errorf("Expected error: nil, Actual: %q", err)
+			}
+			if tC.expected != value {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, value)
+			}
+		})
+	}
+}
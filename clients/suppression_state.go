@@ -0,0 +1,56 @@
+package clients
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SuppressionState represents the state of a suppressed email address.
+type SuppressionState uint8
+
+const (
+	// UnknownSuppressionState unknown state.
+	UnknownSuppressionState SuppressionState = iota
+	// Suppressed the email address is suppressed.
+	Suppressed
+)
+
+const (
+	suppressionStateUnknownStr    = `unknown`
+	suppressionStateSuppressedStr = `suppressed`
+)
+
+var (
+	suppressionStateToValue = map[string]SuppressionState{
+		suppressionStateSuppressedStr: Suppressed,
+	}
+
+	suppressionStateFromValue = map[SuppressionState]string{
+		Suppressed: suppressionStateSuppressedStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (s SuppressionState) MarshalJSON() ([]byte, error) {
+	typeStr, ok := suppressionStateFromValue[s]
+	if !ok {
+		return json.Marshal(suppressionStateUnknownStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (s *SuppressionState) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	rt, ok := suppressionStateToValue[value]
+	if !ok {
+		rt = UnknownSuppressionState
+	}
+	*s = rt
+	return nil
+}
+
+// String Stringer implementation
+func (s SuppressionState) String() string {
+	return suppressionStateFromValue[s]
+}
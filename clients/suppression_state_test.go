@@ -0,0 +1,75 @@
+package clients
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSuppressionState_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		value    SuppressionState
+		expected string
+	}{
+		{
+			title:    "Unknown",
+			expected: fmt.Sprintf("%q", suppressionStateUnknownStr),
+		},
+		{
+			title:    "Suppressed",
+			value:    Suppressed,
+			expected: fmt.Sprintf("%q", suppressionStateSuppressedStr),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.value.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestSuppressionState_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    string
+		expected SuppressionState
+	}{
+		{
+			title:    "Unknown",
+			input:    `"Unknown"`,
+			expected: UnknownSuppressionState,
+		},
+		{
+			title:    "random string",
+			input:    `"random"`,
+			expected: UnknownSuppressionState,
+		},
+		{
+			title:    "Suppressed lowercase",
+			input:    `"suppressed"`,
+			expected: Suppressed,
+		},
+		{
+			title:    "Suppressed title case",
+			input:    `"Suppressed"`,
+			expected: Suppressed,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var value SuppressionState
+			err := value.UnmarshalJSON([]byte(tC.input))
+			if err != nil {
+				t.Errorf("Expected error: nil, Actual: %q", err)
+			}
+			if tC.expected != value {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, value)
+			}
+		})
+	}
+}
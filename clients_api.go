@@ -8,6 +8,7 @@ import (
 	"github.com/xitonix/createsend/clients"
 	"github.com/xitonix/createsend/internal"
 	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/timezone"
 )
 
 const (
@@ -42,7 +43,7 @@ func (a *clientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
 			ContactName  string
 		}
 		AccessDetails *struct {
-			AccessLevel int
+			AccessLevel clients.AccessLevel
 			Username    string
 		}
 		BillingDetails        *internal.BillingDetails
@@ -58,7 +59,7 @@ func (a *clientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
 		ID:       result.BasicDetails.ClientID,
 		Company:  result.BasicDetails.CompanyName,
 		Country:  result.BasicDetails.Country,
-		Timezone: result.BasicDetails.TimeZone,
+		Timezone: timezone.Timezone(result.BasicDetails.TimeZone),
 		Billing:  result.BillingDetails.ToClientBillingDetails(result.PendingBillingDetails),
 	}
 
@@ -66,7 +67,7 @@ func (a *clientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
 		clientDetails.Contact = &clients.ContactDetails{
 			Name:         result.BasicDetails.ContactName,
 			EmailAddress: result.BasicDetails.EmailAddress,
-			AccessLevel:  -1,
+			AccessLevel:  clients.UnknownAccessLevel,
 			Username:     "",
 		}
 	}
@@ -89,9 +90,13 @@ func (a *clientsAPI) SentCampaigns(clientID string) ([]*clients.SentCampaign, er
 	if err != nil {
 		return nil, err
 	}
+	location, err := a.client.Location()
+	if err != nil {
+		return nil, err
+	}
 	campaigns := make([]*clients.SentCampaign, len(result))
 	for i, c := range result {
-		cm, err := c.ToSendCampaign()
+		cm, err := c.ToSendCampaign(location)
 		if err != nil {
 			return nil, newClientError(ErrCodeDataProcessing)
 		}
@@ -107,9 +112,13 @@ func (a *clientsAPI) ScheduledCampaigns(clientID string) ([]*clients.ScheduledCa
 	if err != nil {
 		return nil, err
 	}
+	location, err := a.client.Location()
+	if err != nil {
+		return nil, err
+	}
 	campaigns := make([]*clients.ScheduledCampaign, len(result))
 	for i, c := range result {
-		cm, err := c.ToScheduledCampaign()
+		cm, err := c.ToScheduledCampaign(location)
 		if err != nil {
 			return nil, newClientError(ErrCodeDataProcessing)
 		}
@@ -125,9 +134,13 @@ func (a *clientsAPI) DraftCampaigns(clientID string) ([]*clients.DraftCampaign,
 	if err != nil {
 		return nil, err
 	}
+	location, err := a.client.Location()
+	if err != nil {
+		return nil, err
+	}
 	campaigns := make([]*clients.DraftCampaign, len(result))
 	for i, c := range result {
-		cm, err := c.ToDraftCampaign()
+		cm, err := c.ToDraftCampaign(location)
 		if err != nil {
 			return nil, newClientError(ErrCodeDataProcessing)
 		}
@@ -153,9 +166,13 @@ func (a *clientsAPI) ListsByEmailAddress(clientID, emailAddress string) ([]*clie
 	if err != nil {
 		return nil, err
 	}
+	location, err := a.client.Location()
+	if err != nil {
+		return nil, err
+	}
 	lists := make([]*clients.SubscriberList, len(result))
 	for i, r := range result {
-		sl, err := r.ToSubscriberList()
+		sl, err := r.ToSubscriberList(location)
 		if err != nil {
 			return nil, newClientError(ErrCodeDataProcessing)
 		}
@@ -192,7 +209,12 @@ func (a *clientsAPI) SuppressionList(clientID string,
 		return nil, err
 	}
 
-	list, err := result.ToSuppressionList()
+	location, err := a.client.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := result.ToSuppressionList(location)
 	if err != nil {
 		return nil, newClientError(ErrCodeDataProcessing)
 	}
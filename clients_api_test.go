@@ -12,6 +12,7 @@ import (
 
 	"github.com/xitonix/createsend/clients"
 	"github.com/xitonix/createsend/mock"
+	"github.com/xitonix/createsend/money"
 	"github.com/xitonix/createsend/order"
 )
 
@@ -122,7 +123,7 @@ func TestClientsAPI_Get(t *testing.T) {
 				"BillingDetails": {
 					"CurrentTier": "current_tier",
 					"MonthlyScheme": "monthly_scheme",
-					"Currency": "currency",
+					"Currency": "USD",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.0,
@@ -154,11 +155,11 @@ func TestClientsAPI_Get(t *testing.T) {
 					Monthly: &clients.MonthlyBillingDetails{
 						Tier:             "current_tier",
 						Scheme:           "monthly_scheme",
-						Rate:             1.0,
+						Rate:             money.MustParse("1.0"),
 						MarkupPercentage: 20,
 						Pending:          nil,
 					},
-					Currency:   "currency",
+					Currency:   money.USD,
 					ClientPays: true,
 				},
 			},
@@ -184,7 +185,7 @@ func TestClientsAPI_Get(t *testing.T) {
 				"BillingDetails": {
 					"CurrentTier": "",
 					"MonthlyScheme": "monthly_scheme",
-					"Currency": "currency",
+					"Currency": "USD",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.0,
@@ -216,14 +217,14 @@ func TestClientsAPI_Get(t *testing.T) {
 					PAYG: &clients.PayAsYouGoBillingDetails{
 						CanPurchaseCredits:     true,
 						Credits:                100,
-						BaseRatePerRecipient:   10.1,
-						MarkupPerRecipient:     20.1,
-						MarkupOnDelivery:       30.1,
-						BaseDeliveryRate:       40.1,
-						MarkupOnDesignSpamTest: 50.1,
-						BaseDesignSpamTestRate: 60.1,
+						BaseRatePerRecipient:   money.MustParse("10.1"),
+						MarkupPerRecipient:     money.MustParse("20.1"),
+						MarkupOnDelivery:       money.MustParse("30.1"),
+						BaseDeliveryRate:       money.MustParse("40.1"),
+						MarkupOnDesignSpamTest: money.MustParse("50.1"),
+						BaseDesignSpamTestRate: money.MustParse("60.1"),
 					},
-					Currency:   "currency",
+					Currency:   money.USD,
 					ClientPays: true,
 				},
 			},
@@ -249,7 +250,7 @@ func TestClientsAPI_Get(t *testing.T) {
 				"BillingDetails": {
 					"CurrentTier": "current_tier",
 					"MonthlyScheme": "monthly_scheme",
-					"Currency": "currency",
+					"Currency": "USD",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.0,
@@ -265,7 +266,7 @@ func TestClientsAPI_Get(t *testing.T) {
  				"PendingBillingDetails": {
 					"CurrentTier": "pending_current_tier",
 					"MonthlyScheme": "pending_monthly_scheme",
-					"Currency": "pending_currency",
+					"Currency": "GBP",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.2,
@@ -297,22 +298,22 @@ func TestClientsAPI_Get(t *testing.T) {
 					Monthly: &clients.MonthlyBillingDetails{
 						Tier:             "current_tier",
 						Scheme:           "monthly_scheme",
-						Rate:             1.0,
+						Rate:             money.MustParse("1.0"),
 						MarkupPercentage: 20,
 						Pending: &clients.BillingDetails{
 							Mode: clients.MonthlyBilling,
 							Monthly: &clients.MonthlyBillingDetails{
 								Tier:             "pending_current_tier",
 								Scheme:           "pending_monthly_scheme",
-								Rate:             1.2,
+								Rate:             money.MustParse("1.2"),
 								MarkupPercentage: 30,
 								Pending:          nil,
 							},
-							Currency:   "pending_currency",
+							Currency:   money.GBP,
 							ClientPays: true,
 						},
 					},
-					Currency:   "currency",
+					Currency:   money.USD,
 					ClientPays: true,
 				},
 			},
@@ -338,7 +339,7 @@ func TestClientsAPI_Get(t *testing.T) {
 				"BillingDetails": {
 					"CurrentTier": "current_tier",
 					"MonthlyScheme": "monthly_scheme",
-					"Currency": "currency",
+					"Currency": "USD",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.0,
@@ -354,7 +355,7 @@ func TestClientsAPI_Get(t *testing.T) {
  				"PendingBillingDetails": {
 					"CurrentTier": "",
 					"MonthlyScheme": "",
-					"Currency": "pending_currency",
+					"Currency": "GBP",
 					"CanPurchaseCredits": true,
 					"ClientPays": true,
 					"CurrentMonthlyRate": 1.2,
@@ -386,25 +387,25 @@ func TestClientsAPI_Get(t *testing.T) {
 					Monthly: &clients.MonthlyBillingDetails{
 						Tier:             "current_tier",
 						Scheme:           "monthly_scheme",
-						Rate:             1.0,
+						Rate:             money.MustParse("1.0"),
 						MarkupPercentage: 20,
 						Pending: &clients.BillingDetails{
 							Mode: clients.PAYGBilling,
 							PAYG: &clients.PayAsYouGoBillingDetails{
 								CanPurchaseCredits:     true,
 								Credits:                200,
-								BaseRatePerRecipient:   10.2,
-								MarkupPerRecipient:     20.2,
-								MarkupOnDelivery:       30.2,
-								BaseDeliveryRate:       40.2,
-								MarkupOnDesignSpamTest: 50.2,
-								BaseDesignSpamTestRate: 60.2,
+								BaseRatePerRecipient:   money.MustParse("10.2"),
+								MarkupPerRecipient:     money.MustParse("20.2"),
+								MarkupOnDelivery:       money.MustParse("30.2"),
+								BaseDeliveryRate:       money.MustParse("40.2"),
+								MarkupOnDesignSpamTest: money.MustParse("50.2"),
+								BaseDesignSpamTestRate: money.MustParse("60.2"),
 							},
-							Currency:   "pending_currency",
+							Currency:   money.GBP,
 							ClientPays: true,
 						},
 					},
-					Currency:   "currency",
+					Currency:   money.USD,
 					ClientPays: true,
 				},
 			},
@@ -1215,7 +1216,7 @@ func TestClientsAPI_ListsByEmailAddress(t *testing.T) {
 				{
 					"ListID": "list_id",
 					"ListName": "list_name",
-					"SubscriberState": "state", 
+					"SubscriberState": "Active", 
 					"DateSubscriberAdded": "2020-12-01 20:21:22"
     			}
 			]`)),
@@ -1227,7 +1228,7 @@ func TestClientsAPI_ListsByEmailAddress(t *testing.T) {
 						Name: "list_name",
 					},
 					Subscriber: clients.Subscriber{
-						State:     "state",
+						State:     clients.ActiveSubscriber,
 						DateAdded: date,
 					},
 				},
@@ -1241,7 +1242,7 @@ func TestClientsAPI_ListsByEmailAddress(t *testing.T) {
 				{
 					"ListID": "list_id",
 					"ListName": "list_name",
-					"SubscriberState": "state", 
+					"SubscriberState": "Active", 
 					"DateSubscriberAdded": "invalid date"
     			}
 			]`)),
@@ -1258,7 +1259,7 @@ func TestClientsAPI_ListsByEmailAddress(t *testing.T) {
 				{
 					"ListID": "list_id",
 					"ListName": "list_name",
-					"SubscriberState": "state", 
+					"SubscriberState": "Active", 
 					"DateSubscriberAdded": "2020-12-01 20:21:22"
     			}
 			]`)),
@@ -1270,7 +1271,7 @@ func TestClientsAPI_ListsByEmailAddress(t *testing.T) {
 						Name: "list_name",
 					},
 					Subscriber: clients.Subscriber{
-						State:     "state",
+						State:     clients.ActiveSubscriber,
 						DateAdded: date,
 					},
 				},
@@ -1449,10 +1450,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "email",
@@ -1467,10 +1468,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 			expected: &clients.SuppressionList{
 				Entries: []*clients.SuppressionDetails{
 					{
-						Reason:       "reason",
+						Reason:       clients.UnsubscribedSuppression,
 						EmailAddress: "email@address.com",
 						Date:         date,
-						State:        "state",
+						State:        clients.Suppressed,
 					},
 				},
 				OrderedBy:            order.BySuppressedEmailAddress,
@@ -1489,10 +1490,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "email",
@@ -1507,10 +1508,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 			expected: &clients.SuppressionList{
 				Entries: []*clients.SuppressionDetails{
 					{
-						Reason:       "reason",
+						Reason:       clients.UnsubscribedSuppression,
 						EmailAddress: "email@address.com",
 						Date:         date,
-						State:        "state",
+						State:        clients.Suppressed,
 					},
 				},
 				OrderedBy:            order.BySuppressedEmailAddress,
@@ -1529,10 +1530,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "date",
@@ -1547,10 +1548,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 			expected: &clients.SuppressionList{
 				Entries: []*clients.SuppressionDetails{
 					{
-						Reason:       "reason",
+						Reason:       clients.UnsubscribedSuppression,
 						EmailAddress: "email@address.com",
 						Date:         date,
-						State:        "state",
+						State:        clients.Suppressed,
 					},
 				},
 				OrderedBy:            order.BySuppressionDate,
@@ -1569,10 +1570,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "date",
@@ -1587,10 +1588,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 			expected: &clients.SuppressionList{
 				Entries: []*clients.SuppressionDetails{
 					{
-						Reason:       "reason",
+						Reason:       clients.UnsubscribedSuppression,
 						EmailAddress: "email@address.com",
 						Date:         date,
-						State:        "state",
+						State:        clients.Suppressed,
 					},
 				},
 				OrderedBy:            order.BySuppressionDate,
@@ -1609,10 +1610,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "invalid",
@@ -1635,10 +1636,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "email",
@@ -1661,10 +1662,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "invalid date",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "email",
@@ -1687,10 +1688,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 				Body: ioutil.NopCloser(bytes.NewBufferString(`{
 					"Results": [
 							{
-								"SuppressionReason": "reason",
+								"SuppressionReason": "Unsubscribed",
 								"EmailAddress": "email@address.com",
 								"Date": "2020-12-01 20:21:22",
-								"State": "state"
+								"State": "Suppressed"
 							}
 						],
 						"ResultsOrderedBy": "date",
@@ -1705,10 +1706,10 @@ func TestClientsAPI_SuppressionList(t *testing.T) {
 			expected: &clients.SuppressionList{
 				Entries: []*clients.SuppressionDetails{
 					{
-						Reason:       "reason",
+						Reason:       clients.UnsubscribedSuppression,
 						EmailAddress: "email@address.com",
 						Date:         date,
-						State:        "state",
+						State:        clients.Suppressed,
 					},
 				},
 				OrderedBy:            order.BySuppressionDate,
@@ -2452,7 +2453,7 @@ func TestClientsAPI_People(t *testing.T) {
 					"EmailAddress": "e@d.com",
 					"Name":         "name",
 					"AccessLevel":  10,
-					"Status": "status"
+					"Status": "Active"
     			}
 			]`)),
 			},
@@ -2463,7 +2464,7 @@ func TestClientsAPI_People(t *testing.T) {
 						Name:         "name",
 						AccessLevel:  10,
 					},
-					Status: "status",
+					Status: clients.ActivePerson,
 				},
 			},
 		},
@@ -2476,7 +2477,7 @@ func TestClientsAPI_People(t *testing.T) {
 					"EmailAddress": "e@d.com",
 					"Name":         "name",
 					"AccessLevel":  10,
-					"Status": "status"
+					"Status": "Active"
     			}
 			]`)),
 			},
@@ -2487,7 +2488,7 @@ func TestClientsAPI_People(t *testing.T) {
 						Name:         "name",
 						AccessLevel:  10,
 					},
-					Status: "status",
+					Status: clients.ActivePerson,
 				},
 			},
 			oAuthAuthentication: true,
@@ -2560,7 +2561,7 @@ func TestClientsAPI_Person(t *testing.T) {
 					"EmailAddress": "e@d.com",
 					"Name":         "name",
 					"AccessLevel":  10,
-					"Status": "status"
+					"Status": "Active"
     			}
 			`)),
 			},
@@ -2570,7 +2571,7 @@ func TestClientsAPI_Person(t *testing.T) {
 					Name:         "name",
 					AccessLevel:  10,
 				},
-				Status: "status",
+				Status: clients.ActivePerson,
 			},
 		},
 		{
@@ -2582,7 +2583,7 @@ func TestClientsAPI_Person(t *testing.T) {
 					"EmailAddress": "e@d.com",
 					"Name":         "name",
 					"AccessLevel":  10,
-					"Status": "status"
+					"Status": "Active"
     			}
 			`)),
 			},
@@ -2592,7 +2593,7 @@ func TestClientsAPI_Person(t *testing.T) {
 					Name:         "name",
 					AccessLevel:  10,
 				},
-				Status: "status",
+				Status: clients.ActivePerson,
 			},
 		},
 		{
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/xitonix/createsend"
+)
+
+const (
+	apiKeyEnv     = "CREATESEND_API_KEY"
+	oAuthTokenEnv = "CREATESEND_OAUTH_TOKEN"
+	baseURLEnv    = "CREATESEND_BASE_URL"
+)
+
+var errUsage = errors.New("invalid usage")
+
+// command represents a command or a group of sub-commands.
+type command struct {
+	name        string
+	usage       string
+	description string
+	run         func(client *createsend.Client, args []string) (*result, error)
+	subCommands []*command
+}
+
+func (c *command) find(name string) *command {
+	for _, sub := range c.subCommands {
+		if sub.name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (c *command) printUsage(w io.Writer, path string) {
+	if c.run != nil {
+		fmt.Fprintf(w, "Usage: createsend [global flags] %s %s\n\n%s\n", path, c.usage, c.description)
+		return
+	}
+	fmt.Fprintf(w, "Usage: createsend [global flags] %s <command>\n\nCommands:\n", path)
+	names := make([]string, 0, len(c.subCommands))
+	descriptions := make(map[string]string)
+	for _, sub := range c.subCommands {
+		names = append(names, sub.name)
+		descriptions[sub.name] = sub.description
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-20s %s\n", name, descriptions[name])
+	}
+}
+
+type app struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+	// options the extra client options (used for testing).
+	options []createsend.Option
+}
+
+func (a *app) run(args []string) int {
+	flags := flag.NewFlagSet("createsend", flag.ContinueOnError)
+	flags.SetOutput(a.stderr)
+	apiKey := flags.String("api-key", "", "Campaign Monitor API key ($"+apiKeyEnv+")")
+	oAuthToken := flags.String("oauth-token", "", "Campaign Monitor OAuth token ($"+oAuthTokenEnv+")")
+	baseURL := flags.String("base-url", a.getenv(baseURLEnv), "Campaign Monitor API base URL ($"+baseURLEnv+")")
+	output := flags.String("output", tableOutput, "Output format (table, json or csv)")
+
+	root := rootCommand()
+	flags.Usage = func() {
+		root.printUsage(a.stderr, "")
+		fmt.Fprintln(a.stderr, "\nGlobal flags:")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	// The credentials are read after parsing so that PrintDefaults never exposes them.
+	if *apiKey == "" {
+		*apiKey = a.getenv(apiKeyEnv)
+	}
+	if *oAuthToken == "" {
+		*oAuthToken = a.getenv(oAuthTokenEnv)
+	}
+
+	switch strings.ToLower(*output) {
+	case tableOutput, jsonOutput, csvOutput:
+	default:
+		fmt.Fprintf(a.stderr, "Invalid output format %q. Valid formats are %s, %s and %s\n", *output, tableOutput, jsonOutput, csvOutput)
+		return 2
+	}
+
+	cmd, path, rest := root, []string{}, flags.Args()
+	for cmd.run == nil {
+		if len(rest) == 0 {
+			flags.Usage()
+			return 2
+		}
+		sub := cmd.find(rest[0])
+		if sub == nil {
+			fmt.Fprintf(a.stderr, "Unknown command %q\n\n", strings.Join(append(path, rest[0]), " "))
+			cmd.printUsage(a.stderr, strings.Join(path, " "))
+			return 2
+		}
+		cmd, path, rest = sub, append(path, sub.name), rest[1:]
+	}
+
+	options := []createsend.Option{}
+	switch {
+	case *oAuthToken != "":
+		options = append(options, createsend.WithOAuthToken(*oAuthToken))
+	default:
+		options = append(options, createsend.WithAPIKey(*apiKey))
+	}
+	if *baseURL != "" {
+		options = append(options, createsend.WithBaseURL(*baseURL))
+	}
+	options = append(options, a.options...)
+
+	client, err := createsend.New(options...)
+	if err != nil {
+		fmt.Fprintf(a.stderr, "Failed to create the client: %v\n", err)
+		return 1
+	}
+
+	res, err := cmd.run(client, rest)
+	if err != nil {
+		if errors.Is(err, errUsage) {
+			if err != errUsage && !errors.Is(err, flag.ErrHelp) {
+				fmt.Fprintf(a.stderr, "Error: %v\n\n", err)
+			}
+			cmd.printUsage(a.stderr, strings.Join(path, " "))
+			return 2
+		}
+		fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if err := render(a.stdout, *output, res); err != nil {
+		fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/mock"
+)
+
+func newTestApp(httpClient *mock.HTTPClientMock, env map[string]string) (*app, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	return &app{
+		stdout: &stdout,
+		stderr: &stderr,
+		getenv: func(key string) string {
+			return env[key]
+		},
+		options: []createsend.Option{
+			createsend.WithBaseURL("https://base.com"),
+			createsend.WithHTTPClient(httpClient),
+		},
+	}, &stdout, &stderr
+}
+
+func jsonResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestApp_Run(t *testing.T) {
+	testCases := []struct {
+		title            string
+		args             []string
+		env              map[string]string
+		path             string
+		response         *http.Response
+		expectedExitCode int
+		expectedOutput   string
+		expectedError    string
+		expectedQuery    map[string]string
+	}{
+		{
+			title:            "list clients as table",
+			args:             []string{"-api-key", "key", "clients", "list"},
+			path:             "clients.json",
+			response:         jsonResponse(`[{"ClientID":"id","Name":"name"}]`),
+			expectedOutput:   "ID  Name\nid  name\n",
+			expectedExitCode: 0,
+		},
+		{
+			title:          "list clients as json using the API key from the environment",
+			args:           []string{"-output", "json", "accounts", "clients"},
+			env:            map[string]string{apiKeyEnv: "key"},
+			path:           "clients.json",
+			response:       jsonResponse(`[{"ClientID":"id","Name":"name"}]`),
+			expectedOutput: "[\n  {\n    \"ClientID\": \"id\",\n    \"Name\": \"name\"\n  }\n]\n",
+		},
+		{
+			title:          "list clients as csv using OAuth token",
+			args:           []string{"-oauth-token", "token", "-output", "csv", "clients", "list"},
+			path:           "clients.json",
+			response:       jsonResponse(`[{"ClientID":"id","Name":"name, with comma"}]`),
+			expectedOutput: "ID,Name\nid,\"name, with comma\"\n",
+		},
+		{
+			title:          "get client",
+			args:           []string{"-api-key", "key", "-output", "csv", "clients", "get", "id"},
+			path:           "clients/id.json",
+			response:       jsonResponse(`{"BasicDetails":{"ClientID":"id","CompanyName":"company","Country":"Australia","TimeZone":"tz"}}`),
+			expectedOutput: "ID,Company,Country,Timezone\nid,company,Australia,tz\n",
+		},
+		{
+			title:          "export suppression list",
+			args:           []string{"-api-key", "key", "-output", "csv", "clients", "suppression", "export", "-order-by", "date", "id"},
+			path:           "clients/id/suppressionlist.json",
+			response:       jsonResponse(`{"Results":[{"SuppressionReason":"Unsubscribed","EmailAddress":"a@b.com","Date":"2020-01-02 10:11:12","State":"Suppressed"}],"ResultsOrderedBy":"date","OrderDirection":"asc","PageNumber":1,"PageSize":1000,"RecordsOnThisPage":1,"TotalNumberOfRecords":1,"NumberOfPages":1}`),
+			expectedOutput: "Email Address,Reason,State,Date\na@b.com,unsubscribed,suppressed,2020-01-02T10:11:12Z\n",
+			expectedQuery: map[string]string{
+				"page":           "1",
+				"pagesize":       "1000",
+				"orderfield":     "date",
+				"orderdirection": "asc",
+			},
+		},
+		{
+			title:          "list active smart emails of a client",
+			args:           []string{"-api-key", "key", "-output", "csv", "smart-emails", "list", "-status", "active", "-client", "client_id"},
+			path:           "transactional/smartEmail",
+			response:       jsonResponse(`[{"ID":"id","Name":"name","CreatedAt":"2020-01-02T10:11:12Z","Status":"Active"}]`),
+			expectedOutput: "ID,Name,Status,Created\nid,name,active,2020-01-02T10:11:12Z\n",
+			expectedQuery: map[string]string{
+				"status":   "active",
+				"clientID": "client_id",
+			},
+		},
+		{
+			title:            "invalid smart email status",
+			args:             []string{"-api-key", "key", "smart-emails", "list", "-status", "invalid"},
+			expectedExitCode: 2,
+			expectedError:    `invalid smart email status "invalid"`,
+		},
+		{
+			title:            "invalid sub-command flag",
+			args:             []string{"-api-key", "key", "smart-emails", "list", "-invalid"},
+			expectedExitCode: 2,
+			expectedError:    "flag provided but not defined: -invalid",
+		},
+		{
+			title: "server side error",
+			args:  []string{"-api-key", "key", "clients", "get", "id"},
+			path:  "clients/id.json",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":203,"Message":"Invalid ClientID"}`)),
+			},
+			expectedExitCode: 1,
+			expectedError:    "Invalid ClientID",
+		},
+		{
+			title:            "missing credentials",
+			args:             []string{"clients", "list"},
+			expectedExitCode: 1,
+			expectedError:    createsend.ErrCodeEmptyAPIKey.String(),
+		},
+		{
+			title:            "missing positional argument",
+			args:             []string{"-api-key", "key", "clients", "get"},
+			expectedExitCode: 2,
+			expectedError:    "Usage: createsend [global flags] clients get <client id>",
+		},
+		{
+			title:            "unknown command",
+			args:             []string{"-api-key", "key", "clients", "unknown"},
+			expectedExitCode: 2,
+			expectedError:    `Unknown command "clients unknown"`,
+		},
+		{
+			title:            "missing command",
+			args:             []string{"-api-key", "key"},
+			expectedExitCode: 2,
+			expectedError:    "smart-emails",
+		},
+		{
+			title:            "invalid output format",
+			args:             []string{"-api-key", "key", "-output", "xml", "clients", "list"},
+			expectedExitCode: 2,
+			expectedError:    `Invalid output format "xml"`,
+		},
+		{
+			title:            "help",
+			args:             []string{"-help"},
+			expectedExitCode: 0,
+			expectedError:    "Global flags:",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			if tC.path != "" {
+				httpClient.SetResponse(tC.path, tC.response)
+			}
+			a, stdout, stderr := newTestApp(httpClient, tC.env)
+
+			exitCode := a.run(tC.args)
+			if exitCode != tC.expectedExitCode {
+				t.Errorf("Expected exit code: %d, Actual: %d. Stderr: %s", tC.expectedExitCode, exitCode, stderr.String())
+			}
+			if stdout.String() != tC.expectedOutput {
+				t.Errorf("Expected output: %q, Actual: %q", tC.expectedOutput, stdout.String())
+			}
+			if !strings.Contains(stderr.String(), tC.expectedError) {
+				t.Errorf("Expected error output to contain %q, Actual: %q", tC.expectedError, stderr.String())
+			}
+			for key, expected := range tC.expectedQuery {
+				if actual := httpClient.LastRequest().Query().Get(key); actual != expected {
+					t.Errorf("Expected query string value under %s key: %q, Actual: %q", key, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestApp_Run_DoesNotPrintEnvCredentials(t *testing.T) {
+	env := map[string]string{
+		apiKeyEnv:     "secret-api-key",
+		oAuthTokenEnv: "secret-oauth-token",
+	}
+	a, _, stderr := newTestApp(mock.NewHTTPClientMock(), env)
+
+	if exitCode := a.run([]string{"-help"}); exitCode != 0 {
+		t.Errorf("Expected exit code: 0, Actual: %d", exitCode)
+	}
+	for _, secret := range env {
+		if strings.Contains(stderr.String(), secret) {
+			t.Errorf("Expected the help output not to contain %q, Actual: %q", secret, stderr.String())
+		}
+	}
+}
@@ -0,0 +1,542 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const exportPageSize = 1000
+
+func rootCommand() *command {
+	return &command{
+		subCommands: []*command{
+			accountsCommand(),
+			clientsCommand(),
+			smartEmailsCommand(),
+		},
+	}
+}
+
+func accountsCommand() *command {
+	return &command{
+		name:        "accounts",
+		description: "Account level operations",
+		subCommands: []*command{
+			{
+				name:        "clients",
+				description: "Lists all the clients of the account",
+				run:         listClients,
+			},
+			{
+				name:        "billing",
+				description: "Shows the billing details of the account",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					billing, err := client.Accounts().Billing()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(billing, "Credits")
+					r.add(billing.Credits)
+					return r, nil
+				},
+			},
+			{
+				name:        "countries",
+				description: "Lists all the valid countries",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					countries, err := client.Accounts().Countries()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(countries, "Country")
+					for _, country := range countries {
+						r.add(country)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "timezones",
+				description: "Lists all the valid timezones",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					timezones, err := client.Accounts().Timezones()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(timezones, "Timezone")
+					for _, timezone := range timezones {
+						r.add(timezone)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "now",
+				description: "Shows the current date and time in the account's timezone",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					now, err := client.Accounts().Now()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(now, "Now")
+					r.add(now)
+					return r, nil
+				},
+			},
+			{
+				name:        "admins",
+				description: "Lists all the administrators of the account",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					admins, err := client.Accounts().Administrators()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(admins, "Email Address", "Name", "Status")
+					for _, admin := range admins {
+						r.add(admin.EmailAddress, admin.Name, admin.Status)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "primary-contact",
+				description: "Shows the primary contact of the account",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args); err != nil {
+						return nil, err
+					}
+					email, err := client.Accounts().PrimaryContact()
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(email, "Email Address")
+					r.add(email)
+					return r, nil
+				},
+			},
+		},
+	}
+}
+
+func clientsCommand() *command {
+	return &command{
+		name:        "clients",
+		description: "Client level operations",
+		subCommands: []*command{
+			{
+				name:        "list",
+				description: "Lists all the clients of the account",
+				run:         listClients,
+			},
+			{
+				name:        "get",
+				usage:       "<client id>",
+				description: "Shows the details of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					details, err := client.Clients().Get(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(details, "ID", "Company", "Country", "Timezone")
+					r.add(details.ID, details.Company, details.Country, details.Timezone)
+					return r, nil
+				},
+			},
+			{
+				name:        "sent",
+				usage:       "<client id>",
+				description: "Lists the sent campaigns of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					campaigns, err := client.Clients().SentCampaigns(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(campaigns, "ID", "Name", "Subject", "Sent", "Recipients")
+					for _, c := range campaigns {
+						r.add(c.ID, c.Name, c.Subject, c.SentDate, c.Recipients)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "scheduled",
+				usage:       "<client id>",
+				description: "Lists the scheduled campaigns of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					campaigns, err := client.Clients().ScheduledCampaigns(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(campaigns, "ID", "Name", "Subject", "Scheduled", "Timezone")
+					for _, c := range campaigns {
+						r.add(c.ID, c.Name, c.Subject, c.DateScheduled, c.Timezone)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "drafts",
+				usage:       "<client id>",
+				description: "Lists the draft campaigns of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					campaigns, err := client.Clients().DraftCampaigns(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(campaigns, "ID", "Name", "Subject", "Created")
+					for _, c := range campaigns {
+						r.add(c.ID, c.Name, c.Subject, c.DateCreated)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "lists",
+				usage:       "<client id>",
+				description: "Lists the subscriber lists of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					lists, err := client.Clients().Lists(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(lists, "ID", "Name")
+					for _, l := range lists {
+						r.add(l.ID, l.Name)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "lists-for-email",
+				usage:       "<client id> <email address>",
+				description: "Lists the subscriber lists of a client to which the email address is subscribed",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id", "email address"); err != nil {
+						return nil, err
+					}
+					lists, err := client.Clients().ListsByEmailAddress(args[0], args[1])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(lists, "ID", "Name", "State", "Added")
+					for _, l := range lists {
+						r.add(l.ID, l.Name, l.Subscriber.State, l.Subscriber.DateAdded)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "segments",
+				usage:       "<client id>",
+				description: "Lists the segments of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					segments, err := client.Clients().Segments(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(segments, "ID", "Title", "List ID")
+					for _, s := range segments {
+						r.add(s.ID, s.Title, s.ListID)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "templates",
+				usage:       "<client id>",
+				description: "Lists the templates of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					templates, err := client.Clients().Templates(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(templates, "ID", "Name", "Preview URL")
+					for _, t := range templates {
+						r.add(t.ID, t.Name, t.PreviewURL)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "people",
+				usage:       "<client id>",
+				description: "Lists the people of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					people, err := client.Clients().People(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(people, "Email Address", "Name", "Access Level", "Status")
+					for _, p := range people {
+						r.add(p.EmailAddress, p.Name, p.AccessLevel, p.Status)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "primary-contact",
+				usage:       "<client id>",
+				description: "Shows the primary contact of a client",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "client id"); err != nil {
+						return nil, err
+					}
+					email, err := client.Clients().PrimaryContact(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(email, "Email Address")
+					r.add(email)
+					return r, nil
+				},
+			},
+			{
+				name:        "suppression",
+				description: "Client suppression list operations",
+				subCommands: []*command{
+					{
+						name:        "list",
+						usage:       "[-page 1] [-page-size 1000] [-order-by email|date] [-direction asc|desc] <client id>",
+						description: "Shows a single page of the client's suppression list",
+						run:         listSuppressions,
+					},
+					{
+						name:        "export",
+						usage:       "[-order-by email|date] [-direction asc|desc] <client id>",
+						description: "Exports the client's entire suppression list",
+						run:         exportSuppressions,
+					},
+				},
+			},
+		},
+	}
+}
+
+func smartEmailsCommand() *command {
+	return &command{
+		name:        "smart-emails",
+		description: "Smart transactional email operations",
+		subCommands: []*command{
+			{
+				name:        "list",
+				usage:       "[-status all|active|draft] [-client <client id>]",
+				description: "Lists the smart transactional emails",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					flags := newFlagSet("list")
+					status := flags.String("status", "all", "Smart email status (all, active or draft)")
+					clientID := flags.String("client", "", "Client ID")
+					if err := parseFlags(flags, args); err != nil {
+						return nil, err
+					}
+					if err := expectArgs(flags.Args()); err != nil {
+						return nil, err
+					}
+
+					options := []transactional.Option{}
+					if *status != "all" {
+						s := transactional.ParseSmartEmailStatus(*status)
+						if s == transactional.UnknownSmartEmail {
+							return nil, fmt.Errorf("%w: invalid smart email status %q", errUsage, *status)
+						}
+						options = append(options, transactional.WithSmartEmailStatus(s))
+					}
+					if *clientID != "" {
+						options = append(options, transactional.WithClientID(*clientID))
+					}
+
+					emails, err := client.Transactional().SmartEmails(options...)
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(emails, "ID", "Name", "Status", "Created")
+					for _, e := range emails {
+						r.add(e.ID, e.Name, e.Status, e.CreatedAt)
+					}
+					return r, nil
+				},
+			},
+			{
+				name:        "get",
+				usage:       "<smart email id>",
+				description: "Shows the details of a smart transactional email",
+				run: func(client *createsend.Client, args []string) (*result, error) {
+					if err := expectArgs(args, "smart email id"); err != nil {
+						return nil, err
+					}
+					email, err := client.Transactional().SmartEmail(args[0])
+					if err != nil {
+						return nil, err
+					}
+					r := newResult(email, "ID", "Name", "Status", "From", "Subject", "Variables")
+					r.add(email.ID, email.Name, email.Status, email.From.String(), email.Subject, fmt.Sprint(email.EmailVariables))
+					return r, nil
+				},
+			},
+		},
+	}
+}
+
+func listClients(client *createsend.Client, args []string) (*result, error) {
+	if err := expectArgs(args); err != nil {
+		return nil, err
+	}
+	list, err := client.Accounts().Clients()
+	if err != nil {
+		return nil, err
+	}
+	r := newResult(list, "ID", "Name")
+	for _, c := range list {
+		r.add(c.ID, c.Name)
+	}
+	return r, nil
+}
+
+func listSuppressions(client *createsend.Client, args []string) (*result, error) {
+	flags := newFlagSet("list")
+	page := flags.Int("page", 1, "Page number")
+	pageSize := flags.Int("page-size", exportPageSize, "Page size")
+	orderBy, direction := orderFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if err := expectArgs(flags.Args(), "client id"); err != nil {
+		return nil, err
+	}
+	field, dir, err := parseOrder(*orderBy, *direction)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.Clients().SuppressionList(flags.Arg(0), *pageSize, *page, field, dir)
+	if err != nil {
+		return nil, err
+	}
+	r := newResult(list, suppressionHeaders...)
+	for _, entry := range list.Entries {
+		r.add(entry.EmailAddress, entry.Reason, entry.State, entry.Date)
+	}
+	return r, nil
+}
+
+func exportSuppressions(client *createsend.Client, args []string) (*result, error) {
+	flags := newFlagSet("export")
+	orderBy, direction := orderFlags(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return nil, err
+	}
+	if err := expectArgs(flags.Args(), "client id"); err != nil {
+		return nil, err
+	}
+	field, dir, err := parseOrder(*orderBy, *direction)
+	if err != nil {
+		return nil, err
+	}
+
+	r := newResult(nil, suppressionHeaders...)
+	var entries []interface{}
+	for page := 1; ; page++ {
+		list, err := client.Clients().SuppressionList(flags.Arg(0), exportPageSize, page, field, dir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range list.Entries {
+			entries = append(entries, entry)
+			r.add(entry.EmailAddress, entry.Reason, entry.State, entry.Date)
+		}
+		if page >= list.NumberOfPages || len(list.Entries) == 0 {
+			break
+		}
+	}
+	if entries == nil {
+		entries = []interface{}{}
+	}
+	r.value = entries
+	return r, nil
+}
+
+var suppressionHeaders = []string{"Email Address", "Reason", "State", "Date"}
+
+func orderFlags(flags *flag.FlagSet) (orderBy, direction *string) {
+	orderBy = flags.String("order-by", "email", "Order by field (email or date)")
+	direction = flags.String("direction", "asc", "Order direction (asc or desc)")
+	return orderBy, direction
+}
+
+func parseOrder(orderBy, direction string) (order.SuppressionListField, order.Direction, error) {
+	var field order.SuppressionListField
+	if err := json.Unmarshal([]byte(strconv.Quote(orderBy)), &field); err != nil {
+		return 0, 0, err
+	}
+	var dir order.Direction
+	if err := json.Unmarshal([]byte(strconv.Quote(direction)), &dir); err != nil {
+		return 0, 0, err
+	}
+	return field, dir, nil
+}
+
+func newFlagSet(name string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.SetOutput(ioutil.Discard)
+	return flags
+}
+
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", errUsage, err)
+	}
+	return nil
+}
+
+// expectArgs makes sure that the number of the positional arguments matches the expected argument names.
+func expectArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return errUsage
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+// Command createsend provides command-line access to Campaign Monitor's accounts, clients and transactional APIs.
+//
+// Usage:
+//
+//	createsend [global flags] <command> <sub-command> [flags] [arguments]
+//
+// The credentials can be provided using the -api-key or -oauth-token flags, or the CREATESEND_API_KEY and
+// CREATESEND_OAUTH_TOKEN environment variables. Run createsend -help for the list of all the commands.
+package main
+
+import (
+	"os"
+)
+
+func main() {
+	a := &app{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	}
+	os.Exit(a.run(os.Args[1:]))
+}
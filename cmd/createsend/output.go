@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	tableOutput = "table"
+	jsonOutput  = "json"
+	csvOutput   = "csv"
+)
+
+// result represents the output of a command.
+type result struct {
+	// value the raw value which will be rendered as JSON.
+	value interface{}
+	// headers the column headers of the table/CSV output.
+	headers []string
+	// rows the rows of the table/CSV output.
+	rows [][]string
+}
+
+func newResult(value interface{}, headers ...string) *result {
+	return &result{
+		value:   value,
+		headers: headers,
+	}
+}
+
+func (r *result) add(values ...interface{}) {
+	row := make([]string, len(values))
+	for i, v := range values {
+		row[i] = format(v)
+	}
+	r.rows = append(r.rows, row)
+}
+
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		if v.IsZero() {
+			return ""
+		}
+		return v.Format(time.RFC3339)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func render(w io.Writer, output string, r *result) error {
+	switch strings.ToLower(output) {
+	case jsonOutput:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r.value)
+	case csvOutput:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(r.headers); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(r.rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case tableOutput:
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(r.headers, "\t"))
+		for _, row := range r.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("invalid output format %q. Valid formats are %s, %s and %s", output, tableOutput, jsonOutput, csvOutput)
+	}
+}
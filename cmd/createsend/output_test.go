@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestRender(t *testing.T) {
+	r := newResult([]string{"value"}, "Text", "Number", "Flag", "Time", "Empty Time", "Stringer", "Nil")
+	r.add("text", 10, true, time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), time.Time{}, stringer{}, nil)
+
+	testCases := []struct {
+		title         string
+		output        string
+		expected      string
+		expectedError bool
+	}{
+		{
+			title:    "table",
+			output:   tableOutput,
+			expected: "Text  Number  Flag  Time                  Empty Time  Stringer  Nil\ntext  10      true  2020-01-02T03:04:05Z              stringer  \n",
+		},
+		{
+			title:    "csv",
+			output:   csvOutput,
+			expected: "Text,Number,Flag,Time,Empty Time,Stringer,Nil\ntext,10,true,2020-01-02T03:04:05Z,,stringer,\n",
+		},
+		{
+			title:    "json",
+			output:   "JSON",
+			expected: "[\n  \"value\"\n]\n",
+		},
+		{
+			title:         "invalid format",
+			output:        "xml",
+			expectedError: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var buf bytes.Buffer
+			err := render(&buf, tC.output, r)
+			if (err != nil) != tC.expectedError {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if buf.String() != tC.expected {
+				t.Errorf("Expected: %q, Actual: %q", tC.expected, buf.String())
+			}
+		})
+	}
+}
@@ -1,16 +1,54 @@
 package createsend
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
 )
 
+const (
+	// maxErrorBodySize the maximum number of bytes read from a failed response.
+	maxErrorBodySize = 64 << 10
+	// maxErrorBodySnippetSize the maximum number of bytes of the response body kept in the error.
+	maxErrorBodySnippetSize = 512
+	retryAfterHeaderKey     = "Retry-After"
+	rateLimitResetHeaderKey = "X-RateLimit-Reset"
+)
+
+// preservedErrorHeaders the response headers that will be copied into the error.
+var preservedErrorHeaders = []string{
+	"Content-Type",
+	"Date",
+	retryAfterHeaderKey,
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	rateLimitResetHeaderKey,
+}
+
 // Error wraps server side and client side errors.
 type Error struct {
 	// Code error code.
 	Code int
 	// Message error message.
 	Message string
+	// StatusCode the HTTP status code of the response.
+	//
+	// The value will be zero if the error has occurred before receiving a response from the server.
+	StatusCode int
+	// Header the selected headers of the response, including the rate limit headers.
+	Header http.Header
+	// Method the HTTP method of the failed request.
+	Method string
+	// Path the URL path of the failed request.
+	Path string
+	// RawBody the beginning of the response body, truncated to a few hundred bytes.
+	RawBody string
 	err     error
 	wrapped bool
 }
@@ -33,6 +71,66 @@ func newClientError(code ClientErrorCode) error {
 	}
 }
 
+func newServerError(code ServerErrorCode) *Error {
+	msg := code.String()
+	return &Error{
+		Code:    int(code),
+		Message: msg,
+		err:     errors.New(msg),
+	}
+}
+
+func newResponseError(request *http.Request, response *http.Response) error {
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, maxErrorBodySize))
+	if err != nil {
+		return withRequest(newWrappedClientError("Failed to read the server error response", err, ErrCodeDataProcessing), request)
+	}
+
+	var document struct {
+		Code    *int
+		Message string
+	}
+	var csError *Error
+	if err := json.Unmarshal(body, &document); err != nil || document.Code == nil {
+		msg := fmt.Sprintf("%s: %d %s", ErrCodeUnexpectedResponse, response.StatusCode, http.StatusText(response.StatusCode))
+		csError = &Error{
+			Code:    int(ErrCodeUnexpectedResponse),
+			Message: msg,
+			err:     errors.New(msg),
+		}
+	} else {
+		csError = &Error{
+			Code:    *document.Code,
+			Message: document.Message,
+			err:     errors.New(document.Message),
+		}
+	}
+
+	csError.StatusCode = response.StatusCode
+	csError.Header = make(http.Header)
+	for _, key := range preservedErrorHeaders {
+		for _, value := range response.Header.Values(key) {
+			csError.Header.Add(key, value)
+		}
+	}
+	if len(body) > maxErrorBodySnippetSize {
+		body = body[:maxErrorBodySnippetSize]
+	}
+	csError.RawBody = string(body)
+	return withRequest(csError, request)
+}
+
+func withRequest(err error, request *http.Request) error {
+	var csError *Error
+	if errors.As(err, &csError) && request != nil {
+		csError.Method = request.Method
+		if request.URL != nil {
+			csError.Path = request.URL.Path
+		}
+	}
+	return err
+}
+
 // IsFromServer returns true if the error reported by the server.
 //
 // The method will return false if this is a client side error.
@@ -40,6 +138,36 @@ func (e *Error) IsFromServer() bool {
 	return e.Code >= 0
 }
 
+// ServerErrorCode returns the server side error code.
+//
+// The returned value is only meaningful if the error has been reported by the server.
+func (e *Error) ServerErrorCode() ServerErrorCode {
+	return ServerErrorCode(e.Code)
+}
+
+// RetryAfter returns the amount of time the server has asked the client to wait before sending the next request.
+//
+// The second return value will be false if the server has not specified any waiting time.
+func (e *Error) RetryAfter() (time.Duration, bool) {
+	for _, key := range []string{retryAfterHeaderKey, rateLimitResetHeaderKey} {
+		value := e.Header.Get(key)
+		if value == "" {
+			continue
+		}
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			wait := time.Until(date)
+			if wait < 0 {
+				wait = 0
+			}
+			return wait, true
+		}
+	}
+	return 0, false
+}
+
 // Unwrap returns the internal error.
 func (e *Error) Unwrap() error {
 	return e.err
@@ -61,3 +189,70 @@ func (e *Error) Is(target error) bool {
 	}
 	return e.Code == t.Code
 }
+
+// IsAuthError returns true if the server has rejected the request due to invalid, expired or missing credentials.
+func IsAuthError(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized) || hasServerErrorCode(err,
+		ErrCodeMustBeLoggedIn,
+		ErrCodeInvalidAPIKey,
+		ErrCodeInvalidOAuthToken,
+		ErrCodeExpiredOAuthToken,
+		ErrCodeRevokedOAuthToken)
+}
+
+// IsNotFound returns true if the server could not find the requested resource.
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound) || hasServerErrorCode(err,
+		ErrCodeInvalidListID,
+		ErrCodeInvalidClientID,
+		ErrCodeClientNotFound)
+}
+
+// IsRateLimited returns true if the server has throttled the request.
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+// IsRetryable returns true if sending the same request again may succeed.
+//
+// Rate limited requests, network timeouts and temporary server failures are considered retryable.
+func IsRetryable(err error) bool {
+	if IsRateLimited(err) {
+		return true
+	}
+	if hasStatusCode(err,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+func hasServerErrorCode(err error, codes ...ServerErrorCode) bool {
+	var csErr *Error
+	if !errors.As(err, &csErr) || !csErr.IsFromServer() {
+		return false
+	}
+	for _, code := range codes {
+		if csErr.ServerErrorCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+func hasStatusCode(err error, statusCodes ...int) bool {
+	var csErr *Error
+	if !errors.As(err, &csErr) {
+		return false
+	}
+	for _, statusCode := range statusCodes {
+		if csErr.StatusCode == statusCode {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package createsend
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -41,3 +42,195 @@ func TestError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestError_IsServerSentinel(t *testing.T) {
+	testCases := []struct {
+		title    string
+		err      error
+		target   error
+		expected bool
+	}{
+		{
+			title:    "same server error code",
+			err:      &Error{Code: 203, Message: "Invalid ClientID"},
+			target:   ErrClientNotFound,
+			expected: true,
+		},
+		{
+			title:    "wrapped server error",
+			err:      fmt.Errorf("wrapped: %w", &Error{Code: 121, Message: "Expired OAuth Token"}),
+			target:   ErrExpiredOAuthToken,
+			expected: true,
+		},
+		{
+			title:  "different server error code",
+			err:    &Error{Code: 1, Message: "Invalid Email Address"},
+			target: ErrClientNotFound,
+		},
+		{
+			title:  "non createsend error",
+			err:    errors.New("random"),
+			target: ErrInvalidEmailAddress,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual := errors.Is(tC.err, tC.target)
+			if actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestErrorClassification(t *testing.T) {
+	testCases := []struct {
+		title               string
+		err                 error
+		expectAuthError     bool
+		expectNotFound      bool
+		expectRateLimited   bool
+		expectRetryableFlag bool
+	}{
+		{
+			title: "nil error",
+		},
+		{
+			title: "non createsend error",
+			err:   errors.New("random"),
+		},
+		{
+			title: "client side error",
+			err:   newClientError(ErrCodeEmptyAPIKey),
+		},
+		{
+			title:           "must be logged in",
+			err:             &Error{Code: int(ErrCodeMustBeLoggedIn)},
+			expectAuthError: true,
+		},
+		{
+			title:           "invalid API key",
+			err:             &Error{Code: int(ErrCodeInvalidAPIKey)},
+			expectAuthError: true,
+		},
+		{
+			title:           "invalid OAuth token",
+			err:             &Error{Code: int(ErrCodeInvalidOAuthToken)},
+			expectAuthError: true,
+		},
+		{
+			title:           "expired OAuth token",
+			err:             &Error{Code: int(ErrCodeExpiredOAuthToken)},
+			expectAuthError: true,
+		},
+		{
+			title:           "revoked OAuth token",
+			err:             &Error{Code: int(ErrCodeRevokedOAuthToken)},
+			expectAuthError: true,
+		},
+		{
+			title:          "invalid list ID",
+			err:            &Error{Code: int(ErrCodeInvalidListID)},
+			expectNotFound: true,
+		},
+		{
+			title:          "invalid client ID",
+			err:            &Error{Code: int(ErrCodeInvalidClientID)},
+			expectNotFound: true,
+		},
+		{
+			title:          "client not found",
+			err:            &Error{Code: int(ErrCodeClientNotFound)},
+			expectNotFound: true,
+		},
+		{
+			title:               "rate limit exceeded",
+			err:                 &Error{StatusCode: 429},
+			expectRateLimited:   true,
+			expectRetryableFlag: true,
+		},
+		{
+			title:               "network timeout",
+			err:                 newWrappedClientError("Failed to send the request", timeoutError{}, ErrCodeDataProcessing),
+			expectRetryableFlag: true,
+		},
+		{
+			title: "invalid email address",
+			err:   &Error{Code: int(ErrCodeInvalidEmailAddress)},
+		},
+		{
+			title:           "unauthorised http status",
+			err:             &Error{Code: int(ErrCodeUnexpectedResponse), StatusCode: 401},
+			expectAuthError: true,
+		},
+		{
+			title:          "not found http status",
+			err:            &Error{Code: int(ErrCodeUnexpectedResponse), StatusCode: 404},
+			expectNotFound: true,
+		},
+		{
+			title:               "too many requests http status",
+			err:                 &Error{Code: int(ErrCodeUnexpectedResponse), StatusCode: 429},
+			expectRateLimited:   true,
+			expectRetryableFlag: true,
+		},
+		{
+			title:               "bad gateway http status",
+			err:                 &Error{Code: int(ErrCodeUnexpectedResponse), StatusCode: 502},
+			expectRetryableFlag: true,
+		},
+		{
+			title: "bad request http status",
+			err:   &Error{Code: int(ErrCodeInvalidEmailAddress), StatusCode: 400},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			if actual := IsAuthError(tC.err); actual != tC.expectAuthError {
+				t.Errorf("IsAuthError: Expected %v, Actual: %v", tC.expectAuthError, actual)
+			}
+			if actual := IsNotFound(tC.err); actual != tC.expectNotFound {
+				t.Errorf("IsNotFound: Expected %v, Actual: %v", tC.expectNotFound, actual)
+			}
+			if actual := IsRateLimited(tC.err); actual != tC.expectRateLimited {
+				t.Errorf("IsRateLimited: Expected %v, Actual: %v", tC.expectRateLimited, actual)
+			}
+			if actual := IsRetryable(tC.err); actual != tC.expectRetryableFlag {
+				t.Errorf("IsRetryable: Expected %v, Actual: %v", tC.expectRetryableFlag, actual)
+			}
+		})
+	}
+}
+
+func TestServerErrorCode_String(t *testing.T) {
+	codes := []ServerErrorCode{
+		ErrCodeInvalidEmailAddress,
+		ErrCodeMustBeLoggedIn,
+		ErrCodeInvalidAPIKey,
+		ErrCodeInvalidListID,
+		ErrCodeInvalidClientID,
+		ErrCodeInvalidOAuthToken,
+		ErrCodeExpiredOAuthToken,
+		ErrCodeRevokedOAuthToken,
+		ErrCodeClientNotFound,
+	}
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		str := code.String()
+		if str == ServerErrorCode(-1).String() {
+			t.Errorf("Expected a dedicated message for code %d", code)
+		}
+		if seen[str] {
+			t.Errorf("Duplicate message %q for code %d", str, code)
+		}
+		seen[str] = true
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
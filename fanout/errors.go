@@ -0,0 +1,40 @@
+package fanout
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Errors represents the errors of the failed calls, keyed by client ID.
+type Errors map[string]error
+
+// Error returns the string representation of the errors, sorted by client ID.
+func (e Errors) Error() string {
+	ids := e.ClientIDs()
+	messages := make([]string, len(ids))
+	for i, id := range ids {
+		messages[i] = fmt.Sprintf("%s: %v", id, e[id])
+	}
+	return fmt.Sprintf("%d client(s) failed: %s", len(e), strings.Join(messages, "; "))
+}
+
+// ClientIDs returns the sorted IDs of the failed clients.
+func (e Errors) ClientIDs() []string {
+	ids := make([]string, 0, len(e))
+	for id := range e {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Unwrap returns the errors sorted by client ID, so that they can be matched using errors.Is and errors.As.
+func (e Errors) Unwrap() []error {
+	ids := e.ClientIDs()
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		errs[i] = e[id]
+	}
+	return errs
+}
@@ -0,0 +1,153 @@
+// Package fanout runs the same function for every client of an account with bounded concurrency.
+package fanout
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+)
+
+// Func represents the function which gets executed for each client.
+//
+// The context is cancelled when the parent context passed to Run or Stream is cancelled. To also cancel
+// the in-flight HTTP requests, create the createsend client using createsend.WithContext with the same context.
+type Func[T any] func(ctx context.Context, client *accounts.Client) (T, error)
+
+// Result represents the outcome of the function for a single client.
+type Result[T any] struct {
+	// Client the client.
+	Client *accounts.Client
+	// Value the value returned by the function.
+	Value T
+	// Err the error returned by the function, or the context error if the function has not been executed.
+	Err error
+}
+
+// Stream executes the function for every client returned by accounts.API.Clients, and sends the results
+// to the returned channel as they complete.
+//
+// The channel is closed once all the clients have been processed, and must be drained by the caller.
+// Once the context is cancelled, the remaining clients are reported with the context error without
+// executing the function. Rate limited calls are retried after the delay requested by the server,
+// during which no other calls are made.
+func Stream[T any](ctx context.Context, api accounts.API, fn Func[T], options ...Option) (<-chan Result[T], error) {
+	ops := newOptions(options)
+	accountClients, err := api.Clients()
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make(chan *accounts.Client)
+	results := make(chan Result[T])
+	g := &gate{ops: ops}
+
+	workers := ops.workers
+	if workers > len(accountClients) {
+		workers = len(accountClients)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for client := range jobs {
+				results <- execute(ctx, client, fn, ops, g)
+			}
+		}()
+	}
+
+	go func() {
+		for _, client := range accountClients {
+			jobs <- client
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+	return results, nil
+}
+
+// Run executes the function for every client returned by accounts.API.Clients, and waits for all of them to complete.
+//
+// The values returned by the successful calls are keyed by client ID. If any of the calls fails, an Errors value is
+// returned alongside the successful values.
+func Run[T any](ctx context.Context, api accounts.API, fn Func[T], options ...Option) (map[string]T, error) {
+	results, err := Stream(ctx, api, fn, options...)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]T)
+	errs := make(Errors)
+	for result := range results {
+		if result.Err != nil {
+			errs[result.Client.ID] = result.Err
+			continue
+		}
+		values[result.Client.ID] = result.Value
+	}
+	if len(errs) > 0 {
+		return values, errs
+	}
+	return values, nil
+}
+
+func execute[T any](ctx context.Context, client *accounts.Client, fn Func[T], ops *options, g *gate) Result[T] {
+	for attempt := 0; ; attempt++ {
+		if err := g.wait(ctx); err != nil {
+			return Result[T]{Client: client, Err: err}
+		}
+		value, err := fn(ctx, client)
+		if err == nil || attempt >= ops.retries || !createsend.IsRateLimited(err) {
+			return Result[T]{Client: client, Value: value, Err: err}
+		}
+		g.pause(retryDelay(err, ops.backoff, attempt))
+	}
+}
+
+// retryDelay returns the delay requested by the server, or an exponential backoff if the server has not specified any.
+func retryDelay(err error, backoff time.Duration, attempt int) time.Duration {
+	var csErr *createsend.Error
+	if errors.As(err, &csErr) {
+		if delay, ok := csErr.RetryAfter(); ok {
+			return delay
+		}
+	}
+	return backoff << attempt
+}
+
+// gate holds all the workers back once the server starts throttling the requests.
+type gate struct {
+	ops   *options
+	mux   sync.Mutex
+	until time.Time
+}
+
+func (g *gate) pause(delay time.Duration) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+	if until := g.ops.now().Add(delay); until.After(g.until) {
+		g.until = until
+	}
+}
+
+func (g *gate) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	g.mux.Lock()
+	delay := g.until.Sub(g.ops.now())
+	g.mux.Unlock()
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-g.ops.after(delay):
+		return nil
+	}
+}
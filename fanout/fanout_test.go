@@ -0,0 +1,287 @@
+package fanout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+)
+
+var errAPI = errors.New("api error")
+
+type accountsStub struct {
+	accounts.API
+	clients []*accounts.Client
+	err     error
+}
+
+func (a *accountsStub) Clients() ([]*accounts.Client, error) {
+	return a.clients, a.err
+}
+
+func newAccounts(count int) *accountsStub {
+	stub := &accountsStub{}
+	for i := 1; i <= count; i++ {
+		stub.clients = append(stub.clients, &accounts.Client{ID: fmt.Sprintf("c%d", i), Name: fmt.Sprintf("Client %d", i)})
+	}
+	return stub
+}
+
+// fakeClock records the requested delays and fires immediately.
+type fakeClock struct {
+	mux    sync.Mutex
+	now    time.Time
+	delays []time.Duration
+}
+
+func (f *fakeClock) option() Option {
+	return func(ops *options) {
+		ops.now = func() time.Time {
+			f.mux.Lock()
+			defer f.mux.Unlock()
+			return f.now
+		}
+		ops.after = func(d time.Duration) <-chan time.Time {
+			f.mux.Lock()
+			defer f.mux.Unlock()
+			f.delays = append(f.delays, d)
+			f.now = f.now.Add(d)
+			ch := make(chan time.Time, 1)
+			ch <- f.now
+			return ch
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	api := newAccounts(10)
+	var running, maxRunning int32
+	values, err := Run(context.Background(), api, func(ctx context.Context, client *accounts.Client) (string, error) {
+		current := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if current <= max || atomic.CompareAndSwapInt32(&maxRunning, max, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		if client.ID == "c3" || client.ID == "c7" {
+			return "", errAPI
+		}
+		return client.Name, nil
+	}, WithWorkers(3))
+
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Expected fanout.Errors, Actual: %v", err)
+	}
+	if diff := cmp.Diff([]string{"c3", "c7"}, errs.ClientIDs()); diff != "" {
+		t.Errorf("Failed clients mismatch (-expected +actual):\n%s", diff)
+	}
+	if !errors.Is(err, errAPI) {
+		t.Errorf("Expected the errors to wrap %v", errAPI)
+	}
+	if expected := "2 client(s) failed: c3: api error; c7: api error"; err.Error() != expected {
+		t.Errorf("Expected: %q, Actual: %q", expected, err.Error())
+	}
+	if len(values) != 8 || values["c1"] != "Client 1" || values["c10"] != "Client 10" {
+		t.Errorf("Unexpected values: %v", values)
+	}
+	if maxRunning > 3 {
+		t.Errorf("Expected at most 3 concurrent calls, Actual: %d", maxRunning)
+	}
+}
+
+func TestRun_Success(t *testing.T) {
+	values, err := Run(context.Background(), newAccounts(2), func(ctx context.Context, client *accounts.Client) (int, error) {
+		return len(client.Name), nil
+	})
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(map[string]int{"c1": 8, "c2": 8}, values); diff != "" {
+		t.Errorf("Values mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRun_ClientsFailure(t *testing.T) {
+	api := &accountsStub{err: errAPI}
+	values, err := Run(context.Background(), api, func(ctx context.Context, client *accounts.Client) (int, error) {
+		t.Error("Did not expect the function to be called")
+		return 0, nil
+	})
+	if !errors.Is(err, errAPI) {
+		t.Fatalf("Expected error: %v, Actual: %v", errAPI, err)
+	}
+	if values != nil {
+		t.Errorf("Expected nil values, Actual: %v", values)
+	}
+}
+
+func TestStream_Cancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results, err := Stream(ctx, newAccounts(5), func(ctx context.Context, client *accounts.Client) (string, error) {
+		if client.ID == "c2" {
+			cancel()
+		}
+		return client.ID, nil
+	}, WithWorkers(1))
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	var actual []string
+	for result := range results {
+		actual = append(actual, fmt.Sprintf("%s %s %v", result.Client.ID, result.Value, result.Err))
+	}
+	expected := []string{
+		"c1 c1 <nil>",
+		"c2 c2 <nil>",
+		"c3  context canceled",
+		"c4  context canceled",
+		"c5  context canceled",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Results mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+var errRateLimited = &createsend.Error{StatusCode: http.StatusTooManyRequests}
+
+func TestStream_CancelledWhileThrottled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := Stream(ctx, newAccounts(1), func(ctx context.Context, client *accounts.Client) (string, error) {
+		cancel()
+		return "", errRateLimited
+	}, WithBackoff(time.Hour))
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	result := <-results
+	if !errors.Is(result.Err, context.Canceled) {
+		t.Errorf("Expected error: %v, Actual: %v", context.Canceled, result.Err)
+	}
+}
+
+func TestStream_WaitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ops := newOptions([]Option{func(ops *options) {
+		ops.after = func(time.Duration) <-chan time.Time {
+			cancel()
+			return make(chan time.Time)
+		}
+	}})
+	g := &gate{ops: ops}
+	g.pause(time.Hour)
+	if err := g.wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected error: %v, Actual: %v", context.Canceled, err)
+	}
+}
+
+func TestRun_RateLimits(t *testing.T) {
+	withRetryAfter := &createsend.Error{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"7"}},
+	}
+	testCases := []struct {
+		title          string
+		errs           []error
+		options        []Option
+		expectedCalls  int
+		expectedError  error
+		expectedDelays []time.Duration
+	}{
+		{
+			title:          "exponential backoff",
+			errs:           []error{errRateLimited, errRateLimited},
+			options:        []Option{WithBackoff(time.Second)},
+			expectedCalls:  3,
+			expectedDelays: []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			title:          "server delay",
+			errs:           []error{withRetryAfter},
+			expectedCalls:  2,
+			expectedDelays: []time.Duration{7 * time.Second},
+		},
+		{
+			title:          "retries exhausted",
+			errs:           []error{errRateLimited, errRateLimited, errRateLimited},
+			options:        []Option{WithRateLimitRetries(2), WithBackoff(time.Millisecond)},
+			expectedCalls:  3,
+			expectedError:  errRateLimited,
+			expectedDelays: []time.Duration{time.Millisecond, 2 * time.Millisecond},
+		},
+		{
+			title:         "retries disabled",
+			errs:          []error{errRateLimited},
+			options:       []Option{WithRateLimitRetries(0)},
+			expectedCalls: 1,
+			expectedError: errRateLimited,
+		},
+		{
+			title:         "other errors are not retried",
+			errs:          []error{createsend.ErrClientNotFound},
+			expectedCalls: 1,
+			expectedError: createsend.ErrClientNotFound,
+		},
+		{
+			title:         "invalid options are ignored",
+			errs:          []error{errRateLimited},
+			options:       []Option{WithWorkers(0), WithRateLimitRetries(-1), WithBackoff(0)},
+			expectedCalls: 2,
+			expectedDelays: []time.Duration{
+				defaultBackoff,
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+			calls := 0
+			_, err := Run(context.Background(), newAccounts(1), func(ctx context.Context, client *accounts.Client) (int, error) {
+				calls++
+				if calls <= len(tC.errs) {
+					return 0, tC.errs[calls-1]
+				}
+				return calls, nil
+			}, append(tC.options, clock.option())...)
+			if !errors.Is(err, tC.expectedError) {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if calls != tC.expectedCalls {
+				t.Errorf("Expected %d calls, Actual: %d", tC.expectedCalls, calls)
+			}
+			if diff := cmp.Diff(tC.expectedDelays, clock.delays); diff != "" {
+				t.Errorf("Delays mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGate_SharedPause(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+	g := &gate{ops: newOptions([]Option{clock.option()})}
+	g.pause(10 * time.Second)
+	g.pause(time.Second)
+	if err := g.wait(context.Background()); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if err := g.wait(context.Background()); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff([]time.Duration{10 * time.Second}, clock.delays); diff != "" {
+		t.Errorf("Delays mismatch (-expected +actual):\n%s", diff)
+	}
+}
@@ -0,0 +1,65 @@
+package fanout
+
+import "time"
+
+const (
+	defaultWorkers = 4
+	defaultRetries = 3
+	defaultBackoff = time.Second
+)
+
+type options struct {
+	workers int
+	retries int
+	backoff time.Duration
+	now     func() time.Time
+	after   func(time.Duration) <-chan time.Time
+}
+
+func newOptions(opts []Option) *options {
+	ops := &options{
+		workers: defaultWorkers,
+		retries: defaultRetries,
+		backoff: defaultBackoff,
+		now:     time.Now,
+		after:   time.After,
+	}
+	for _, op := range opts {
+		op(ops)
+	}
+	return ops
+}
+
+// Option represents a fan-out option.
+type Option func(ops *options)
+
+// WithWorkers sets the maximum number of the clients which are processed concurrently (default 4).
+func WithWorkers(workers int) Option {
+	return func(ops *options) {
+		if workers > 0 {
+			ops.workers = workers
+		}
+	}
+}
+
+// WithRateLimitRetries sets the maximum number of times a rate limited call is retried (default 3).
+//
+// Zero disables the retries.
+func WithRateLimitRetries(retries int) Option {
+	return func(ops *options) {
+		if retries >= 0 {
+			ops.retries = retries
+		}
+	}
+}
+
+// WithBackoff sets the initial delay before retrying a rate limited call, if the server has not specified any (default 1s).
+//
+// The delay is doubled after each attempt.
+func WithBackoff(backoff time.Duration) Option {
+	return func(ops *options) {
+		if backoff > 0 {
+			ops.backoff = backoff
+		}
+	}
+}
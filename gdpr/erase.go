@@ -0,0 +1,195 @@
+package gdpr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xitonix/createsend/clients"
+)
+
+// ActionType represents the type of an erasure action.
+type ActionType int8
+
+const (
+	// DeleteSubscriber marks the subscriber with the email address as deleted in a subscriber list.
+	DeleteSubscriber ActionType = iota + 1
+	// DeletePerson deletes a client person.
+	DeletePerson
+	// DeleteAdministrator deletes an account administrator.
+	DeleteAdministrator
+	// Suppress adds the email address to a client's suppression list.
+	Suppress
+	// UnSuppress removes the email address from a client's suppression list.
+	UnSuppress
+)
+
+// String Stringer implementation
+func (t ActionType) String() string {
+	switch t {
+	case DeleteSubscriber:
+		return "delete subscriber"
+	case DeletePerson:
+		return "delete person"
+	case DeleteAdministrator:
+		return "delete administrator"
+	case Suppress:
+		return "suppress"
+	case UnSuppress:
+		return "unsuppress"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON marshal the object into json bytes.
+func (t ActionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+// Action represents an executed erasure action.
+type Action struct {
+	// Type the action type.
+	Type ActionType
+	// ClientID the ID of the client, or an empty string for the account level actions.
+	ClientID string `json:",omitempty"`
+	// ListID the ID of the subscriber list, for DeleteSubscriber actions.
+	ListID string `json:",omitempty"`
+	// ExecutedAt the time when the action was executed.
+	ExecutedAt time.Time
+	// Err the error returned by the API, or nil if the action has succeeded.
+	Err error `json:"-"`
+	// Error the error message, or an empty string if the action has succeeded.
+	Error string `json:",omitempty"`
+}
+
+// Report represents the auditable outcome of an erasure.
+type Report struct {
+	// EmailAddress the email address of the data subject.
+	EmailAddress string
+	// StartedAt the time when the erasure has started.
+	StartedAt time.Time
+	// FinishedAt the time when the erasure has finished.
+	FinishedAt time.Time
+	// KeptSuppressed is true if the email address has been kept suppressed.
+	KeptSuppressed bool
+	// Actions the executed actions, in order.
+	Actions []*Action
+}
+
+// Failed returns the failed actions.
+func (r *Report) Failed() []*Action {
+	var failed []*Action
+	for _, action := range r.Actions {
+		if action.Err != nil {
+			failed = append(failed, action)
+		}
+	}
+	return failed
+}
+
+// Write writes the report to w in indented JSON format.
+func (r *Report) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// IncompleteError occurs when some of the erasure actions have failed.
+//
+// The underlying API errors can be retrieved using errors.Is and errors.As.
+type IncompleteError struct {
+	// Failed the failed actions.
+	Failed []*Action
+}
+
+// Error returns the string representation of the error.
+func (e *IncompleteError) Error() string {
+	messages := make([]string, len(e.Failed))
+	for i, action := range e.Failed {
+		messages[i] = fmt.Sprintf("%s: %v", action.Type, action.Err)
+	}
+	return fmt.Sprintf("%d erasure action(s) failed: %s", len(e.Failed), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed actions.
+func (e *IncompleteError) Unwrap() []error {
+	errs := make([]error, len(e.Failed))
+	for i, action := range e.Failed {
+		errs[i] = action.Err
+	}
+	return errs
+}
+
+// Erase removes the email address from the subscriber lists, the client people and the account administrators.
+//
+// The API does not purge subscribers: a deleted subscriber is only marked as Deleted, and its details, including
+// the custom fields, remain in the list until they are permanently deleted using the Campaign Monitor web application.
+//
+// The email address is also removed from the suppression lists, unless WithKeepSuppressed is enabled, in which case
+// it gets suppressed in every client it has been found in. The transactional message history cannot be erased using the API.
+// Nothing is erased if the lookup fails for any of the clients. Otherwise, all the actions are executed even if some of
+// them fail, and the report is returned alongside an *IncompleteError.
+func (p *Processor) Erase(ctx context.Context, emailAddress string) (*Report, error) {
+	found, err := p.find(ctx, emailAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{
+		EmailAddress:   found.EmailAddress,
+		StartedAt:      p.now().UTC(),
+		KeptSuppressed: p.keepSuppressed,
+	}
+	execute := func(action *Action, fn func() error) {
+		action.Err = fn()
+		action.ExecutedAt = p.now().UTC()
+		if action.Err != nil {
+			action.Error = action.Err.Error()
+		}
+		report.Actions = append(report.Actions, action)
+	}
+
+	email := found.EmailAddress
+	clientsAPI := p.apis.Clients()
+	for _, c := range found.Clients {
+		clientID := c.Client.ID
+		for _, list := range c.Lists {
+			if list.Subscriber.State == clients.DeletedSubscriber {
+				continue
+			}
+			execute(&Action{Type: DeleteSubscriber, ClientID: clientID, ListID: list.ID}, func() error {
+				return p.apis.Subscribers().Delete(list.ID, email)
+			})
+		}
+		if c.Person != nil {
+			execute(&Action{Type: DeletePerson, ClientID: clientID}, func() error {
+				return clientsAPI.DeletePerson(clientID, c.Person.EmailAddress)
+			})
+		}
+		switch {
+		case p.keepSuppressed && c.Suppression == nil:
+			execute(&Action{Type: Suppress, ClientID: clientID}, func() error {
+				return clientsAPI.Suppress(clientID, email)
+			})
+		case !p.keepSuppressed && c.Suppression != nil:
+			execute(&Action{Type: UnSuppress, ClientID: clientID}, func() error {
+				return clientsAPI.UnSuppress(clientID, c.Suppression.EmailAddress)
+			})
+		}
+	}
+	if found.Administrator != nil {
+		execute(&Action{Type: DeleteAdministrator}, func() error {
+			return p.apis.Accounts().DeleteAdministrator(found.Administrator.EmailAddress)
+		})
+	}
+
+	report.FinishedAt = p.now().UTC()
+	if failed := report.Failed(); len(failed) > 0 {
+		return report, &IncompleteError{Failed: failed}
+	}
+	return report, nil
+}
@@ -0,0 +1,149 @@
+package gdpr
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/fanout"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+// Version the current export format version.
+const Version = 1
+
+// Export represents all the data associated with an email address.
+type Export struct {
+	// Version the export format version.
+	Version int
+	// EmailAddress the email address of the data subject.
+	EmailAddress string
+	// CreatedAt the time when the data was exported.
+	CreatedAt time.Time
+	// Administrator the account administrator with the email address, if any.
+	Administrator *accounts.AdministratorDetails `json:",omitempty"`
+	// Clients the data held by each client, sorted by client name.
+	Clients []*ClientData
+}
+
+// ClientData represents the data associated with an email address within a single client.
+type ClientData struct {
+	// Client the client.
+	Client *accounts.Client
+	// Subscriptions the subscriber lists the email address belongs to.
+	Subscriptions []*Subscription `json:",omitempty"`
+	// Suppression the suppression list entry of the email address, if any.
+	Suppression *clients.SuppressionDetails `json:",omitempty"`
+	// Person the client person with the email address, if any.
+	Person *clients.PersonDetails `json:",omitempty"`
+	// Messages the transactional messages sent to the email address, newest first.
+	Messages []*transactional.Message `json:",omitempty"`
+}
+
+// Subscription represents the membership of an email address in a subscriber list.
+type Subscription struct {
+	// List the subscriber list.
+	List clients.List
+	// Subscriber the subscriber details, including the custom fields.
+	Subscriber *subscribers.Details
+}
+
+// Write writes the export to w in indented JSON format.
+func (e *Export) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(e)
+}
+
+// Export gathers all the data associated with the email address: the list memberships (including the custom fields),
+// the suppression list entries, the client people and account administrator records, and the transactional message history
+// unless WithMessages is disabled.
+//
+// The export fails if the data of any of the clients cannot be retrieved, so that an incomplete export is never returned.
+func (p *Processor) Export(ctx context.Context, emailAddress string) (*Export, error) {
+	report, err := p.find(ctx, emailAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		Version:       Version,
+		EmailAddress:  report.EmailAddress,
+		CreatedAt:     p.now().UTC(),
+		Administrator: report.Administrator,
+	}
+	byID := make(map[string]*ClientData)
+	for _, clientReport := range report.Clients {
+		data := &ClientData{
+			Client:      clientReport.Client,
+			Suppression: clientReport.Suppression,
+			Person:      clientReport.Person,
+		}
+		for _, list := range clientReport.Lists {
+			details, err := p.apis.Subscribers().Get(list.ID, report.EmailAddress)
+			if err != nil {
+				return nil, err
+			}
+			data.Subscriptions = append(data.Subscriptions, &Subscription{List: list.List, Subscriber: details})
+		}
+		byID[data.Client.ID] = data
+		export.Clients = append(export.Clients, data)
+	}
+
+	if p.messages {
+		messages, err := fanout.Run(ctx, p.apis.Accounts(), func(ctx context.Context, client *accounts.Client) (*ClientData, error) {
+			found, err := p.exportMessages(client.ID, report.EmailAddress)
+			return &ClientData{Client: client, Messages: found}, err
+		}, fanout.WithWorkers(p.workers))
+		if err != nil {
+			return nil, err
+		}
+		for clientID, found := range messages {
+			if len(found.Messages) == 0 {
+				continue
+			}
+			data, ok := byID[clientID]
+			if !ok {
+				data = found
+				export.Clients = append(export.Clients, data)
+			}
+			data.Messages = found.Messages
+		}
+	}
+
+	sort.Slice(export.Clients, func(i, j int) bool {
+		a, b := export.Clients[i].Client, export.Clients[j].Client
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	return export, nil
+}
+
+// exportMessages pages through the client's entire transactional message timeline, since the API
+// does not support filtering the messages by recipient.
+func (p *Processor) exportMessages(clientID, emailAddress string) ([]*transactional.Message, error) {
+	var result []*transactional.Message
+	options := []transactional.Option{transactional.WithClientID(clientID), transactional.WithCount(messagePageSize)}
+	for {
+		messages, err := p.apis.Transactional().MessageTimeline(options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if isRecipient(message.Recipient, emailAddress) {
+				result = append(result, message)
+			}
+		}
+		if len(messages) < messagePageSize {
+			return result, nil
+		}
+		options = append(options[:2:2], transactional.WithSentBeforeID(messages[len(messages)-1].ID))
+	}
+}
@@ -0,0 +1,82 @@
+// Package gdpr exports and erases the personal data associated with an email address (the data subject)
+// across all the clients of an account.
+package gdpr
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/lookup"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const (
+	defaultWorkers  = 4
+	defaultPageSize = 1000
+	messagePageSize = 200
+)
+
+// ErrEmptyEmailAddress occurs when the email address of the data subject is empty.
+var ErrEmptyEmailAddress = errors.New("the email address must not be empty")
+
+// APIs provides access to the Campaign Monitor APIs.
+//
+// *createsend.Client implements the interface.
+type APIs interface {
+	// Accounts returns the accounts API.
+	Accounts() accounts.API
+	// Clients returns the clients API.
+	Clients() clients.API
+	// Subscribers returns the subscribers API.
+	Subscribers() subscribers.API
+	// Transactional returns the transactional API.
+	Transactional() transactional.API
+}
+
+// Processor processes the data subject requests.
+type Processor struct {
+	apis           APIs
+	workers        int
+	pageSize       int
+	messages       bool
+	keepSuppressed bool
+	now            func() time.Time
+}
+
+// New creates a new data subject request processor.
+func New(apis APIs, options ...Option) *Processor {
+	p := &Processor{
+		apis:     apis,
+		workers:  defaultWorkers,
+		pageSize: defaultPageSize,
+		messages: true,
+		now:      time.Now,
+	}
+	for _, op := range options {
+		op(p)
+	}
+	return p
+}
+
+func (p *Processor) find(ctx context.Context, emailAddress string) (*lookup.Report, error) {
+	emailAddress = strings.TrimSpace(emailAddress)
+	if len(emailAddress) == 0 {
+		return nil, ErrEmptyEmailAddress
+	}
+	finder := lookup.New(p.apis.Accounts(), p.apis.Clients(), lookup.WithWorkers(p.workers), lookup.WithPageSize(p.pageSize))
+	return finder.Find(ctx, emailAddress)
+}
+
+// isRecipient returns true if the message recipient (eg. "Joe <joe@example.com>") has the specified email address.
+func isRecipient(recipient, emailAddress string) bool {
+	if address, err := mail.ParseAddress(recipient); err == nil {
+		recipient = address.Address
+	}
+	return strings.EqualFold(strings.TrimSpace(recipient), emailAddress)
+}
@@ -0,0 +1,452 @@
+package gdpr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/fanout"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+var (
+	errAPI  = errors.New("api error")
+	now     = time.Date(2021, 2, 3, 4, 5, 6, 0, time.UTC)
+	added   = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	alpha   = &accounts.Client{ID: "c1", Name: "Alpha"}
+	beta    = &accounts.Client{ID: "c2", Name: "Beta"}
+	gamma   = &accounts.Client{ID: "c3", Name: "Gamma"}
+	admin   = &accounts.AdministratorDetails{Administrator: accounts.Administrator{EmailAddress: "A@b.com", Name: "Admin"}, Status: accounts.ActiveAdministrator}
+	person  = &clients.PersonDetails{PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: "a@B.com", Name: "Person", AccessLevel: clients.FullAccess}}
+	blocked = &clients.SuppressionDetails{EmailAddress: "a@b.com", Reason: clients.UnsubscribedSuppression}
+	details = &subscribers.Details{EmailAddress: "a@b.com", Name: "Subscriber", CustomFields: []*subscribers.CustomField{{Key: "k", Value: "v"}}}
+)
+
+type clientData struct {
+	lists        []*clients.SubscriberList
+	suppressions []*clients.SuppressionDetails
+	people       []*clients.PersonDetails
+	messages     []*transactional.Message
+}
+
+// apisStub records the API calls, and fails the calls registered in errs.
+type apisStub struct {
+	mux            sync.Mutex
+	clients        []*accounts.Client
+	administrators []*accounts.AdministratorDetails
+	data           map[string]*clientData
+	errs           map[string]error
+	calls          []string
+}
+
+func (a *apisStub) record(call string) error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	a.calls = append(a.calls, call)
+	return a.errs[call]
+}
+
+func (a *apisStub) Accounts() accounts.API           { return accountsStub{s: a} }
+func (a *apisStub) Clients() clients.API             { return clientsStub{s: a} }
+func (a *apisStub) Subscribers() subscribers.API     { return subscribersStub{s: a} }
+func (a *apisStub) Transactional() transactional.API { return transactionalStub{s: a} }
+
+type accountsStub struct {
+	accounts.API
+	s *apisStub
+}
+
+func (a accountsStub) Clients() ([]*accounts.Client, error) {
+	return a.s.clients, a.s.errs["Clients"]
+}
+
+func (a accountsStub) Administrators() ([]*accounts.AdministratorDetails, error) {
+	return a.s.administrators, a.s.errs["Administrators"]
+}
+
+func (a accountsStub) DeleteAdministrator(emailAddress string) error {
+	return a.s.record("DeleteAdministrator " + emailAddress)
+}
+
+type clientsStub struct {
+	clients.API
+	s *apisStub
+}
+
+func (c clientsStub) ListsByEmailAddress(clientID, emailAddress string) ([]*clients.SubscriberList, error) {
+	return c.s.data[clientID].lists, c.s.errs["ListsByEmailAddress "+clientID]
+}
+
+func (c clientsStub) SuppressionList(clientID string, pageSize, page int, orderBy order.SuppressionListField, direction order.Direction) (*clients.SuppressionList, error) {
+	return &clients.SuppressionList{Entries: c.s.data[clientID].suppressions, NumberOfPages: 1}, nil
+}
+
+func (c clientsStub) People(clientID string) ([]*clients.PersonDetails, error) {
+	return c.s.data[clientID].people, nil
+}
+
+func (c clientsStub) DeletePerson(clientID, emailAddress string) error {
+	return c.s.record(fmt.Sprintf("DeletePerson %s %s", clientID, emailAddress))
+}
+
+func (c clientsStub) Suppress(clientID string, emails ...string) error {
+	return c.s.record(fmt.Sprintf("Suppress %s %v", clientID, emails))
+}
+
+func (c clientsStub) UnSuppress(clientID, email string) error {
+	return c.s.record(fmt.Sprintf("UnSuppress %s %s", clientID, email))
+}
+
+type subscribersStub struct {
+	subscribers.API
+	s *apisStub
+}
+
+func (s subscribersStub) Get(listID, emailAddress string) (*subscribers.Details, error) {
+	if err := s.s.record(fmt.Sprintf("Get %s %s", listID, emailAddress)); err != nil {
+		return nil, err
+	}
+	return details, nil
+}
+
+func (s subscribersStub) Delete(listID, emailAddress string) error {
+	return s.s.record(fmt.Sprintf("Delete %s %s", listID, emailAddress))
+}
+
+type transactionalStub struct {
+	transactional.API
+	s *apisStub
+}
+
+func (t transactionalStub) MessageTimeline(options ...transactional.Option) ([]*transactional.Message, error) {
+	ops := &transactional.Options{}
+	for _, op := range options {
+		op(ops)
+	}
+	if err := t.s.record(fmt.Sprintf("MessageTimeline %s %d %s", ops.ClientID(), ops.Count(), ops.SentBeforeID())); err != nil {
+		return nil, err
+	}
+	messages := t.s.data[ops.ClientID()].messages
+	start := 0
+	for i, message := range messages {
+		if message.ID == ops.SentBeforeID() {
+			start = i + 1
+		}
+	}
+	end := start + ops.Count()
+	if end > len(messages) {
+		end = len(messages)
+	}
+	return messages[start:end], nil
+}
+
+func subscribed(listID string, state clients.SubscriberState) *clients.SubscriberList {
+	return &clients.SubscriberList{
+		List:       clients.List{ID: listID, Name: "List " + listID},
+		Subscriber: clients.Subscriber{State: state, DateAdded: added},
+	}
+}
+
+func messages(count int, recipient func(i int) string) []*transactional.Message {
+	result := make([]*transactional.Message, count)
+	for i := range result {
+		result[i] = &transactional.Message{ID: fmt.Sprintf("m%d", i), Recipient: recipient(i), Status: transactional.DeliveredMessage}
+	}
+	return result
+}
+
+func newStub() *apisStub {
+	return &apisStub{
+		clients:        []*accounts.Client{beta, alpha, gamma},
+		administrators: []*accounts.AdministratorDetails{admin},
+		data: map[string]*clientData{
+			"c1": {
+				lists:        []*clients.SubscriberList{subscribed("l1", clients.ActiveSubscriber), subscribed("l2", clients.DeletedSubscriber)},
+				suppressions: []*clients.SuppressionDetails{blocked},
+			},
+			"c2": {
+				people: []*clients.PersonDetails{person},
+			},
+			"c3": {
+				messages: messages(450, func(i int) string {
+					if i%200 == 0 {
+						return "Joe <A@b.com>"
+					}
+					return "x@b.com"
+				}),
+			},
+		},
+		errs: map[string]error{},
+	}
+}
+
+func newProcessor(stub *apisStub, options ...Option) *Processor {
+	p := New(stub, append([]Option{WithWorkers(1)}, options...)...)
+	p.now = func() time.Time { return now }
+	return p
+}
+
+func TestProcessor_Export(t *testing.T) {
+	stub := newStub()
+	export, err := newProcessor(stub).Export(context.Background(), " a@b.com ")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	c3 := stub.data["c3"].messages
+	expected := &Export{
+		Version:       Version,
+		EmailAddress:  "a@b.com",
+		CreatedAt:     now,
+		Administrator: admin,
+		Clients: []*ClientData{
+			{
+				Client: alpha,
+				Subscriptions: []*Subscription{
+					{List: clients.List{ID: "l1", Name: "List l1"}, Subscriber: details},
+					{List: clients.List{ID: "l2", Name: "List l2"}, Subscriber: details},
+				},
+				Suppression: blocked,
+			},
+			{Client: beta, Person: person},
+			{Client: gamma, Messages: []*transactional.Message{c3[0], c3[200], c3[400]}},
+		},
+	}
+	if diff := cmp.Diff(expected, export); diff != "" {
+		t.Errorf("Export mismatch (-want +got):\n%s", diff)
+	}
+
+	expectedCalls := []string{
+		"Get l1 a@b.com",
+		"Get l2 a@b.com",
+		"MessageTimeline c2 200 ",
+		"MessageTimeline c1 200 ",
+		"MessageTimeline c3 200 ",
+		"MessageTimeline c3 200 m199",
+		"MessageTimeline c3 200 m399",
+	}
+	if diff := cmp.Diff(expectedCalls, stub.calls); diff != "" {
+		t.Errorf("Calls mismatch (-want +got):\n%s", diff)
+	}
+
+	var buf bytes.Buffer
+	if err := export.Write(&buf); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	var decoded Export
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(expected, &decoded); diff != "" {
+		t.Errorf("Written export mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestProcessor_Export_WithoutMessages(t *testing.T) {
+	stub := newStub()
+	export, err := newProcessor(stub, WithPageSize(10), WithPageSize(0), WithWorkers(0), WithMessages(false)).Export(context.Background(), "a@b.com")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if len(export.Clients) != 2 {
+		t.Errorf("Expected clients: 2, Actual: %d", len(export.Clients))
+	}
+	for _, call := range stub.calls {
+		if call[:3] != "Get" {
+			t.Errorf("Unexpected call: %s", call)
+		}
+	}
+}
+
+func TestProcessor_Export_Failures(t *testing.T) {
+	testCases := []struct {
+		title       string
+		email       string
+		errs        map[string]error
+		expectedErr error
+	}{
+		{title: "empty email address", email: " ", expectedErr: ErrEmptyEmailAddress},
+		{title: "lookup failure", email: "a@b.com", errs: map[string]error{"ListsByEmailAddress c2": errAPI}, expectedErr: errAPI},
+		{title: "subscriber details failure", email: "a@b.com", errs: map[string]error{"Get l2 a@b.com": errAPI}, expectedErr: errAPI},
+		{title: "message timeline failure", email: "a@b.com", errs: map[string]error{"MessageTimeline c3 200 m199": errAPI}, expectedErr: errAPI},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			stub := newStub()
+			stub.errs = tC.errs
+			export, err := newProcessor(stub).Export(context.Background(), tC.email)
+			if !errors.Is(err, tC.expectedErr) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedErr, err)
+			}
+			if export != nil {
+				t.Errorf("Expected no export, Actual: %+v", export)
+			}
+		})
+	}
+}
+
+func TestProcessor_Erase(t *testing.T) {
+	testCases := []struct {
+		title          string
+		options        []Option
+		errs           map[string]error
+		expected       *Report
+		expectedFailed int
+	}{
+		{
+			title: "remove suppression",
+			expected: &Report{
+				EmailAddress: "a@b.com",
+				StartedAt:    now,
+				FinishedAt:   now,
+				Actions: []*Action{
+					{Type: DeleteSubscriber, ClientID: "c1", ListID: "l1", ExecutedAt: now},
+					{Type: UnSuppress, ClientID: "c1", ExecutedAt: now},
+					{Type: DeletePerson, ClientID: "c2", ExecutedAt: now},
+					{Type: DeleteAdministrator, ExecutedAt: now},
+				},
+			},
+		},
+		{
+			title:   "keep suppressed",
+			options: []Option{WithKeepSuppressed(true)},
+			errs: map[string]error{
+				"Delete l1 a@b.com":     errAPI,
+				"Suppress c2 [a@b.com]": errAPI,
+			},
+			expected: &Report{
+				EmailAddress:   "a@b.com",
+				StartedAt:      now,
+				FinishedAt:     now,
+				KeptSuppressed: true,
+				Actions: []*Action{
+					{Type: DeleteSubscriber, ClientID: "c1", ListID: "l1", ExecutedAt: now, Err: errAPI, Error: errAPI.Error()},
+					{Type: DeletePerson, ClientID: "c2", ExecutedAt: now},
+					{Type: Suppress, ClientID: "c2", ExecutedAt: now, Err: errAPI, Error: errAPI.Error()},
+					{Type: DeleteAdministrator, ExecutedAt: now},
+				},
+			},
+			expectedFailed: 2,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			stub := newStub()
+			stub.errs = tC.errs
+			report, err := newProcessor(stub, tC.options...).Erase(context.Background(), "a@b.com")
+
+			var incomplete *IncompleteError
+			if tC.expectedFailed == 0 && err != nil {
+				t.Errorf("Did not expect an error but received: '%v'", err)
+			}
+			if tC.expectedFailed > 0 {
+				if !errors.As(err, &incomplete) || len(incomplete.Failed) != tC.expectedFailed {
+					t.Fatalf("Expected %d failed action(s), Actual: %v", tC.expectedFailed, err)
+				}
+				if !errors.Is(err, errAPI) {
+					t.Errorf("Expected the API errors to be wrapped, Actual: %v", err)
+				}
+			}
+			if diff := cmp.Diff(tC.expected, report, cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Report mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tC.expected.Failed(), report.Failed(), cmpopts.EquateErrors()); diff != "" {
+				t.Errorf("Failed actions mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProcessor_Erase_LookupFailure(t *testing.T) {
+	stub := newStub()
+	stub.errs["ListsByEmailAddress c3"] = errAPI
+	report, err := newProcessor(stub).Erase(context.Background(), "a@b.com")
+	var errs fanout.Errors
+	if !errors.As(err, &errs) || !errors.Is(err, errAPI) {
+		t.Errorf("Expected a fan-out error, Actual: %v", err)
+	}
+	if report != nil || len(stub.calls) != 0 {
+		t.Errorf("Nothing must have been erased. Report: %+v, Calls: %v", report, stub.calls)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	report := &Report{
+		EmailAddress: "a@b.com",
+		StartedAt:    now,
+		FinishedAt:   now,
+		Actions: []*Action{
+			{Type: DeleteSubscriber, ClientID: "c1", ListID: "l1", ExecutedAt: now, Err: errAPI, Error: errAPI.Error()},
+			{Type: DeleteAdministrator, ExecutedAt: now},
+		},
+	}
+	var buf bytes.Buffer
+	if err := report.Write(&buf); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expected := `{
+  "EmailAddress": "a@b.com",
+  "StartedAt": "2021-02-03T04:05:06Z",
+  "FinishedAt": "2021-02-03T04:05:06Z",
+  "KeptSuppressed": false,
+  "Actions": [
+    {
+      "Type": "delete subscriber",
+      "ClientID": "c1",
+      "ListID": "l1",
+      "ExecutedAt": "2021-02-03T04:05:06Z",
+      "Error": "api error"
+    },
+    {
+      "Type": "delete administrator",
+      "ExecutedAt": "2021-02-03T04:05:06Z"
+    }
+  ]
+}
+`
+	if diff := cmp.Diff(expected, buf.String()); diff != "" {
+		t.Errorf("Report mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestActionType_String(t *testing.T) {
+	testCases := []struct {
+		input    ActionType
+		expected string
+	}{
+		{input: DeleteSubscriber, expected: "delete subscriber"},
+		{input: DeletePerson, expected: "delete person"},
+		{input: DeleteAdministrator, expected: "delete administrator"},
+		{input: Suppress, expected: "suppress"},
+		{input: UnSuppress, expected: "unsuppress"},
+		{input: 0, expected: "unknown"},
+	}
+	for _, tC := range testCases {
+		if actual := tC.input.String(); actual != tC.expected {
+			t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+		}
+	}
+}
+
+func TestIncompleteError(t *testing.T) {
+	err := &IncompleteError{Failed: []*Action{
+		{Type: DeletePerson, Err: errAPI},
+		{Type: Suppress, Err: errors.New("other")},
+	}}
+	expected := "2 erasure action(s) failed: delete person: api error; suppress: other"
+	if err.Error() != expected {
+		t.Errorf("Expected: %q, Actual: %q", expected, err.Error())
+	}
+}
@@ -0,0 +1,42 @@
+package gdpr
+
+// Option represents a processor option.
+type Option func(p *Processor)
+
+// WithWorkers sets the maximum number of the clients which are processed concurrently (default 4).
+func WithWorkers(workers int) Option {
+	return func(p *Processor) {
+		if workers > 0 {
+			p.workers = workers
+		}
+	}
+}
+
+// WithPageSize sets the page size used to search the suppression lists (default 1000).
+func WithPageSize(pageSize int) Option {
+	return func(p *Processor) {
+		if pageSize > 0 {
+			p.pageSize = pageSize
+		}
+	}
+}
+
+// WithMessages enables exporting the transactional message history (enabled by default).
+//
+// The API does not support filtering the messages by recipient, so the entire message timeline
+// of every client is scanned, which can take many requests on busy accounts.
+func WithMessages(enabled bool) Option {
+	return func(p *Processor) {
+		p.messages = enabled
+	}
+}
+
+// WithKeepSuppressed keeps the email address suppressed in the clients it has been erased from,
+// so that it cannot be emailed again if it gets re-imported (disabled by default).
+//
+// If disabled, the email address is also removed from the suppression lists.
+func WithKeepSuppressed(enabled bool) Option {
+	return func(p *Processor) {
+		p.keepSuppressed = enabled
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 )
 
 const (
@@ -24,13 +25,15 @@ type HTTPClient interface {
 }
 
 type httpClient struct {
-	client  HTTPClient
-	auth    *authentication
-	baseURL *url.URL
-	ctx     context.Context
+	client   HTTPClient
+	auth     *authentication
+	baseURL  *url.URL
+	ctx      context.Context
+	logger   *requestLogger
+	location *locationResolver
 }
 
-func newHTTPClient(ctx context.Context, baseURL string, client HTTPClient, auth *authentication) (*httpClient, error) {
+func newHTTPClient(ctx context.Context, baseURL string, client HTTPClient, auth *authentication, middlewares ...Middleware) (*httpClient, error) {
 	if client == nil {
 		return nil, newClientError(ErrCodeNilHTTPClient)
 	}
@@ -60,13 +63,20 @@ func newHTTPClient(ctx context.Context, baseURL string, client HTTPClient, auth
 	}
 
 	return &httpClient{
-		client:  client,
+		client:  chain(client, middlewares),
 		auth:    auth,
 		baseURL: base,
 		ctx:     ctx,
 	}, nil
 }
 
+// withContext returns a shallow copy of the client which sends the requests within the specified context.
+func (h *httpClient) withContext(ctx context.Context) *httpClient {
+	clone := *h
+	clone.ctx = ctx
+	return &clone
+}
+
 func (h *httpClient) Do(request *http.Request) (*http.Response, error) {
 	request.Header.Add(userAgentHeaderKey, userAgentHeaderValue)
 	h.auth.apply(request)
@@ -89,38 +99,48 @@ func (h *httpClient) Delete(path string) error {
 	return h.do(http.MethodDelete, path, nil, nil)
 }
 
+func (h *httpClient) Location() (*time.Location, error) {
+	return h.location.resolve(h)
+}
+
 func (h *httpClient) do(method, path string, result, body interface{}) error {
 	request, err := h.newRequest(method, path, body)
 	if err != nil {
 		return err
 	}
+	start := time.Now()
+	statusCode, responseBody, err := h.send(request, result)
+	h.logger.log(request, statusCode, time.Since(start), responseBody, err)
+	return err
+}
+
+// send sends the request and decodes the response into the result.
+//
+// The response body will only be returned if it needs to be logged.
+func (h *httpClient) send(request *http.Request, result interface{}) (int, []byte, error) {
 	response, err := h.Do(request)
 	if err != nil {
-		return newWrappedClientError("Failed to send the request", err, ErrCodeDataProcessing)
+		return 0, nil, withRequest(newWrappedClientError("Failed to send the request", err, ErrCodeDataProcessing), request)
 	}
 
 	defer func() {
 		_ = response.Body.Close()
 	}()
 
+	responseBody := h.logger.captureBody(request.Context(), response)
+
 	if response.StatusCode < 200 || response.StatusCode >= 300 {
-		var csError Error
-		err = json.NewDecoder(response.Body).Decode(&csError)
-		if err != nil {
-			return newWrappedClientError("Failed to decode the server error response", err, ErrCodeInvalidJSON)
-		}
-		csError.err = errors.New(csError.Message)
-		return &csError
+		return response.StatusCode, responseBody, newResponseError(request, response)
 	}
 	if result != nil {
 		err = json.NewDecoder(response.Body).Decode(result)
 		// io.EOF means that server returned an empty response
 		// Successful HTTP status code with an empty response is Ok!
 		if err != nil && !errors.Is(err, io.EOF) {
-			return newWrappedClientError("Failed to decode the server response", err, ErrCodeInvalidJSON)
+			return response.StatusCode, responseBody, withRequest(newWrappedClientError("Failed to decode the server response", err, ErrCodeInvalidJSON), request)
 		}
 	}
-	return nil
+	return response.StatusCode, responseBody, nil
 }
 
 func (h *httpClient) getFullURL(path string) (string, error) {
@@ -3,9 +3,12 @@ package createsend
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -510,13 +513,13 @@ func TestGet(t *testing.T) {
 			expectedResult: &result{Data: "d"},
 		},
 		{
-			title: "should fail to decode a server side error with invalid createsend error json content",
+			title: "a server side error with invalid createsend error json content must be reported as an unexpected response",
 			path:  "/path",
 			response: &http.Response{
 				StatusCode: 500,
 				Body:       ioutil.NopCloser(bytes.NewBufferString(`{I'm invalid Json'`)),
 			},
-			expectedError:  newClientError(ErrCodeInvalidJSON),
+			expectedError:  newClientError(ErrCodeUnexpectedResponse),
 			expectedResult: &result{},
 		},
 		{
@@ -706,13 +709,13 @@ func TestPost(t *testing.T) {
 			expectRemoteServerCallCount: 1,
 		},
 		{
-			title: "should fail to decode a server side error with invalid createsend error json content",
+			title: "a server side error with invalid createsend error json content must be reported as an unexpected response",
 			path:  "/path",
 			response: &http.Response{
 				StatusCode: 500,
 				Body:       ioutil.NopCloser(bytes.NewBufferString(`{I'm invalid Json'`)),
 			},
-			expectedError:               newClientError(ErrCodeInvalidJSON),
+			expectedError:               newClientError(ErrCodeUnexpectedResponse),
 			expectedResult:              &result{},
 			expectRemoteServerCallCount: 1,
 		},
@@ -932,13 +935,13 @@ func TestPut(t *testing.T) {
 			expectRemoteServerCallCount: 1,
 		},
 		{
-			title: "should fail to decode a server side error with invalid createsend error json content",
+			title: "a server side error with invalid createsend error json content must be reported as an unexpected response",
 			path:  "/path",
 			response: &http.Response{
 				StatusCode: 500,
 				Body:       ioutil.NopCloser(bytes.NewBufferString(`{I'm invalid Json'`)),
 			},
-			expectedError:               newClientError(ErrCodeInvalidJSON),
+			expectedError:               newClientError(ErrCodeUnexpectedResponse),
 			expectedResult:              &result{},
 			expectRemoteServerCallCount: 1,
 		},
@@ -1117,13 +1120,13 @@ func TestDelete(t *testing.T) {
 			expectedError: nil,
 		},
 		{
-			title: "should fail to decode a server side error with invalid createsend error json content",
+			title: "a server side error with invalid createsend error json content must be reported as an unexpected response",
 			path:  "/path",
 			response: &http.Response{
 				StatusCode: 500,
 				Body:       ioutil.NopCloser(bytes.NewBufferString(`{I'm invalid Json'`)),
 			},
-			expectedError: newClientError(ErrCodeInvalidJSON),
+			expectedError: newClientError(ErrCodeUnexpectedResponse),
 		},
 	}
 
@@ -1188,3 +1191,172 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestServerErrorDetails(t *testing.T) {
+	const base = "https://base"
+	longBody := strings.Repeat("x", maxErrorBodySnippetSize+100)
+	testCases := []struct {
+		title              string
+		response           *http.Response
+		expectedCode       int
+		expectedMessage    string
+		expectedRawBody    string
+		expectedHeader     http.Header
+		expectedRetryAfter time.Duration
+		expectRetryAfter   bool
+	}{
+		{
+			title: "campaign monitor error document",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Header: http.Header{
+					"Content-Type":          []string{"application/json"},
+					"Set-Cookie":            []string{"secret"},
+					"X-Ratelimit-Limit":     []string{"1000"},
+					"X-Ratelimit-Remaining": []string{"999"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"Code":1,"Message":"Invalid Email Address"}`)),
+			},
+			expectedCode:    1,
+			expectedMessage: "Invalid Email Address",
+			expectedRawBody: `{"Code":1,"Message":"Invalid Email Address"}`,
+			expectedHeader: http.Header{
+				"Content-Type":          []string{"application/json"},
+				"X-Ratelimit-Limit":     []string{"1000"},
+				"X-Ratelimit-Remaining": []string{"999"},
+			},
+		},
+		{
+			title: "html response from a proxy",
+			response: &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Header: http.Header{
+					"Content-Type": []string{"text/html"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(`<html>Bad Gateway</html>`)),
+			},
+			expectedCode:    int(ErrCodeUnexpectedResponse),
+			expectedMessage: "unexpected server response: 502 Bad Gateway",
+			expectedRawBody: `<html>Bad Gateway</html>`,
+			expectedHeader: http.Header{
+				"Content-Type": []string{"text/html"},
+			},
+		},
+		{
+			title: "empty unauthorised response",
+			response: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(&bytes.Buffer{}),
+			},
+			expectedCode:    int(ErrCodeUnexpectedResponse),
+			expectedMessage: "unexpected server response: 401 Unauthorized",
+			expectedHeader:  http.Header{},
+		},
+		{
+			title: "json document which is not a campaign monitor error",
+			response: &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"error":"maintenance"}`)),
+			},
+			expectedCode:    int(ErrCodeUnexpectedResponse),
+			expectedMessage: "unexpected server response: 503 Service Unavailable",
+			expectedRawBody: `{"error":"maintenance"}`,
+			expectedHeader:  http.Header{},
+		},
+		{
+			title: "large response body must be truncated",
+			response: &http.Response{
+				StatusCode: http.StatusInternalServerError,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(longBody)),
+			},
+			expectedCode:    int(ErrCodeUnexpectedResponse),
+			expectedMessage: "unexpected server response: 500 Internal Server Error",
+			expectedRawBody: longBody[:maxErrorBodySnippetSize],
+			expectedHeader:  http.Header{},
+		},
+		{
+			title: "rate limited response",
+			response: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"Retry-After": []string{"30"},
+				},
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{"Code":429,"Message":"Rate limit exceeded"}`)),
+			},
+			expectedCode:    429,
+			expectedMessage: "Rate limit exceeded",
+			expectedRawBody: `{"Code":429,"Message":"Rate limit exceeded"}`,
+			expectedHeader: http.Header{
+				"Retry-After": []string{"30"},
+			},
+			expectedRetryAfter: 30 * time.Second,
+			expectRetryAfter:   true,
+		},
+		{
+			title: "rate limit reset header",
+			response: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header: http.Header{
+					"X-Ratelimit-Reset": []string{"12"},
+				},
+				Body: ioutil.NopCloser(&bytes.Buffer{}),
+			},
+			expectedCode:    int(ErrCodeUnexpectedResponse),
+			expectedMessage: "unexpected server response: 429 Too Many Requests",
+			expectedHeader: http.Header{
+				"X-Ratelimit-Reset": []string{"12"},
+			},
+			expectedRetryAfter: 12 * time.Second,
+			expectRetryAfter:   true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			auth := &authentication{
+				token:  "api_key",
+				method: apiKeyAuthentication,
+			}
+			client, err := newHTTPClient(context.Background(), base, httpClient, auth)
+			if err != nil {
+				t.Fatalf("Client Creation: Did not expect to receive an error, but received: '%s'", err)
+			}
+			httpClient.SetResponse("/path", tC.response)
+
+			err = client.Delete("path?email=secret")
+			var csErr *Error
+			if !errors.As(err, &csErr) {
+				t.Fatalf("Expected custom createsend error type, Actual: %v", err)
+			}
+			if csErr.Code != tC.expectedCode {
+				t.Errorf("Expected Code: %d, Actual: %d", tC.expectedCode, csErr.Code)
+			}
+			if csErr.Message != tC.expectedMessage {
+				t.Errorf("Expected Message: %q, Actual: %q", tC.expectedMessage, csErr.Message)
+			}
+			if csErr.StatusCode != tC.response.StatusCode {
+				t.Errorf("Expected Status Code: %d, Actual: %d", tC.response.StatusCode, csErr.StatusCode)
+			}
+			if csErr.Method != http.MethodDelete {
+				t.Errorf("Expected Method: %s, Actual: %s", http.MethodDelete, csErr.Method)
+			}
+			if csErr.Path != "/path" {
+				t.Errorf("Expected Path: /path, Actual: %s", csErr.Path)
+			}
+			if csErr.RawBody != tC.expectedRawBody {
+				t.Errorf("Expected Raw Body: %q, Actual: %q", tC.expectedRawBody, csErr.RawBody)
+			}
+			if diff := cmp.Diff(tC.expectedHeader, csErr.Header); diff != "" {
+				t.Errorf("Header expectations failed (-expected +actual):\n%s", diff)
+			}
+			retryAfter, ok := csErr.RetryAfter()
+			if ok != tC.expectRetryAfter {
+				t.Errorf("Expected Retry After flag: %v, Actual: %v", tC.expectRetryAfter, ok)
+			}
+			if retryAfter != tC.expectedRetryAfter {
+				t.Errorf("Expected Retry After: %v, Actual: %v", tC.expectedRetryAfter, retryAfter)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package createsend
+
+import (
+	"time"
+)
+
+// CredentialType represents the type of credentials used to authenticate the requests.
+type CredentialType int8
+
+const (
+	// UnknownCredentials unknown credentials.
+	UnknownCredentials CredentialType = iota
+	// AccountAPIKey account level API key.
+	AccountAPIKey
+	// ClientAPIKey client specific API key.
+	ClientAPIKey
+	// OAuthToken OAuth token.
+	OAuthToken
+)
+
+// String returns the string representation of the credential type.
+func (c CredentialType) String() string {
+	switch c {
+	case AccountAPIKey:
+		return "account API key"
+	case ClientAPIKey:
+		return "client API key"
+	case OAuthToken:
+		return "OAuth token"
+	default:
+		return "unknown"
+	}
+}
+
+// Identity represents the details of the credentials the client is authenticated with.
+type Identity struct {
+	// CredentialType the type of the credentials.
+	CredentialType CredentialType
+	// ClientID the ID of the client the credentials are scoped to.
+	//
+	// The value is only known for the clients created by ForClient or WithClientAPIKey.
+	ClientID string
+	// Latency the round-trip latency of the verification request.
+	Latency time.Duration
+	// AccountTime the current date and time in the account's timezone.
+	AccountTime time.Time
+	// TimeOffset the difference between the account's wall clock and the local wall clock, rounded to the nearest second.
+	TimeOffset time.Duration
+}
+
+// Verify makes sure that the credentials are accepted by the server by making a cheap API call.
+func (c *Client) Verify() error {
+	_, err := c.accounts.Now()
+	return err
+}
+
+// WhoAmI verifies the credentials and reports their type and scope, the round-trip latency,
+// and the time offset of the account's timezone versus the local time.
+//
+// API keys are classified by probing the account level clients list, which is not accessible using client API keys.
+// The client ID set by WithClientAPIKey is confirmed using the client's details, which are only accessible
+// if the key is scoped to the client.
+func (c *Client) WhoAmI() (*Identity, error) {
+	start := time.Now()
+	accountTime, err := c.accounts.Now()
+	if err != nil {
+		return nil, err
+	}
+	latency := time.Since(start)
+
+	// The offset is the difference between the wall clock times, regardless of the location of the account time.
+	local := start.Add(latency / 2)
+	identity := &Identity{
+		ClientID:    c.clientID,
+		Latency:     latency,
+		AccountTime: accountTime,
+		TimeOffset:  wallClock(accountTime).Sub(wallClock(local)).Round(time.Second),
+	}
+
+	switch {
+	case c.options.auth.method == oAuthAuthentication:
+		identity.CredentialType = OAuthToken
+	case c.clientID != "":
+		identity.CredentialType = ClientAPIKey
+	default:
+		_, err := c.accounts.Clients()
+		switch {
+		case err == nil:
+			identity.CredentialType = AccountAPIKey
+		case IsAuthError(err):
+			identity.CredentialType = ClientAPIKey
+			if c.options.clientID != "" {
+				details, err := c.clients.Get(c.options.clientID)
+				if err != nil {
+					return nil, err
+				}
+				identity.ClientID = details.ID
+			}
+		default:
+			return nil, err
+		}
+	}
+
+	return identity, nil
+}
+
+// wallClock returns the wall clock time of t expressed in UTC.
+func wallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
@@ -0,0 +1,252 @@
+package createsend_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/mock"
+)
+
+func TestCredentialType_String(t *testing.T) {
+	testCases := []struct {
+		credentials createsend.CredentialType
+		expected    string
+	}{
+		{createsend.UnknownCredentials, "unknown"},
+		{createsend.AccountAPIKey, "account API key"},
+		{createsend.ClientAPIKey, "client API key"},
+		{createsend.OAuthToken, "OAuth token"},
+		{createsend.CredentialType(100), "unknown"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.expected, func(t *testing.T) {
+			if actual := tC.credentials.String(); actual != tC.expected {
+				t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestClient_WhoAmI(t *testing.T) {
+	const offset = 10 * time.Hour
+	systemDate := func() *http.Response {
+		now := time.Now().Add(offset).Format("2006-01-02 15:04:05")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"SystemDate":%q}`, now))),
+		}
+	}
+
+	testCases := []struct {
+		title               string
+		oAuth               bool
+		session             bool
+		clientID            string
+		detailsResponse     *http.Response
+		dateResponse        *http.Response
+		clientsResponse     *http.Response
+		expectedCredentials createsend.CredentialType
+		expectedClientID    string
+		expectedError       error
+	}{
+		{
+			title: "account API key",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			expectedCredentials: createsend.AccountAPIKey,
+		},
+		{
+			title: "client API key",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+			},
+			expectedCredentials: createsend.ClientAPIKey,
+		},
+		{
+			title:    "client API key with client ID",
+			clientID: "client_id",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+			},
+			expectedCredentials: createsend.ClientAPIKey,
+			expectedClientID:    "client_id",
+		},
+		{
+			title:    "client API key of another client",
+			clientID: "client_id",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+			},
+			detailsResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+			},
+			expectedError: createsend.ErrMustBeLoggedIn,
+		},
+		{
+			title:    "account API key with client ID",
+			clientID: "client_id",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			expectedCredentials: createsend.AccountAPIKey,
+		},
+		{
+			title:               "client session",
+			session:             true,
+			expectedCredentials: createsend.ClientAPIKey,
+			expectedClientID:    "client_id",
+		},
+		{
+			title:               "OAuth token",
+			oAuth:               true,
+			expectedCredentials: createsend.OAuthToken,
+		},
+		{
+			title: "invalid credentials",
+			dateResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":100,"Message":"Invalid API Key"}`)),
+			},
+			expectedError: createsend.ErrInvalidAPIKey,
+		},
+		{
+			title: "clients probe failure",
+			clientsResponse: &http.Response{
+				StatusCode: http.StatusServiceUnavailable,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`Service Unavailable`)),
+			},
+			expectedError: &createsend.Error{Code: int(createsend.ErrCodeUnexpectedResponse)},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			if tC.dateResponse != nil {
+				httpClient.SetResponse("systemdate.json", tC.dateResponse)
+			} else {
+				httpClient.SetResponse("systemdate.json", systemDate())
+			}
+			if tC.clientsResponse != nil {
+				httpClient.SetResponse("clients.json", tC.clientsResponse)
+			}
+			if tC.detailsResponse != nil {
+				httpClient.SetResponse("clients/client_id.json", tC.detailsResponse)
+			} else {
+				httpClient.SetResponse("clients/client_id.json", &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`{"ApiKey":"client_key","BasicDetails":{"ClientID":"client_id"}}`)),
+				})
+			}
+
+			options := []createsend.Option{
+				createsend.WithBaseURL("https://base.com"),
+				createsend.WithHTTPClient(httpClient),
+			}
+			switch {
+			case tC.oAuth:
+				options = append(options, createsend.WithOAuthToken("token"))
+			case tC.clientID != "":
+				options = append(options, createsend.WithClientAPIKey("client_key", tC.clientID))
+			default:
+				options = append(options, createsend.WithAPIKey("api_key"))
+			}
+			client, err := createsend.New(options...)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if tC.session {
+				client, err = client.ForClient("client_id")
+				if err != nil {
+					t.Fatalf("Did not expect an error but received: '%v'", err)
+				}
+			}
+
+			identity, err := client.WhoAmI()
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+			}
+			if tC.expectedError != nil {
+				if identity != nil {
+					t.Error("The identity must be nil on error")
+				}
+				return
+			}
+
+			if identity.CredentialType != tC.expectedCredentials {
+				t.Errorf("Expected credentials: %v, Actual: %v", tC.expectedCredentials, identity.CredentialType)
+			}
+			if identity.ClientID != tC.expectedClientID {
+				t.Errorf("Expected client ID: %q, Actual: %q", tC.expectedClientID, identity.ClientID)
+			}
+			if identity.Latency <= 0 {
+				t.Errorf("Expected a positive latency, Actual: %v", identity.Latency)
+			}
+			if identity.AccountTime.IsZero() {
+				t.Error("The account time must have been set")
+			}
+			if diff := identity.TimeOffset - offset; diff < -2*time.Second || diff > 2*time.Second {
+				t.Errorf("Expected time offset: %v, Actual: %v", offset, identity.TimeOffset)
+			}
+			if count := httpClient.Count("/clients.json"); tC.clientsResponse == nil && count > 0 {
+				t.Errorf("The clients list must not be probed. Actual calls: %d", count)
+			}
+		})
+	}
+}
+
+func TestClient_Verify(t *testing.T) {
+	testCases := []struct {
+		title         string
+		response      *http.Response
+		expectedError error
+	}{
+		{
+			title: "valid credentials",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"SystemDate":"2020-06-01 10:00:00"}`)),
+			},
+		},
+		{
+			title: "expired OAuth token",
+			response: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":121,"Message":"Expired OAuth Token"}`)),
+			},
+			expectedError: createsend.ErrExpiredOAuthToken,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			httpClient.SetResponse("systemdate.json", tC.response)
+			client, err := createsend.New(
+				createsend.WithBaseURL("https://base.com"),
+				createsend.WithHTTPClient(httpClient),
+				createsend.WithOAuthToken("token"),
+			)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			err = client.Verify()
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+			}
+		})
+	}
+}
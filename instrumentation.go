@@ -0,0 +1,135 @@
+package createsend
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/xitonix/createsend/internal"
+)
+
+const (
+	instrumentationName = "github.com/xitonix/createsend"
+
+	requestCountMetric    = "createsend.client.requests"
+	requestDurationMetric = "createsend.client.duration"
+	requestErrorsMetric   = "createsend.client.errors"
+
+	operationAttributeKey  = attribute.Key("createsend.operation")
+	errorCodeAttributeKey  = attribute.Key("createsend.error.code")
+	httpMethodAttributeKey = attribute.Key("http.request.method")
+	httpStatusAttributeKey = attribute.Key("http.response.status_code")
+	urlPathAttributeKey    = attribute.Key("url.path")
+)
+
+type instrumentation struct {
+	tracer   trace.Tracer
+	requests metric.Int64Counter
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+func newInstrumentation(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) (*instrumentation, error) {
+	if tracerProvider == nil {
+		tracerProvider = tracenoop.NewTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = metricnoop.NewMeterProvider()
+	}
+
+	meter := meterProvider.Meter(instrumentationName)
+	requests, err := meter.Int64Counter(requestCountMetric,
+		metric.WithDescription("The number of Campaign Monitor API operations."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, newWrappedClientError("Failed to create the request count metric", err, ErrCodeDataProcessing)
+	}
+
+	duration, err := meter.Float64Histogram(requestDurationMetric,
+		metric.WithDescription("The duration of Campaign Monitor API operations."),
+		metric.WithUnit("s"))
+	if err != nil {
+		return nil, newWrappedClientError("Failed to create the request duration metric", err, ErrCodeDataProcessing)
+	}
+
+	failures, err := meter.Int64Counter(requestErrorsMetric,
+		metric.WithDescription("The number of failed Campaign Monitor API operations."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, newWrappedClientError("Failed to create the request errors metric", err, ErrCodeDataProcessing)
+	}
+
+	return &instrumentation{
+		tracer:   tracerProvider.Tracer(instrumentationName),
+		requests: requests,
+		duration: duration,
+		errors:   failures,
+	}, nil
+}
+
+// observe executes the call within a new span named after the operation and records the operation metrics.
+//
+// The internal client passed to the call sends its requests within the context of the operation span.
+func (i *instrumentation) observe(client *httpClient, operation string, call func(internal.Client) error) error {
+	operationAttr := operationAttributeKey.String(operation)
+	ctx, span := i.tracer.Start(client.ctx, operation, trace.WithAttributes(operationAttr))
+	defer span.End()
+
+	start := time.Now()
+	err := call(client.withContext(ctx))
+	elapsed := time.Since(start)
+
+	i.requests.Add(ctx, 1, metric.WithAttributes(operationAttr))
+	i.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(operationAttr))
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		errorAttrs := []attribute.KeyValue{operationAttr}
+		var csErr *Error
+		if errors.As(err, &csErr) {
+			errorCode := errorCodeAttributeKey.Int(csErr.Code)
+			span.SetAttributes(errorCode)
+			errorAttrs = append(errorAttrs, errorCode)
+			if csErr.StatusCode != 0 {
+				span.SetAttributes(httpStatusAttributeKey.Int(csErr.StatusCode))
+			}
+		}
+		i.errors.Add(ctx, 1, metric.WithAttributes(errorAttrs...))
+	}
+
+	return err
+}
+
+// middleware creates a child span for each HTTP call.
+func (i *instrumentation) middleware(next HTTPClient) HTTPClient {
+	return HTTPClientFunc(func(request *http.Request) (*http.Response, error) {
+		ctx, span := i.tracer.Start(request.Context(), "HTTP "+request.Method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				httpMethodAttributeKey.String(request.Method),
+				urlPathAttributeKey.String(request.URL.Path),
+			))
+		defer span.End()
+
+		response, err := next.Do(request.WithContext(ctx))
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			return response, err
+		}
+
+		span.SetAttributes(httpStatusAttributeKey.Int(response.StatusCode))
+		if response.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, http.StatusText(response.StatusCode))
+		}
+		return response, nil
+	})
+}
@@ -0,0 +1,259 @@
+package createsend
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/mock"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/transactional"
+)
+
+func createInstrumentedClient(t *testing.T) (*Client, *mock.HTTPClientMock, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	httpClient := mock.NewHTTPClientMock()
+	client, err := New(
+		WithBaseURL("https://base.com"),
+		WithHTTPClient(httpClient),
+		WithAPIKey("api_key"),
+		WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))),
+		WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return client, httpClient, recorder, reader
+}
+
+func findAttribute(attributes []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, attr := range attributes {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+func TestInstrumentation_Spans(t *testing.T) {
+	testCases := []struct {
+		title              string
+		response           *http.Response
+		expectedStatus     codes.Code
+		expectedHTTPStatus int64
+		expectedErrorCode  int64
+	}{
+		{
+			title: "successful operation",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"BasicDetails":{"ClientID":"id"}}`)),
+			},
+			expectedStatus:     codes.Unset,
+			expectedHTTPStatus: http.StatusOK,
+		},
+		{
+			title: "server side error",
+			response: &http.Response{
+				StatusCode: http.StatusBadRequest,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":203,"Message":"Invalid ClientID"}`)),
+			},
+			expectedStatus:     codes.Error,
+			expectedHTTPStatus: http.StatusBadRequest,
+			expectedErrorCode:  203,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient, recorder, _ := createInstrumentedClient(t)
+			httpClient.SetResponse("clients/id.json", tC.response)
+
+			_, _ = client.Clients().Get("id")
+
+			spans := recorder.Ended()
+			if len(spans) != 2 {
+				t.Fatalf("Expected number of spans: 2, Actual: %d", len(spans))
+			}
+			httpSpan, operationSpan := spans[0], spans[1]
+
+			if operationSpan.Name() != "clients.Get" {
+				t.Errorf("Expected operation span name: clients.Get, Actual: %s", operationSpan.Name())
+			}
+			if httpSpan.Name() != "HTTP GET" {
+				t.Errorf("Expected HTTP span name: HTTP GET, Actual: %s", httpSpan.Name())
+			}
+			if httpSpan.Parent().SpanID() != operationSpan.SpanContext().SpanID() {
+				t.Error("The HTTP span must be a child of the operation span")
+			}
+			if operationSpan.Status().Code != tC.expectedStatus {
+				t.Errorf("Expected operation span status: %v, Actual: %v", tC.expectedStatus, operationSpan.Status().Code)
+			}
+
+			status, ok := findAttribute(httpSpan.Attributes(), httpStatusAttributeKey)
+			if !ok || status.AsInt64() != tC.expectedHTTPStatus {
+				t.Errorf("Expected HTTP status attribute: %d, Actual: %v", tC.expectedHTTPStatus, status.Emit())
+			}
+
+			errorCode, ok := findAttribute(operationSpan.Attributes(), errorCodeAttributeKey)
+			if tC.expectedErrorCode == 0 {
+				if ok {
+					t.Errorf("Did not expect an error code attribute, Actual: %v", errorCode.Emit())
+				}
+				return
+			}
+			if !ok || errorCode.AsInt64() != tC.expectedErrorCode {
+				t.Errorf("Expected error code attribute: %d, Actual: %v", tC.expectedErrorCode, errorCode.Emit())
+			}
+		})
+	}
+}
+
+func TestInstrumentation_Metrics(t *testing.T) {
+	client, httpClient, _, reader := createInstrumentedClient(t)
+	httpClient.SetResponse(fetchValidCountriesPath, &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`["Australia"]`)),
+	})
+	httpClient.SetResponse("transactional/smartEmail", &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":500,"Message":"msg"}`)),
+	})
+
+	_, _ = client.Accounts().Countries()
+	_, _ = client.Transactional().SmartEmails()
+
+	var data metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &data); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	counts := make(map[string]map[string]int64)
+	for _, scope := range data.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			switch agg := m.Data.(type) {
+			case metricdata.Sum[int64]:
+				for _, point := range agg.DataPoints {
+					operation, _ := point.Attributes.Value(operationAttributeKey)
+					if counts[m.Name] == nil {
+						counts[m.Name] = make(map[string]int64)
+					}
+					counts[m.Name][operation.AsString()] += point.Value
+				}
+			case metricdata.Histogram[float64]:
+				for _, point := range agg.DataPoints {
+					operation, _ := point.Attributes.Value(operationAttributeKey)
+					if counts[m.Name] == nil {
+						counts[m.Name] = make(map[string]int64)
+					}
+					counts[m.Name][operation.AsString()] += int64(point.Count)
+				}
+			}
+		}
+	}
+
+	expected := map[string]map[string]int64{
+		requestCountMetric: {
+			"accounts.Countries":        1,
+			"transactional.SmartEmails": 1,
+		},
+		requestDurationMetric: {
+			"accounts.Countries":        1,
+			"transactional.SmartEmails": 1,
+		},
+		requestErrorsMetric: {
+			"transactional.SmartEmails": 1,
+		},
+	}
+
+	for name, operations := range expected {
+		for operation, value := range operations {
+			if actual := counts[name][operation]; actual != value {
+				t.Errorf("Expected %s value for %s: %d, Actual: %d", name, operation, value, actual)
+			}
+		}
+		if len(counts[name]) != len(operations) {
+			t.Errorf("Expected number of operations recorded by %s: %d, Actual: %d", name, len(operations), len(counts[name]))
+		}
+	}
+}
+
+func TestInstrumentation_OperationNames(t *testing.T) {
+	client, _, recorder, _ := createInstrumentedClient(t)
+	a, c, tr := client.Accounts(), client.Clients(), client.Transactional()
+
+	calls := map[string]func(){
+		"accounts.Clients":             func() { _, _ = a.Clients() },
+		"accounts.Billing":             func() { _, _ = a.Billing() },
+		"accounts.Countries":           func() { _, _ = a.Countries() },
+		"accounts.Timezones":           func() { _, _ = a.Timezones() },
+		"accounts.Now":                 func() { _, _ = a.Now() },
+		"accounts.AddAdministrator":    func() { _ = a.AddAdministrator(accounts.Administrator{}) },
+		"accounts.UpdateAdministrator": func() { _ = a.UpdateAdministrator("e", accounts.Administrator{}) },
+		"accounts.Administrators":      func() { _, _ = a.Administrators() },
+		"accounts.Administrator":       func() { _, _ = a.Administrator("e") },
+		"accounts.DeleteAdministrator": func() { _ = a.DeleteAdministrator("e") },
+		"accounts.SetAsPrimaryContact": func() { _ = a.SetAsPrimaryContact("e") },
+		"accounts.PrimaryContact":      func() { _, _ = a.PrimaryContact() },
+		"accounts.NewEmbeddedSession":  func() { _, _ = a.NewEmbeddedSession(accounts.EmbeddedSession{}) },
+		"clients.Create":               func() { _, _ = c.Create(clients.BasicDetails{}) },
+		"clients.Get":                  func() { _, _ = c.Get("id") },
+		"clients.SentCampaigns":        func() { _, _ = c.SentCampaigns("id") },
+		"clients.ScheduledCampaigns":   func() { _, _ = c.ScheduledCampaigns("id") },
+		"clients.DraftCampaigns":       func() { _, _ = c.DraftCampaigns("id") },
+		"clients.Lists":                func() { _, _ = c.Lists("id") },
+		"clients.ListsByEmailAddress":  func() { _, _ = c.ListsByEmailAddress("id", "e") },
+		"clients.Segments":             func() { _, _ = c.Segments("id") },
+		"clients.SuppressionList":      func() { _, _ = c.SuppressionList("id", 10, 1, order.BySuppressionDate, order.ASC) },
+		"clients.Suppress":             func() { _ = c.Suppress("id", "e") },
+		"clients.UnSuppress":           func() { _ = c.UnSuppress("id", "e") },
+		"clients.Templates":            func() { _, _ = c.Templates("id") },
+		"clients.Update":               func() { _ = c.Update("id", clients.BasicDetails{}) },
+		"clients.SetPAYGBilling":       func() { _ = c.SetPAYGBilling("id", clients.PAYGRates{}) },
+		"clients.SetMonthlyBilling":    func() { _ = c.SetMonthlyBilling("id", clients.MonthlyRates{}) },
+		"clients.TransferCredits":      func() { _, _ = c.TransferCredits("id", clients.CreditTransferRequest{}) },
+		"clients.Delete":               func() { _ = c.Delete("id") },
+		"clients.AddPerson":            func() { _, _ = c.AddPerson("id", clients.Person{}) },
+		"clients.UpdatePerson":         func() { _, _ = c.UpdatePerson("id", "e", clients.Person{}) },
+		"clients.People":               func() { _, _ = c.People("id") },
+		"clients.Person":               func() { _, _ = c.Person("id", "e") },
+		"clients.DeletePerson":         func() { _ = c.DeletePerson("id", "e") },
+		"clients.SetPrimaryContact":    func() { _, _ = c.SetPrimaryContact("id", "e") },
+		"clients.PrimaryContact":       func() { _, _ = c.PrimaryContact("id") },
+		"transactional.SmartEmails":    func() { _, _ = tr.SmartEmails() },
+		"transactional.SmartEmail":     func() { _, _ = tr.SmartEmail("id") },
+
+		"transactional.MessageTimeline": func() { _, _ = tr.MessageTimeline() },
+		"transactional.SendSmartEmail":  func() { _, _ = tr.SendSmartEmail("id", transactional.SmartEmailMessage{}) },
+		"subscribers.Get":               func() { _, _ = client.Subscribers().Get("id", "e") },
+		"subscribers.Delete":            func() { _ = client.Subscribers().Delete("id", "e") },
+	}
+
+	for operation, call := range calls {
+		t.Run(operation, func(t *testing.T) {
+			before := len(recorder.Ended())
+			call()
+			spans := recorder.Ended()[before:]
+			if len(spans) == 0 {
+				t.Fatal("Expected at least one span")
+			}
+			actual := spans[len(spans)-1].Name()
+			if actual != operation {
+				t.Errorf("Expected operation span: %s, Actual: %s", operation, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package createsend
+
+import (
+	"time"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/internal"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+type instrumentedAccountsAPI struct {
+	client *httpClient
+	inst   *instrumentation
+}
+
+func (a *instrumentedAccountsAPI) Clients() (result []*accounts.Client, err error) {
+	err = a.inst.observe(a.client, "accounts.Clients", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Clients()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) Billing() (result *accounts.Billing, err error) {
+	err = a.inst.observe(a.client, "accounts.Billing", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Billing()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) Countries() (result []string, err error) {
+	err = a.inst.observe(a.client, "accounts.Countries", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Countries()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) Timezones() (result []string, err error) {
+	err = a.inst.observe(a.client, "accounts.Timezones", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Timezones()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) Now() (result time.Time, err error) {
+	err = a.inst.observe(a.client, "accounts.Now", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Now()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) AddAdministrator(administrator accounts.Administrator) error {
+	return a.inst.observe(a.client, "accounts.AddAdministrator", func(c internal.Client) error {
+		return newAccountAPI(c).AddAdministrator(administrator)
+	})
+}
+
+func (a *instrumentedAccountsAPI) UpdateAdministrator(currentEmailAddress string, administrator accounts.Administrator) error {
+	return a.inst.observe(a.client, "accounts.UpdateAdministrator", func(c internal.Client) error {
+		return newAccountAPI(c).UpdateAdministrator(currentEmailAddress, administrator)
+	})
+}
+
+func (a *instrumentedAccountsAPI) Administrators() (result []*accounts.AdministratorDetails, err error) {
+	err = a.inst.observe(a.client, "accounts.Administrators", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Administrators()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) Administrator(emailAddress string) (result *accounts.AdministratorDetails, err error) {
+	err = a.inst.observe(a.client, "accounts.Administrator", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).Administrator(emailAddress)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) DeleteAdministrator(emailAddress string) error {
+	return a.inst.observe(a.client, "accounts.DeleteAdministrator", func(c internal.Client) error {
+		return newAccountAPI(c).DeleteAdministrator(emailAddress)
+	})
+}
+
+func (a *instrumentedAccountsAPI) SetAsPrimaryContact(emailAddress string) error {
+	return a.inst.observe(a.client, "accounts.SetAsPrimaryContact", func(c internal.Client) error {
+		return newAccountAPI(c).SetAsPrimaryContact(emailAddress)
+	})
+}
+
+func (a *instrumentedAccountsAPI) PrimaryContact() (result string, err error) {
+	err = a.inst.observe(a.client, "accounts.PrimaryContact", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).PrimaryContact()
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedAccountsAPI) NewEmbeddedSession(session accounts.EmbeddedSession) (result string, err error) {
+	err = a.inst.observe(a.client, "accounts.NewEmbeddedSession", func(c internal.Client) (err error) {
+		result, err = newAccountAPI(c).NewEmbeddedSession(session)
+		return err
+	})
+	return result, err
+}
+
+type instrumentedClientsAPI struct {
+	client *httpClient
+	inst   *instrumentation
+}
+
+func (a *instrumentedClientsAPI) Create(details clients.BasicDetails) (result string, err error) {
+	err = a.inst.observe(a.client, "clients.Create", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Create(details)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Get(clientID string) (result *clients.ClientDetails, err error) {
+	err = a.inst.observe(a.client, "clients.Get", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Get(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) SentCampaigns(clientID string) (result []*clients.SentCampaign, err error) {
+	err = a.inst.observe(a.client, "clients.SentCampaigns", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).SentCampaigns(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) ScheduledCampaigns(clientID string) (result []*clients.ScheduledCampaign, err error) {
+	err = a.inst.observe(a.client, "clients.ScheduledCampaigns", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).ScheduledCampaigns(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) DraftCampaigns(clientID string) (result []*clients.DraftCampaign, err error) {
+	err = a.inst.observe(a.client, "clients.DraftCampaigns", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).DraftCampaigns(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Lists(clientID string) (result []*clients.List, err error) {
+	err = a.inst.observe(a.client, "clients.Lists", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Lists(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) ListsByEmailAddress(clientID, emailAddress string) (result []*clients.SubscriberList, err error) {
+	err = a.inst.observe(a.client, "clients.ListsByEmailAddress", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).ListsByEmailAddress(clientID, emailAddress)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Segments(clientID string) (result []*clients.Segment, err error) {
+	err = a.inst.observe(a.client, "clients.Segments", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Segments(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) SuppressionList(clientID string, pageSize, page int, orderBy order.SuppressionListField, direction order.Direction) (result *clients.SuppressionList, err error) {
+	err = a.inst.observe(a.client, "clients.SuppressionList", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).SuppressionList(clientID, pageSize, page, orderBy, direction)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Suppress(clientID string, emails ...string) error {
+	return a.inst.observe(a.client, "clients.Suppress", func(c internal.Client) error {
+		return newClientsAPI(c).Suppress(clientID, emails...)
+	})
+}
+
+func (a *instrumentedClientsAPI) UnSuppress(clientID string, email string) error {
+	return a.inst.observe(a.client, "clients.UnSuppress", func(c internal.Client) error {
+		return newClientsAPI(c).UnSuppress(clientID, email)
+	})
+}
+
+func (a *instrumentedClientsAPI) Templates(clientID string) (result []*clients.Template, err error) {
+	err = a.inst.observe(a.client, "clients.Templates", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Templates(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Update(clientID string, details clients.BasicDetails) error {
+	return a.inst.observe(a.client, "clients.Update", func(c internal.Client) error {
+		return newClientsAPI(c).Update(clientID, details)
+	})
+}
+
+func (a *instrumentedClientsAPI) SetPAYGBilling(clientID string, rates clients.PAYGRates) error {
+	return a.inst.observe(a.client, "clients.SetPAYGBilling", func(c internal.Client) error {
+		return newClientsAPI(c).SetPAYGBilling(clientID, rates)
+	})
+}
+
+func (a *instrumentedClientsAPI) SetMonthlyBilling(clientID string, rates clients.MonthlyRates) error {
+	return a.inst.observe(a.client, "clients.SetMonthlyBilling", func(c internal.Client) error {
+		return newClientsAPI(c).SetMonthlyBilling(clientID, rates)
+	})
+}
+
+func (a *instrumentedClientsAPI) TransferCredits(clientID string, request clients.CreditTransferRequest) (result *clients.CreditTransferResult, err error) {
+	err = a.inst.observe(a.client, "clients.TransferCredits", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).TransferCredits(clientID, request)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Delete(clientID string) error {
+	return a.inst.observe(a.client, "clients.Delete", func(c internal.Client) error {
+		return newClientsAPI(c).Delete(clientID)
+	})
+}
+
+func (a *instrumentedClientsAPI) AddPerson(clientID string, person clients.Person) (result string, err error) {
+	err = a.inst.observe(a.client, "clients.AddPerson", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).AddPerson(clientID, person)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) UpdatePerson(clientID string, emailAddress string, person clients.Person) (result string, err error) {
+	err = a.inst.observe(a.client, "clients.UpdatePerson", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).UpdatePerson(clientID, emailAddress, person)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) People(clientID string) (result []*clients.PersonDetails, err error) {
+	err = a.inst.observe(a.client, "clients.People", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).People(clientID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) Person(clientID string, emailAddress string) (result *clients.PersonDetails, err error) {
+	err = a.inst.observe(a.client, "clients.Person", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).Person(clientID, emailAddress)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) DeletePerson(clientID string, emailAddress string) error {
+	return a.inst.observe(a.client, "clients.DeletePerson", func(c internal.Client) error {
+		return newClientsAPI(c).DeletePerson(clientID, emailAddress)
+	})
+}
+
+func (a *instrumentedClientsAPI) SetPrimaryContact(clientID string, emailAddress string) (result string, err error) {
+	err = a.inst.observe(a.client, "clients.SetPrimaryContact", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).SetPrimaryContact(clientID, emailAddress)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedClientsAPI) PrimaryContact(clientID string) (result string, err error) {
+	err = a.inst.observe(a.client, "clients.PrimaryContact", func(c internal.Client) (err error) {
+		result, err = newClientsAPI(c).PrimaryContact(clientID)
+		return err
+	})
+	return result, err
+}
+
+type instrumentedTransactionalAPI struct {
+	client *httpClient
+	inst   *instrumentation
+}
+
+func (a *instrumentedTransactionalAPI) SmartEmails(options ...transactional.Option) (result []*transactional.SmartEmailBasicDetails, err error) {
+	err = a.inst.observe(a.client, "transactional.SmartEmails", func(c internal.Client) (err error) {
+		result, err = newTransactionalAPI(c).SmartEmails(options...)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedTransactionalAPI) SmartEmail(smartEmailID string) (result *transactional.SmartEmailDetails, err error) {
+	err = a.inst.observe(a.client, "transactional.SmartEmail", func(c internal.Client) (err error) {
+		result, err = newTransactionalAPI(c).SmartEmail(smartEmailID)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedTransactionalAPI) MessageTimeline(options ...transactional.Option) (result []*transactional.Message, err error) {
+	err = a.inst.observe(a.client, "transactional.MessageTimeline", func(c internal.Client) (err error) {
+		result, err = newTransactionalAPI(c).MessageTimeline(options...)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedTransactionalAPI) SendSmartEmail(smartEmailID string, message transactional.SmartEmailMessage) (result []*transactional.SendResult, err error) {
+	err = a.inst.observe(a.client, "transactional.SendSmartEmail", func(c internal.Client) (err error) {
+		result, err = newTransactionalAPI(c).SendSmartEmail(smartEmailID, message)
+		return err
+	})
+	return result, err
+}
+
+type instrumentedSubscribersAPI struct {
+	client *httpClient
+	inst   *instrumentation
+}
+
+func (a *instrumentedSubscribersAPI) Get(listID, emailAddress string) (result *subscribers.Details, err error) {
+	err = a.inst.observe(a.client, "subscribers.Get", func(c internal.Client) (err error) {
+		result, err = newSubscribersAPI(c).Get(listID, emailAddress)
+		return err
+	})
+	return result, err
+}
+
+func (a *instrumentedSubscribersAPI) Delete(listID, emailAddress string) error {
+	return a.inst.observe(a.client, "subscribers.Delete", func(c internal.Client) error {
+		return newSubscribersAPI(c).Delete(listID, emailAddress)
+	})
+}
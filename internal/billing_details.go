@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/money"
 )
 
 // BillingDetails represents a raw billing details type.
@@ -11,22 +12,22 @@ type BillingDetails struct {
 	// Monthly plans
 	CurrentTier        string
 	MonthlyScheme      string
-	CurrentMonthlyRate float64
+	CurrentMonthlyRate money.Decimal
 	MarkupPercentage   int64
 
 	// Pay as you go
 	CanPurchaseCredits     bool
 	Credits                int64
-	MarkupOnDesignSpamTest float64
-	BaseRatePerRecipient   float64
-	MarkupPerRecipient     float64
-	MarkupOnDelivery       float64
-	BaseDeliveryRate       float64
-	BaseDesignSpamTestRate float64
+	MarkupOnDesignSpamTest money.Decimal
+	BaseRatePerRecipient   money.Decimal
+	MarkupPerRecipient     money.Decimal
+	MarkupOnDelivery       money.Decimal
+	BaseDeliveryRate       money.Decimal
+	BaseDesignSpamTestRate money.Decimal
 
 	// Common
 	ClientPays bool
-	Currency   string
+	Currency   money.Currency
 }
 
 // ToClientBillingDetails converts the raw model to a new createsend model.
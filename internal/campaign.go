@@ -2,10 +2,10 @@ package internal
 
 import (
 	"net/mail"
-
-	"github.com/araddon/dateparse"
+	"time"
 
 	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/timezone"
 )
 
 // Campaign represents a raw Campaign.
@@ -28,11 +28,13 @@ type SentCampaign struct {
 }
 
 // ToSendCampaign converts the raw model to a new createsend model.
-func (c *SentCampaign) ToSendCampaign() (*clients.SentCampaign, error) {
+//
+// The sent date is interpreted in the given location.
+func (c *SentCampaign) ToSendCampaign(location *time.Location) (*clients.SentCampaign, error) {
 	if c == nil {
 		return nil, nil
 	}
-	date, err := dateparse.ParseAny(c.SentDate)
+	date, err := ParseDate(c.SentDate, location)
 	if err != nil {
 		return nil, err
 	}
@@ -66,16 +68,25 @@ type ScheduledCampaign struct {
 }
 
 // ToScheduledCampaign converts the raw model to a new createsend model.
-func (c *ScheduledCampaign) ToScheduledCampaign() (*clients.ScheduledCampaign, error) {
+//
+// The creation date is interpreted in the given location, and the schedule date is interpreted
+// in the schedule timezone, or in the given location if the schedule timezone is unknown.
+func (c *ScheduledCampaign) ToScheduledCampaign(location *time.Location) (*clients.ScheduledCampaign, error) {
 	if c == nil {
 		return nil, nil
 	}
-	dc, err := dateparse.ParseAny(c.DateCreated)
+	dc, err := ParseDate(c.DateCreated, location)
 	if err != nil {
 		return nil, err
 	}
 
-	ds, err := dateparse.ParseAny(c.DateScheduled)
+	tz := timezone.Timezone(c.ScheduledTimeZone)
+	scheduleLocation, err := tz.Location()
+	if err != nil {
+		scheduleLocation = location
+	}
+
+	ds, err := ParseDate(c.DateScheduled, scheduleLocation)
 	if err != nil {
 		return nil, err
 	}
@@ -94,7 +105,7 @@ func (c *ScheduledCampaign) ToScheduledCampaign() (*clients.ScheduledCampaign, e
 		},
 		DateCreated:   dc,
 		DateScheduled: ds,
-		Timezone:      c.ScheduledTimeZone,
+		Timezone:      tz,
 	}, nil
 }
 
@@ -106,8 +117,10 @@ type DraftCampaign struct {
 }
 
 // ToDraftCampaign converts the raw model to a new createsend model.
-func (c *DraftCampaign) ToDraftCampaign() (*clients.DraftCampaign, error) {
-	date, err := dateparse.ParseAny(c.DateCreated)
+//
+// The creation date is interpreted in the given location.
+func (c *DraftCampaign) ToDraftCampaign(location *time.Location) (*clients.DraftCampaign, error) {
+	date, err := ParseDate(c.DateCreated, location)
 	if err != nil {
 		return nil, err
 	}
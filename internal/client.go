@@ -1,9 +1,13 @@
 package internal
 
+import "time"
+
 // Client represent a client with shortcut methods for HTTP verbs.
 type Client interface {
 	Get(path string, result interface{}) error
 	Post(path string, result, body interface{}) error
 	Put(path string, result, body interface{}) error
 	Delete(path string) error
+	// Location returns the location in which the dates without a UTC offset must be interpreted.
+	Location() (*time.Location, error)
 }
@@ -0,0 +1,30 @@
+package internal
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayouts the date layouts used by Campaign Monitor.
+var dateLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseDate parses a Campaign Monitor date value.
+//
+// The values which do not specify a UTC offset are interpreted in the given location, or in UTC if the location is nil.
+func ParseDate(value string, location *time.Location) (time.Time, error) {
+	if location == nil {
+		location = time.UTC
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date value %q", value)
+}
@@ -0,0 +1,89 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatalf("Failed to load the location: %v", err)
+	}
+
+	testCases := []struct {
+		title         string
+		value         string
+		location      *time.Location
+		expected      time.Time
+		expectedError bool
+	}{
+		{
+			title:    "date and time in UTC by default",
+			value:    "2020-12-01 20:21:22",
+			expected: time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC),
+		},
+		{
+			title:    "date and time in location",
+			value:    "2020-12-01 20:21:22",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 20, 21, 22, 0, sydney),
+		},
+		{
+			title:    "date and time without seconds",
+			value:    "2020-12-01 20:21",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 20, 21, 0, 0, sydney),
+		},
+		{
+			title:    "date only",
+			value:    "2020-12-01",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 0, 0, 0, 0, sydney),
+		},
+		{
+			title:    "ISO 8601 without offset",
+			value:    "2020-12-01T20:21:22",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 20, 21, 22, 0, sydney),
+		},
+		{
+			title:    "ISO 8601 with fractional seconds",
+			value:    "2020-12-01T20:21:22.5",
+			expected: time.Date(2020, 12, 1, 20, 21, 22, 500000000, time.UTC),
+		},
+		{
+			title:    "ISO 8601 with offset ignores the location",
+			value:    "2020-12-01T20:21:22+02:00",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 18, 21, 22, 0, time.UTC),
+		},
+		{
+			title:    "ISO 8601 in UTC",
+			value:    "2020-12-01T20:21:22Z",
+			location: sydney,
+			expected: time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC),
+		},
+		{
+			title:         "day first dates are not accepted",
+			value:         "01/12/2020 20:21:22",
+			expectedError: true,
+		},
+		{
+			title:         "invalid date",
+			value:         "invalid date",
+			expectedError: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := ParseDate(tC.value, tC.location)
+			if (err != nil) != tC.expectedError {
+				t.Fatalf("Expected error: %v, Actual: '%v'", tC.expectedError, err)
+			}
+			if !actual.Equal(tC.expected) {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
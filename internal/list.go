@@ -1,7 +1,7 @@
 package internal
 
 import (
-	"github.com/araddon/dateparse"
+	"time"
 
 	"github.com/xitonix/createsend/clients"
 )
@@ -13,18 +13,20 @@ type SubscriberList struct {
 	// ListName list name.
 	ListName string
 	// SubscriberState subscriber status.
-	SubscriberState string
+	SubscriberState clients.SubscriberState
 	// DateSubscriberAdded date the subscriber was added to the list.
 	DateSubscriberAdded string
 }
 
 // ToSubscriberList converts the raw model to a new createsend model.
-func (s *SubscriberList) ToSubscriberList() (*clients.SubscriberList, error) {
+//
+// The date the subscriber was added is interpreted in the given location.
+func (s *SubscriberList) ToSubscriberList(location *time.Location) (*clients.SubscriberList, error) {
 	if s == nil {
 		return nil, nil
 	}
 
-	date, err := dateparse.ParseAny(s.DateSubscriberAdded)
+	date, err := ParseDate(s.DateSubscriberAdded, location)
 	if err != nil {
 		return nil, err
 	}
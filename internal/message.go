@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+// Message raw transactional message model.
+type Message struct {
+	// ID message ID.
+	ID string `json:"MessageID"`
+	// Status the delivery status of the message.
+	Status transactional.MessageStatus
+	// SentAt the time when the message was sent.
+	SentAt string
+	// Recipient the recipient of the message.
+	Recipient string
+	// From the sender of the message.
+	From string
+	// Subject subject line.
+	Subject string
+	// TotalOpens the number of times the message has been opened.
+	TotalOpens int
+	// TotalClicks the number of times the links of the message have been clicked.
+	TotalClicks int
+	// CanBeResent is true if the message can be resent.
+	CanBeResent bool
+	// SmartEmailID the ID of the smart email the message was sent from, if any.
+	SmartEmailID string
+	// Group the group of the classic email, if any.
+	Group string
+}
+
+// ToMessage converts the raw model to a new createsend model.
+//
+// The sent date is interpreted in the given location, unless it specifies a UTC offset.
+func (m *Message) ToMessage(location *time.Location) (*transactional.Message, error) {
+	date, err := ParseDate(m.SentAt, location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &transactional.Message{
+		ID:           m.ID,
+		Status:       m.Status,
+		SentAt:       date,
+		Recipient:    m.Recipient,
+		From:         m.From,
+		Subject:      m.Subject,
+		TotalOpens:   m.TotalOpens,
+		TotalClicks:  m.TotalClicks,
+		CanBeResent:  m.CanBeResent,
+		SmartEmailID: m.SmartEmailID,
+		Group:        m.Group,
+	}, nil
+}
@@ -0,0 +1,15 @@
+package internal
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// RandomPassword generates a random 24 character URL safe password.
+func RandomPassword() (string, error) {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,14 @@
+package internal
+
+import "testing"
+
+func TestRandomPassword(t *testing.T) {
+	first, err := RandomPassword()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	second, _ := RandomPassword()
+	if len(first) != 24 || first == second {
+		t.Errorf("Expected two different 24 character passwords, Actual: %q, %q", first, second)
+	}
+}
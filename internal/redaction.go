@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"net/http"
+	"regexp"
+)
+
+// Redacted the value which replaces the sensitive data.
+const Redacted = "[REDACTED]"
+
+var (
+	sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+	// sensitiveFields matches the JSON string fields which carry credentials or single use login URLs.
+	sensitiveFields = regexp.MustCompile(`(?i)("(?:APIKey|Password|SessionUrl|AccessToken|RefreshToken)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+)
+
+// RedactHeader returns a copy of the header with the values of the sensitive keys redacted.
+func RedactHeader(header http.Header) http.Header {
+	if header == nil {
+		return nil
+	}
+	redacted := header.Clone()
+	for _, key := range sensitiveHeaders {
+		if _, ok := redacted[key]; ok {
+			redacted.Set(key, Redacted)
+		}
+	}
+	return redacted
+}
+
+// RedactBody returns a copy of the JSON body with the values of the sensitive fields redacted.
+func RedactBody(body []byte) []byte {
+	return sensitiveFields.ReplaceAll(body, []byte(`${1}"`+Redacted+`"`))
+}
@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRedactHeader(t *testing.T) {
+	original := http.Header{
+		"Authorization": []string{"Basic secret"},
+		"Cookie":        []string{"session"},
+		"User-Agent":    []string{"createsend-go"},
+	}
+	expected := http.Header{
+		"Authorization": []string{Redacted},
+		"Cookie":        []string{Redacted},
+		"User-Agent":    []string{"createsend-go"},
+	}
+
+	actual := RedactHeader(original)
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+
+	if original.Get("Authorization") != "Basic secret" {
+		t.Error("The original header must not be modified")
+	}
+
+	if RedactHeader(nil) != nil {
+		t.Error("Redacting a nil header must return nil")
+	}
+}
+
+func TestRedactBody(t *testing.T) {
+	testCases := []struct {
+		title    string
+		body     string
+		expected string
+	}{
+		{
+			title:    "empty body",
+			body:     "",
+			expected: "",
+		},
+		{
+			title:    "no sensitive fields",
+			body:     `{"ClientID":"id","Name":"name"}`,
+			expected: `{"ClientID":"id","Name":"name"}`,
+		},
+		{
+			title:    "client API key",
+			body:     `{"ApiKey": "secret", "BasicDetails":{"ClientID":"id"}}`,
+			expected: `{"ApiKey": "[REDACTED]", "BasicDetails":{"ClientID":"id"}}`,
+		},
+		{
+			title:    "session URL with escaped characters",
+			body:     `{"SessionUrl":"https:\/\/x.createsend.com\/login?token=\"abc\""}`,
+			expected: `{"SessionUrl":"[REDACTED]"}`,
+		},
+		{
+			title:    "password",
+			body:     `{"EmailAddress":"a@b.com","Password":"p@ss"}`,
+			expected: `{"EmailAddress":"a@b.com","Password":"[REDACTED]"}`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual := string(RedactBody([]byte(tC.body)))
+			if actual != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
@@ -1,7 +1,7 @@
 package internal
 
 import (
-	"github.com/araddon/dateparse"
+	"time"
 
 	"github.com/xitonix/createsend/transactional"
 )
@@ -19,12 +19,14 @@ type SmartEmailBasicDetails struct {
 }
 
 // ToSmartEmailBasicDetails converts the raw model to a new createsend model.
-func (s *SmartEmailBasicDetails) ToSmartEmailBasicDetails() (*transactional.SmartEmailBasicDetails, error) {
+//
+// The creation date is interpreted in the given location, unless it specifies a UTC offset.
+func (s *SmartEmailBasicDetails) ToSmartEmailBasicDetails(location *time.Location) (*transactional.SmartEmailBasicDetails, error) {
 	if s == nil {
 		return nil, nil
 	}
 
-	date, err := dateparse.ParseAny(s.CreatedAt)
+	date, err := ParseDate(s.CreatedAt, location)
 	if err != nil {
 		return nil, err
 	}
@@ -4,8 +4,7 @@ import (
 	"fmt"
 	"net/mail"
 	"strings"
-
-	"github.com/araddon/dateparse"
+	"time"
 
 	"github.com/xitonix/createsend/transactional"
 )
@@ -49,12 +48,14 @@ type SmartEmailDetails struct {
 }
 
 // ToSmartEmailDetails converts the raw model to a new createsend model.
-func (s *SmartEmailDetails) ToSmartEmailDetails() (*transactional.SmartEmailDetails, error) {
+//
+// The creation date is interpreted in the given location, unless it specifies a UTC offset.
+func (s *SmartEmailDetails) ToSmartEmailDetails(location *time.Location) (*transactional.SmartEmailDetails, error) {
 	if s == nil || s.ID == "" {
 		return &transactional.SmartEmailDetails{}, nil
 	}
 
-	date, err := dateparse.ParseAny(s.CreatedAt)
+	date, err := ParseDate(s.CreatedAt, location)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,62 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+// SubscriberDetails raw subscriber details model.
+type SubscriberDetails struct {
+	// EmailAddress the email address.
+	EmailAddress string
+	// Name the name.
+	Name string
+	// Date the date the subscriber's state has last changed.
+	Date string
+	// ListJoinedDate the date the subscriber has joined the list.
+	ListJoinedDate string
+	// State the subscription state.
+	State clients.SubscriberState
+	// CustomFields the custom field values.
+	CustomFields []*subscribers.CustomField
+	// ReadsEmailWith the email client the subscriber reads the emails with.
+	ReadsEmailWith string
+	// ConsentToTrack the subscriber's consent to track.
+	ConsentToTrack transactional.ConsentToTrack
+}
+
+// ToSubscriberDetails converts the raw model to a new createsend model.
+//
+// The dates are interpreted in the given location. The list joined date is optional.
+func (s *SubscriberDetails) ToSubscriberDetails(location *time.Location) (*subscribers.Details, error) {
+	date, err := ParseDate(s.Date, location)
+	if err != nil {
+		return nil, err
+	}
+
+	var joined time.Time
+	if len(s.ListJoinedDate) > 0 {
+		if joined, err = ParseDate(s.ListJoinedDate, location); err != nil {
+			return nil, err
+		}
+	}
+
+	customFields := s.CustomFields
+	if customFields == nil {
+		customFields = make([]*subscribers.CustomField, 0)
+	}
+
+	return &subscribers.Details{
+		EmailAddress:   s.EmailAddress,
+		Name:           s.Name,
+		Date:           date,
+		ListJoinedDate: joined,
+		State:          s.State,
+		CustomFields:   customFields,
+		ReadsEmailWith: s.ReadsEmailWith,
+		ConsentToTrack: s.ConsentToTrack,
+	}, nil
+}
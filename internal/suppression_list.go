@@ -1,7 +1,7 @@
 package internal
 
 import (
-	"github.com/araddon/dateparse"
+	"time"
 
 	"github.com/xitonix/createsend/clients"
 	"github.com/xitonix/createsend/order"
@@ -10,13 +10,13 @@ import (
 // SuppressionDetails represents a suppression list item.
 type SuppressionDetails struct {
 	// SuppressionReason reason for suppression.
-	SuppressionReason string
+	SuppressionReason clients.SuppressionReason
 	// EmailAddress the suppressed email address.
 	EmailAddress string
 	// Date the date when the email address has been added to the suppression list.
 	Date string
 	// State the state of the suppressed email address.
-	State string
+	State clients.SuppressionState
 }
 
 // SuppressionList represents client suppression list.
@@ -40,7 +40,9 @@ type SuppressionList struct {
 }
 
 // ToSuppressionList converts the raw model to a new createsend model.
-func (s *SuppressionList) ToSuppressionList() (*clients.SuppressionList, error) {
+//
+// The suppression dates are interpreted in the given location.
+func (s *SuppressionList) ToSuppressionList(location *time.Location) (*clients.SuppressionList, error) {
 	output := &clients.SuppressionList{
 		Entries:              make([]*clients.SuppressionDetails, len(s.Results)),
 		OrderedBy:            s.ResultsOrderedBy,
@@ -52,7 +54,7 @@ func (s *SuppressionList) ToSuppressionList() (*clients.SuppressionList, error)
 		NumberOfPages:        s.NumberOfPages,
 	}
 	for i, entry := range s.Results {
-		date, err := dateparse.ParseAny(entry.Date)
+		date, err := ParseDate(entry.Date, location)
 		if err != nil {
 			return nil, err
 		}
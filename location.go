@@ -0,0 +1,143 @@
+package createsend
+
+import (
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/xitonix/createsend/internal"
+	"github.com/xitonix/createsend/timezone"
+)
+
+const (
+	systemDateLayout = "2006-01-02 15:04:05"
+	// offsetPrecision the precision of the detected account timezone offsets.
+	offsetPrecision = 15 * time.Minute
+)
+
+// locationResolver resolves the location in which the dates without a UTC offset are interpreted.
+type locationResolver struct {
+	location *time.Location
+	detect   bool
+	clientID string
+	lock     sync.Mutex
+}
+
+func newLocationResolver(opts *Options) *locationResolver {
+	return &locationResolver{
+		location: opts.location,
+		detect:   opts.detectLocation,
+		clientID: opts.clientID,
+	}
+}
+
+// resolve returns the configured location, or detects the account's or the client's timezone on the first call if enabled.
+//
+// UTC is returned if neither a location has been set, nor the detection has been enabled.
+func (l *locationResolver) resolve(client internal.Client) (*time.Location, error) {
+	if l == nil {
+		return time.UTC, nil
+	}
+	l.lock.Lock()
+	location := l.location
+	l.lock.Unlock()
+	if location != nil {
+		return location, nil
+	}
+	if !l.detect {
+		return time.UTC, nil
+	}
+
+	// The lock is not held during the detection round trips. Concurrent detections are harmless, and the first one wins.
+	location, err := l.detectLocation(client)
+	if err != nil {
+		return nil, err
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.location == nil {
+		l.location = location
+	}
+	return l.location, nil
+}
+
+// detectLocation returns the location of the client's timezone if the client is known,
+// otherwise the location of the account's timezone.
+func (l *locationResolver) detectLocation(client internal.Client) (*time.Location, error) {
+	if l.clientID != "" {
+		var result struct {
+			BasicDetails struct {
+				TimeZone string
+			}
+		}
+		err := client.Get(fmt.Sprintf("clients/%s.json", url.QueryEscape(l.clientID)), &result)
+		if err != nil {
+			return nil, err
+		}
+		if location, err := timezone.Timezone(result.BasicDetails.TimeZone).Location(); err == nil {
+			return location, nil
+		}
+	}
+
+	var result struct {
+		SystemDate string
+	}
+	err := client.Get(fetchCurrentDatePath, &result)
+	if err != nil {
+		return nil, err
+	}
+	accountTime, err := time.Parse(systemDateLayout, result.SystemDate)
+	if err != nil {
+		return nil, newWrappedClientError("Failed to parse the server date value", err, ErrCodeDataProcessing)
+	}
+	now := time.Now()
+	return locationForOffset(accountTime.Sub(now.UTC()).Round(offsetPrecision), now), nil
+}
+
+// locationForOffset returns the location of the Campaign Monitor timezones which are at the specified UTC offset at now.
+//
+// The account's timezone is not exposed by the API, so if the timezones at the offset follow different
+// daylight saving rules (eg. Brisbane and Sydney in winter), a fixed zone is returned.
+func locationForOffset(offset time.Duration, now time.Time) *time.Location {
+	var candidates []*time.Location
+	for _, tz := range timezone.All() {
+		location, err := tz.Location()
+		if err != nil {
+			continue
+		}
+		if _, seconds := now.In(location).Zone(); time.Duration(seconds)*time.Second == offset {
+			candidates = append(candidates, location)
+		}
+	}
+	if len(candidates) > 0 && haveSameOffsets(candidates, now.Year()) {
+		return candidates[0]
+	}
+	return time.FixedZone(offsetName(offset), int(offset.Seconds()))
+}
+
+// haveSameOffsets returns true if the locations are at the same UTC offsets throughout the year.
+func haveSameOffsets(locations []*time.Location, year int) bool {
+	for month := time.January; month <= time.December; month++ {
+		_, expected := time.Date(year, month, 1, 12, 0, 0, 0, locations[0]).Zone()
+		for _, location := range locations[1:] {
+			if _, offset := time.Date(year, month, 1, 12, 0, 0, 0, location).Zone(); offset != expected {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// offsetName returns the name of a fixed zone with the given UTC offset (eg. GMT+10:00).
+func offsetName(offset time.Duration) string {
+	if offset == 0 {
+		return "GMT"
+	}
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("GMT%c%02d:%02d", sign, int(offset.Hours()), int(offset.Minutes())%60)
+}
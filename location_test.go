@@ -0,0 +1,382 @@
+package createsend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/xitonix/createsend/mock"
+)
+
+func TestOffsetName(t *testing.T) {
+	testCases := []struct {
+		offset   time.Duration
+		expected string
+	}{
+		{0, "GMT"},
+		{10 * time.Hour, "GMT+10:00"},
+		{5*time.Hour + 45*time.Minute, "GMT+05:45"},
+		{-(3*time.Hour + 30*time.Minute), "GMT-03:30"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.expected, func(t *testing.T) {
+			if actual := offsetName(tC.offset); actual != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestLocation(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Fatalf("Failed to load the location: %v", err)
+	}
+	systemDate := func(offset time.Duration) *http.Response {
+		now := time.Now().UTC().Add(offset).Format(systemDateLayout)
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"SystemDate":%q}`, now))),
+		}
+	}
+
+	testCases := []struct {
+		title              string
+		options            []Option
+		systemDateResponse *http.Response
+		expectedOffset     int
+		expectedError      error
+		expectedDetections int
+	}{
+		{
+			title:          "UTC by default",
+			expectedOffset: 0,
+		},
+		{
+			title:          "explicit location",
+			options:        []Option{WithLocation(sydney)},
+			expectedOffset: 11 * 60 * 60,
+		},
+		{
+			title:          "explicit location overrides detection",
+			options:        []Option{WithLocation(sydney), WithLocationDetection(true)},
+			expectedOffset: 11 * 60 * 60,
+		},
+		{
+			title:              "detected location",
+			options:            []Option{WithLocationDetection(true)},
+			systemDateResponse: systemDate(5*time.Hour + 30*time.Minute + 10*time.Second),
+			expectedOffset:     (5*60 + 30) * 60,
+			expectedDetections: 1,
+		},
+		{
+			title:   "detection failure",
+			options: []Option{WithLocationDetection(true)},
+			systemDateResponse: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":100,"Message":"Invalid API Key"}`)),
+			},
+			expectedError:      ErrInvalidAPIKey,
+			expectedDetections: 1,
+		},
+		{
+			title:   "invalid system date",
+			options: []Option{WithLocationDetection(true)},
+			systemDateResponse: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"SystemDate":"invalid"}`)),
+			},
+			expectedError:      &Error{Code: int(ErrCodeDataProcessing)},
+			expectedDetections: 1,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			if tC.systemDateResponse != nil {
+				httpClient.SetResponse("systemdate.json", tC.systemDateResponse)
+			}
+			options := append([]Option{
+				WithBaseURL("https://base.com"),
+				WithHTTPClient(httpClient),
+				WithAPIKey("api_key"),
+			}, tC.options...)
+			client, err := New(options...)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+
+			for i := 0; i < 2; i++ {
+				httpClient.SetResponse("transactional/smartEmail", &http.Response{
+					StatusCode: http.StatusOK,
+					Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"ID":"id","CreatedAt":"2020-12-01T20:21:22","Status":"Active"}]`)),
+				})
+				emails, err := client.Transactional().SmartEmails()
+				if !errors.Is(err, tC.expectedError) {
+					t.Fatalf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+				}
+				if tC.expectedError != nil {
+					if emails != nil {
+						t.Error("The result must be nil on error")
+					}
+					break
+				}
+				createdAt := emails[0].CreatedAt
+				if _, offset := createdAt.Zone(); offset != tC.expectedOffset {
+					t.Errorf("Expected UTC offset: %d, Actual: %d", tC.expectedOffset, offset)
+				}
+				if createdAt.Hour() != 20 || createdAt.Minute() != 21 {
+					t.Errorf("The wall clock time must be preserved. Actual: %v", createdAt)
+				}
+			}
+
+			if count := httpClient.Count("/systemdate.json"); count > 0 && count != tC.expectedDetections {
+				t.Errorf("Expected detection calls: %d, Actual: %d", tC.expectedDetections, count)
+			}
+		})
+	}
+}
+
+func TestScheduledCampaignTimezone(t *testing.T) {
+	client, httpClient := createClient(t, false, false)
+	httpClient.SetResponse("clients/client_id/scheduled.json", &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(bytes.NewBufferString(`[{
+			"CampaignID": "id",
+			"DateCreated": "2020-12-01 20:21:22",
+			"DateScheduled": "2020-12-02 09:00:00",
+			"ScheduledTimeZone": "(GMT+10:00) Canberra, Melbourne, Sydney"
+		}]`)),
+	})
+	campaigns, err := client.Clients().ScheduledCampaigns("client_id")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expected := time.Date(2020, 12, 1, 22, 0, 0, 0, time.UTC)
+	if actual := campaigns[0].DateScheduled; !actual.Equal(expected) {
+		t.Errorf("Expected schedule date: %v, Actual: %v", expected, actual)
+	}
+	if location := campaigns[0].DateScheduled.Location().String(); location != "Australia/Sydney" {
+		t.Errorf("Expected schedule location: Australia/Sydney, Actual: %s", location)
+	}
+	if actual := campaigns[0].DateCreated; !actual.Equal(time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC)) {
+		t.Errorf("The creation date must be interpreted in UTC by default. Actual: %v", actual)
+	}
+}
+
+func TestLocationDetectionFailure(t *testing.T) {
+	testCases := []struct {
+		title string
+		path  string
+		body  string
+		call  func(c *Client) error
+	}{
+		{
+			title: "sent campaigns",
+			path:  "clients/id/campaigns.json",
+			body:  `[]`,
+			call: func(c *Client) error {
+				_, err := c.Clients().SentCampaigns("id")
+				return err
+			},
+		},
+		{
+			title: "scheduled campaigns",
+			path:  "clients/id/scheduled.json",
+			body:  `[]`,
+			call: func(c *Client) error {
+				_, err := c.Clients().ScheduledCampaigns("id")
+				return err
+			},
+		},
+		{
+			title: "draft campaigns",
+			path:  "clients/id/drafts.json",
+			body:  `[]`,
+			call: func(c *Client) error {
+				_, err := c.Clients().DraftCampaigns("id")
+				return err
+			},
+		},
+		{
+			title: "lists by email address",
+			path:  "clients/id/listsforemail.json",
+			body:  `[]`,
+			call: func(c *Client) error {
+				_, err := c.Clients().ListsByEmailAddress("id", "a@b.com")
+				return err
+			},
+		},
+		{
+			title: "suppression list",
+			path:  "clients/id/suppressionlist.json",
+			body:  `{}`,
+			call: func(c *Client) error {
+				_, err := c.Clients().SuppressionList("id", 10, 1, 0, 0)
+				return err
+			},
+		},
+		{
+			title: "smart email",
+			path:  "transactional/smartEmail/id",
+			body:  `{}`,
+			call: func(c *Client) error {
+				_, err := c.Transactional().SmartEmail("id")
+				return err
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			httpClient.SetResponse("systemdate.json", &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":100,"Message":"Invalid API Key"}`)),
+			})
+			httpClient.SetResponse(tC.path, &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(tC.body)),
+			})
+			client, err := New(
+				WithBaseURL("https://base.com"),
+				WithHTTPClient(httpClient),
+				WithAPIKey("api_key"),
+				WithLocationDetection(true),
+			)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if err := tC.call(client); !errors.Is(err, ErrInvalidAPIKey) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", ErrInvalidAPIKey, err)
+			}
+		})
+	}
+}
+
+func TestLocationForOffset(t *testing.T) {
+	testCases := []struct {
+		title    string
+		offset   time.Duration
+		now      time.Time
+		expected string
+	}{
+		{
+			title:    "timezones without daylight saving",
+			offset:   5*time.Hour + 30*time.Minute,
+			now:      time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expected: "Asia/Kolkata",
+		},
+		{
+			title:    "timezones with the same daylight saving rules",
+			offset:   10*time.Hour + 30*time.Minute,
+			now:      time.Date(2026, time.January, 15, 0, 0, 0, 0, time.UTC),
+			expected: "Australia/Adelaide",
+		},
+		{
+			title:    "timezones with different daylight saving rules",
+			offset:   10 * time.Hour,
+			now:      time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expected: "GMT+10:00",
+		},
+		{
+			title:    "unknown offset",
+			offset:   -(2*time.Hour + 15*time.Minute),
+			now:      time.Date(2026, time.July, 1, 0, 0, 0, 0, time.UTC),
+			expected: "GMT-02:15",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			location := locationForOffset(tC.offset, tC.now)
+			if location.String() != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, location)
+			}
+			if _, offset := tC.now.In(location).Zone(); time.Duration(offset)*time.Second != tC.offset {
+				t.Errorf("Expected offset: %v, Actual: %v", tC.offset, time.Duration(offset)*time.Second)
+			}
+		})
+	}
+}
+
+func TestLocation_ClientTimezone(t *testing.T) {
+	testCases := []struct {
+		title            string
+		timezone         string
+		expectedLocation string
+		expectedDates    int
+	}{
+		{
+			title:            "known timezone",
+			timezone:         "(GMT+10:00) Canberra, Melbourne, Sydney",
+			expectedLocation: "Australia/Sydney",
+		},
+		{
+			title:         "unknown timezone",
+			timezone:      "(GMT+01:00) Somewhere",
+			expectedDates: 1,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			httpClient := mock.NewHTTPClientMock()
+			httpClient.SetResponse("clients/client_id.json", &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"BasicDetails":{"TimeZone":%q}}`, tC.timezone))),
+			})
+			httpClient.SetResponse("systemdate.json", &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"SystemDate":%q}`, time.Now().UTC().Format(systemDateLayout)))),
+			})
+			httpClient.SetResponse("transactional/smartEmail", &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"ID":"id","CreatedAt":"2020-12-01T20:21:22","Status":"Active"}]`)),
+			})
+			client, err := New(
+				WithBaseURL("https://base.com"),
+				WithHTTPClient(httpClient),
+				WithClientAPIKey("client_key", "client_id"),
+				WithLocationDetection(true),
+			)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			emails, err := client.Transactional().SmartEmails()
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			createdAt := emails[0].CreatedAt
+			if location := createdAt.Location().String(); tC.expectedLocation != "" && location != tC.expectedLocation {
+				t.Errorf("Expected location: %s, Actual: %s", tC.expectedLocation, location)
+			}
+			if createdAt.Hour() != 20 || createdAt.Minute() != 21 {
+				t.Errorf("The wall clock time must be preserved. Actual: %v", createdAt)
+			}
+			if count := httpClient.Count("/systemdate.json"); count != tC.expectedDates {
+				t.Errorf("Expected system date calls: %d, Actual: %d", tC.expectedDates, count)
+			}
+		})
+	}
+}
+
+func TestLocation_ClientTimezoneFailure(t *testing.T) {
+	httpClient := mock.NewHTTPClientMock()
+	httpClient.SetResponse("clients/client_id.json", &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+	})
+	resolver := &locationResolver{detect: true, clientID: "client_id"}
+	hc, err := newHTTPClient(context.Background(), "https://base.com", httpClient, &authentication{token: "key", method: apiKeyAuthentication})
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if _, err := resolver.resolve(hc); !errors.Is(err, ErrMustBeLoggedIn) {
+		t.Errorf("Expected '%v' error, Actual: '%v'", ErrMustBeLoggedIn, err)
+	}
+}
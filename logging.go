@@ -0,0 +1,103 @@
+package createsend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/xitonix/createsend/internal"
+)
+
+type requestLogger struct {
+	logger       *slog.Logger
+	level        slog.Level
+	failureLevel slog.Level
+	bodies       bool
+}
+
+func newRequestLogger(opts *Options) *requestLogger {
+	if opts.logger == nil {
+		return nil
+	}
+	return &requestLogger{
+		logger:       opts.logger,
+		level:        opts.logLevel,
+		failureLevel: opts.failureLogLevel,
+		bodies:       opts.logBodies,
+	}
+}
+
+// logsBodies returns true if the request and response bodies must be logged.
+func (l *requestLogger) logsBodies(ctx context.Context) bool {
+	return l != nil && l.bodies && l.logger.Enabled(ctx, slog.LevelDebug)
+}
+
+// captureBody reads the response body and replaces it with an in-memory copy.
+func (l *requestLogger) captureBody(ctx context.Context, response *http.Response) []byte {
+	if !l.logsBodies(ctx) || response.Body == nil {
+		return nil
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	_ = response.Body.Close()
+	response.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), errorReader{err: err}))
+	return body
+}
+
+func (l *requestLogger) log(request *http.Request, statusCode int, elapsed time.Duration, responseBody []byte, err error) {
+	if l == nil {
+		return
+	}
+	ctx := request.Context()
+	attrs := []slog.Attr{
+		slog.String("method", request.Method),
+		slog.String("path", request.URL.Path),
+		slog.Int("status", statusCode),
+		slog.Duration("duration", elapsed),
+	}
+
+	level, msg := l.level, "createsend request completed"
+	if err != nil {
+		level, msg = l.failureLevel, "createsend request failed"
+		var csErr *Error
+		if errors.As(err, &csErr) {
+			attrs = append(attrs, slog.Int("error_code", csErr.Code))
+		}
+		attrs = append(attrs, slog.String("error", string(internal.RedactBody([]byte(err.Error())))))
+	}
+	l.logger.LogAttrs(ctx, level, msg, attrs...)
+
+	if !l.logsBodies(ctx) {
+		return
+	}
+	var requestBody []byte
+	if request.GetBody != nil {
+		if body, err := request.GetBody(); err == nil {
+			requestBody, _ = ioutil.ReadAll(body)
+			_ = body.Close()
+		}
+	}
+	l.logger.LogAttrs(ctx, slog.LevelDebug, "createsend request details",
+		slog.String("method", request.Method),
+		slog.String("path", request.URL.Path),
+		slog.Any("request_header", internal.RedactHeader(request.Header)),
+		slog.String("request_body", string(internal.RedactBody(requestBody))),
+		slog.String("response_body", string(internal.RedactBody(responseBody))),
+	)
+}
+
+// errorReader reports the error which has occurred while capturing the response body, once the captured data has been consumed.
+type errorReader struct {
+	err error
+}
+
+func (r errorReader) Read([]byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	return 0, io.EOF
+}
@@ -0,0 +1,189 @@
+package createsend
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/internal"
+	"github.com/xitonix/createsend/mock"
+)
+
+func createLoggedClient(t *testing.T, options ...Option) (*Client, *mock.HTTPClientMock, *bytes.Buffer) {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	httpClient := mock.NewHTTPClientMock()
+	options = append([]Option{
+		WithBaseURL("https://base.com"),
+		WithHTTPClient(httpClient),
+		WithAPIKey("secret_api_key"),
+		WithLogger(logger),
+	}, options...)
+	client, err := New(options...)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return client, httpClient, &buf
+}
+
+func readLogEntries(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid log entry %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestLogging_Requests(t *testing.T) {
+	testCases := []struct {
+		title             string
+		response          *http.Response
+		options           []Option
+		expectedLevel     string
+		expectedMessage   string
+		expectedStatus    float64
+		expectedErrorCode interface{}
+	}{
+		{
+			title: "successful request",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			expectedLevel:   "INFO",
+			expectedMessage: "createsend request completed",
+			expectedStatus:  200,
+		},
+		{
+			title: "failed request",
+			response: &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Code":50,"Message":"Must be logged in"}`)),
+			},
+			expectedLevel:     "ERROR",
+			expectedMessage:   "createsend request failed",
+			expectedStatus:    401,
+			expectedErrorCode: float64(50),
+		},
+		{
+			title: "custom log levels",
+			response: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			options:         []Option{WithLogLevels(slog.LevelDebug, slog.LevelWarn)},
+			expectedLevel:   "DEBUG",
+			expectedMessage: "createsend request completed",
+			expectedStatus:  200,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient, buf := createLoggedClient(t, tC.options...)
+			httpClient.SetResponse(listClientsPath, tC.response)
+
+			_, _ = client.Accounts().Clients()
+
+			entries := readLogEntries(t, buf)
+			if len(entries) != 1 {
+				t.Fatalf("Expected number of log entries: 1, Actual: %d", len(entries))
+			}
+			entry := entries[0]
+			expected := map[string]interface{}{
+				"level":      tC.expectedLevel,
+				"msg":        tC.expectedMessage,
+				"method":     http.MethodGet,
+				"path":       "/" + listClientsPath,
+				"status":     tC.expectedStatus,
+				"error_code": tC.expectedErrorCode,
+			}
+			for key, value := range expected {
+				if entry[key] != value {
+					t.Errorf("Expected %s: %v, Actual: %v", key, value, entry[key])
+				}
+			}
+			if _, ok := entry["duration"]; !ok {
+				t.Error("The duration must have been logged")
+			}
+		})
+	}
+}
+
+func TestLogging_BodiesAreRedacted(t *testing.T) {
+	client, httpClient, buf := createLoggedClient(t, WithBodyLogging(true))
+	httpClient.SetResponse("clients/id.json", &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"ApiKey":"client_api_key","BasicDetails":{"ClientID":"id"}}`)),
+	})
+	httpClient.SetResponse(externalSessionPath, &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"SessionUrl":"https://session/url"}`)),
+	})
+
+	details, err := client.Clients().Get("id")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if details.APIKey != "client_api_key" {
+		t.Errorf("Logging must not alter the response. Expected API key: client_api_key, Actual: %s", details.APIKey)
+	}
+
+	sessionURL, err := client.Accounts().NewEmbeddedSession(accounts.EmbeddedSession{EmailAddress: "a@b.com"})
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if sessionURL != "https://session/url" {
+		t.Errorf("Logging must not alter the response. Expected session URL: https://session/url, Actual: %s", sessionURL)
+	}
+
+	logs := buf.String()
+	for _, secret := range []string{"secret_api_key", "c2VjcmV0X2FwaV9rZXk6c2VjcmV0X2FwaV9rZXk", "client_api_key", "https://session/url"} {
+		if strings.Contains(logs, secret) {
+			t.Errorf("The logs must not contain %q", secret)
+		}
+	}
+
+	entries := readLogEntries(t, buf)
+	if len(entries) != 4 {
+		t.Fatalf("Expected number of log entries: 4, Actual: %d", len(entries))
+	}
+	details0 := entries[1]
+	if details0["level"] != "DEBUG" {
+		t.Errorf("Expected body log level: DEBUG, Actual: %v", details0["level"])
+	}
+	if !strings.Contains(details0["response_body"].(string), internal.Redacted) {
+		t.Errorf("Expected the API key to be redacted, Actual: %v", details0["response_body"])
+	}
+	if !strings.Contains(entries[3]["request_body"].(string), "a@b.com") {
+		t.Errorf("Expected the request body to be logged, Actual: %v", entries[3]["request_body"])
+	}
+}
+
+func TestLogging_BodiesAreNotLoggedByDefault(t *testing.T) {
+	client, httpClient, buf := createLoggedClient(t)
+	httpClient.SetResponse("clients/id.json", &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`{"ApiKey":"client_api_key"}`)),
+	})
+
+	_, _ = client.Clients().Get("id")
+
+	if strings.Contains(buf.String(), "response_body") {
+		t.Error("The bodies must not be logged unless body logging is enabled")
+	}
+}
@@ -0,0 +1,135 @@
+// Package lookup finds where an email address is used across all the clients of an account.
+package lookup
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/fanout"
+	"github.com/xitonix/createsend/order"
+)
+
+const (
+	defaultPageSize = 1000
+	defaultWorkers  = 4
+)
+
+// ErrEmptyEmailAddress occurs when the email address to look up is empty.
+var ErrEmptyEmailAddress = errors.New("the email address must not be empty")
+
+// Finder looks up email addresses across all the clients of an account.
+type Finder struct {
+	accounts accounts.API
+	clients  clients.API
+	pageSize int
+	workers  int
+}
+
+// New creates a new finder.
+func New(accountsAPI accounts.API, clientsAPI clients.API, options ...Option) *Finder {
+	f := &Finder{
+		accounts: accountsAPI,
+		clients:  clientsAPI,
+		pageSize: defaultPageSize,
+		workers:  defaultWorkers,
+	}
+	for _, op := range options {
+		op(f)
+	}
+	return f
+}
+
+// Find looks up the email address in the account administrators, and the subscriber lists, the suppression list
+// and the people of every client. The email addresses are compared case-insensitively.
+//
+// The suppression lists are paged through in full, since the API does not support searching them.
+// If the lookup fails for some of the clients, the report of the other clients is returned alongside
+// a fanout.Errors value keyed by client ID.
+func (f *Finder) Find(ctx context.Context, emailAddress string) (*Report, error) {
+	emailAddress = strings.TrimSpace(emailAddress)
+	if len(emailAddress) == 0 {
+		return nil, ErrEmptyEmailAddress
+	}
+
+	report := &Report{EmailAddress: emailAddress}
+	administrators, err := f.accounts.Administrators()
+	if err != nil {
+		return nil, err
+	}
+	for _, administrator := range administrators {
+		if strings.EqualFold(administrator.EmailAddress, emailAddress) {
+			report.Administrator = administrator
+			break
+		}
+	}
+
+	reports, err := fanout.Run(ctx, f.accounts, func(ctx context.Context, client *accounts.Client) (*ClientReport, error) {
+		return f.findInClient(client, emailAddress)
+	}, fanout.WithWorkers(f.workers))
+	var errs fanout.Errors
+	if err != nil && !errors.As(err, &errs) {
+		return nil, err
+	}
+
+	for _, clientReport := range reports {
+		if clientReport.found() {
+			report.Clients = append(report.Clients, clientReport)
+		}
+	}
+	sort.Slice(report.Clients, func(i, j int) bool {
+		a, b := report.Clients[i].Client, report.Clients[j].Client
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+	if len(errs) > 0 {
+		return report, errs
+	}
+	return report, nil
+}
+
+func (f *Finder) findInClient(client *accounts.Client, emailAddress string) (*ClientReport, error) {
+	lists, err := f.clients.ListsByEmailAddress(client.ID, emailAddress)
+	if err != nil {
+		return nil, err
+	}
+	report := &ClientReport{Client: client, Lists: lists}
+
+	if report.Suppression, err = f.findSuppression(client.ID, emailAddress); err != nil {
+		return nil, err
+	}
+
+	people, err := f.clients.People(client.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, person := range people {
+		if strings.EqualFold(person.EmailAddress, emailAddress) {
+			report.Person = person
+			break
+		}
+	}
+	return report, nil
+}
+
+func (f *Finder) findSuppression(clientID, emailAddress string) (*clients.SuppressionDetails, error) {
+	for page := 1; ; page++ {
+		list, err := f.clients.SuppressionList(clientID, f.pageSize, page, order.BySuppressedEmailAddress, order.ASC)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range list.Entries {
+			if strings.EqualFold(entry.EmailAddress, emailAddress) {
+				return entry, nil
+			}
+		}
+		if page >= list.NumberOfPages {
+			return nil, nil
+		}
+	}
+}
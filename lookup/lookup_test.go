@@ -0,0 +1,241 @@
+package lookup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/fanout"
+	"github.com/xitonix/createsend/order"
+)
+
+var errAPI = errors.New("api error")
+
+type accountsStub struct {
+	accounts.API
+	clients           []*accounts.Client
+	administrators    []*accounts.AdministratorDetails
+	clientsErr        error
+	administratorsErr error
+}
+
+func (a *accountsStub) Clients() ([]*accounts.Client, error) {
+	return a.clients, a.clientsErr
+}
+
+func (a *accountsStub) Administrators() ([]*accounts.AdministratorDetails, error) {
+	return a.administrators, a.administratorsErr
+}
+
+type clientData struct {
+	lists        []*clients.SubscriberList
+	suppressions []*clients.SuppressionDetails
+	people       []*clients.PersonDetails
+	listsErr     error
+	suppressErr  error
+	peopleErr    error
+}
+
+type clientsStub struct {
+	clients.API
+	data  map[string]*clientData
+	pages []string
+}
+
+func (c *clientsStub) ListsByEmailAddress(clientID, emailAddress string) ([]*clients.SubscriberList, error) {
+	d := c.data[clientID]
+	return d.lists, d.listsErr
+}
+
+func (c *clientsStub) SuppressionList(clientID string, pageSize, page int, orderBy order.SuppressionListField, direction order.Direction) (*clients.SuppressionList, error) {
+	d := c.data[clientID]
+	if d.suppressErr != nil {
+		return nil, d.suppressErr
+	}
+	if orderBy != order.BySuppressedEmailAddress || direction != order.ASC {
+		return nil, fmt.Errorf("unexpected order %v %v", orderBy, direction)
+	}
+	c.pages = append(c.pages, fmt.Sprintf("%s:%d/%d", clientID, page, pageSize))
+	start := (page - 1) * pageSize
+	end := start + pageSize
+	if end > len(d.suppressions) {
+		end = len(d.suppressions)
+	}
+	return &clients.SuppressionList{
+		Entries:       d.suppressions[start:end],
+		PageNumber:    page,
+		PageSize:      pageSize,
+		NumberOfPages: (len(d.suppressions) + pageSize - 1) / pageSize,
+	}, nil
+}
+
+func (c *clientsStub) People(clientID string) ([]*clients.PersonDetails, error) {
+	d := c.data[clientID]
+	return d.people, d.peopleErr
+}
+
+func suppressions(emails ...string) []*clients.SuppressionDetails {
+	result := make([]*clients.SuppressionDetails, len(emails))
+	for i, email := range emails {
+		result[i] = &clients.SuppressionDetails{EmailAddress: email, Reason: clients.UnsubscribedSuppression}
+	}
+	return result
+}
+
+func person(email string) *clients.PersonDetails {
+	return &clients.PersonDetails{
+		PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: email, AccessLevel: clients.FullAccess},
+		Status:             clients.ActivePerson,
+	}
+}
+
+func TestFinder_Find(t *testing.T) {
+	added := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	subscribed := []*clients.SubscriberList{
+		{
+			List:       clients.List{ID: "l1", Name: "List 1"},
+			Subscriber: clients.Subscriber{State: clients.ActiveSubscriber, DateAdded: added},
+		},
+	}
+	c1 := &accounts.Client{ID: "c1", Name: "Beta"}
+	c2 := &accounts.Client{ID: "c2", Name: "Alpha"}
+	c3 := &accounts.Client{ID: "c3", Name: "Gamma"}
+	c4 := &accounts.Client{ID: "c4", Name: "Alpha"}
+
+	testCases := []struct {
+		title          string
+		email          string
+		accounts       *accountsStub
+		data           map[string]*clientData
+		options        []Option
+		expected       *Report
+		expectedErr    error
+		expectedFailed []string
+		expectedPages  []string
+	}{
+		{
+			title:       "empty email address",
+			email:       "  ",
+			accounts:    &accountsStub{},
+			expectedErr: ErrEmptyEmailAddress,
+		},
+		{
+			title:       "administrators failure",
+			email:       "a@b.com",
+			accounts:    &accountsStub{administratorsErr: errAPI},
+			expectedErr: errAPI,
+		},
+		{
+			title:       "clients failure",
+			email:       "a@b.com",
+			accounts:    &accountsStub{clientsErr: errAPI},
+			expectedErr: errAPI,
+		},
+		{
+			title:    "not found",
+			email:    "a@b.com",
+			accounts: &accountsStub{clients: []*accounts.Client{c1}, administrators: []*accounts.AdministratorDetails{{Administrator: accounts.Administrator{EmailAddress: "x@b.com"}}}},
+			data: map[string]*clientData{
+				"c1": {suppressions: suppressions("x@b.com"), people: []*clients.PersonDetails{person("x@b.com")}},
+			},
+			expected:      &Report{EmailAddress: "a@b.com"},
+			expectedPages: []string{"c1:1/1000"},
+		},
+		{
+			title: "found everywhere",
+			email: " A@b.com ",
+			accounts: &accountsStub{
+				clients: []*accounts.Client{c1, c2, c3, c4},
+				administrators: []*accounts.AdministratorDetails{
+					{Administrator: accounts.Administrator{EmailAddress: "x@b.com"}},
+					{Administrator: accounts.Administrator{EmailAddress: "a@B.com", Name: "Admin"}, Status: accounts.ActiveAdministrator},
+				},
+			},
+			data: map[string]*clientData{
+				"c1": {lists: subscribed},
+				"c2": {suppressions: suppressions("0@b.com", "1@b.com", "a@b.com", "z@b.com")},
+				"c3": {},
+				"c4": {people: []*clients.PersonDetails{person("x@b.com"), person("a@b.com")}},
+			},
+			options: []Option{WithPageSize(2), WithPageSize(0), WithWorkers(1), WithWorkers(0)},
+			expected: &Report{
+				EmailAddress:  "A@b.com",
+				Administrator: &accounts.AdministratorDetails{Administrator: accounts.Administrator{EmailAddress: "a@B.com", Name: "Admin"}, Status: accounts.ActiveAdministrator},
+				Clients: []*ClientReport{
+					{Client: c2, Suppression: &clients.SuppressionDetails{EmailAddress: "a@b.com", Reason: clients.UnsubscribedSuppression}},
+					{Client: c4, Person: person("a@b.com")},
+					{Client: c1, Lists: subscribed},
+				},
+			},
+			expectedPages: []string{"c1:1/2", "c2:1/2", "c2:2/2", "c3:1/2", "c4:1/2"},
+		},
+		{
+			title:    "partial failure",
+			email:    "a@b.com",
+			accounts: &accountsStub{clients: []*accounts.Client{c1, c2, c3, c4}},
+			data: map[string]*clientData{
+				"c1": {lists: subscribed},
+				"c2": {listsErr: errAPI},
+				"c3": {suppressErr: errAPI},
+				"c4": {peopleErr: errAPI},
+			},
+			options: []Option{WithWorkers(1)},
+			expected: &Report{
+				EmailAddress: "a@b.com",
+				Clients:      []*ClientReport{{Client: c1, Lists: subscribed}},
+			},
+			expectedErr:    errAPI,
+			expectedFailed: []string{"c2", "c3", "c4"},
+			expectedPages:  []string{"c1:1/1000", "c4:1/1000"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			clientsAPI := &clientsStub{data: tC.data}
+			finder := New(tC.accounts, clientsAPI, tC.options...)
+
+			actual, err := finder.Find(context.Background(), tC.email)
+
+			if !errors.Is(err, tC.expectedErr) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedErr, err)
+			}
+			var errs fanout.Errors
+			errors.As(err, &errs)
+			if diff := cmp.Diff(tC.expectedFailed, errs.ClientIDs(), cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("Failed clients mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Report mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tC.expectedPages, clientsAPI.pages); diff != "" {
+				t.Errorf("Suppression pages mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReport_Found(t *testing.T) {
+	testCases := []struct {
+		title    string
+		report   *Report
+		expected bool
+	}{
+		{title: "empty", report: &Report{}},
+		{title: "administrator", report: &Report{Administrator: &accounts.AdministratorDetails{}}, expected: true},
+		{title: "client", report: &Report{Clients: []*ClientReport{{}}}, expected: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			if actual := tC.report.Found(); actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package lookup
+
+// Option represents a finder option.
+type Option func(f *Finder)
+
+// WithPageSize sets the page size used to search the suppression lists (default 1000).
+func WithPageSize(pageSize int) Option {
+	return func(f *Finder) {
+		if pageSize > 0 {
+			f.pageSize = pageSize
+		}
+	}
+}
+
+// WithWorkers sets the maximum number of the clients which are searched concurrently (default 4).
+func WithWorkers(workers int) Option {
+	return func(f *Finder) {
+		if workers > 0 {
+			f.workers = workers
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package lookup
+
+import (
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+)
+
+// Report represents the consolidated lookup result of an email address across the account.
+type Report struct {
+	// EmailAddress the email address.
+	EmailAddress string
+	// Administrator the account administrator with the email address, or nil if the address is not an administrator.
+	Administrator *accounts.AdministratorDetails
+	// Clients the clients the email address has been found in, sorted by name.
+	Clients []*ClientReport
+}
+
+// Found returns true if the email address has been found anywhere in the account.
+func (r *Report) Found() bool {
+	return r.Administrator != nil || len(r.Clients) > 0
+}
+
+// ClientReport represents the lookup result of an email address within a single client.
+type ClientReport struct {
+	// Client the client.
+	Client *accounts.Client
+	// Lists the subscriber lists of the client the email address belongs to, including the subscription state and date.
+	Lists []*clients.SubscriberList
+	// Suppression the suppression list entry of the email address, or nil if the address is not suppressed.
+	Suppression *clients.SuppressionDetails
+	// Person the client person with the email address, or nil if the address is not a person of the client.
+	Person *clients.PersonDetails
+}
+
+func (c *ClientReport) found() bool {
+	return len(c.Lists) > 0 || c.Suppression != nil || c.Person != nil
+}
@@ -0,0 +1,28 @@
+package createsend
+
+import "net/http"
+
+// HTTPClientFunc is an adapter to allow the use of ordinary functions as HTTP clients.
+type HTTPClientFunc func(request *http.Request) (*http.Response, error)
+
+// Do calls f(request).
+func (f HTTPClientFunc) Do(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Middleware wraps an HTTP client to intercept the requests sent to, and the responses received from Campaign Monitor.
+//
+// Middlewares are executed after the User-Agent and authentication headers have been applied to the request.
+// The package neither retries nor rate limits the requests on its own. These behaviours must be implemented as middlewares.
+type Middleware func(next HTTPClient) HTTPClient
+
+// chain wraps the client with the middlewares so that the first middleware becomes the outermost one.
+func chain(client HTTPClient, middlewares []Middleware) HTTPClient {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		if middlewares[i] == nil {
+			continue
+		}
+		client = middlewares[i](client)
+	}
+	return client
+}
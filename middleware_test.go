@@ -0,0 +1,102 @@
+package createsend
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/mock"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	var calls []string
+	record := func(name string) Middleware {
+		return func(next HTTPClient) HTTPClient {
+			return HTTPClientFunc(func(request *http.Request) (*http.Response, error) {
+				if request.Header.Get(authenticationHeaderKey) == "" {
+					t.Errorf("%s: the request must have been authenticated before reaching the middleware", name)
+				}
+				if request.Header.Get(userAgentHeaderKey) != userAgentHeaderValue {
+					t.Errorf("%s: the user agent must have been set before reaching the middleware", name)
+				}
+				calls = append(calls, name+":request")
+				response, err := next.Do(request)
+				calls = append(calls, name+":response")
+				return response, err
+			})
+		}
+	}
+
+	httpClient := mock.NewHTTPClientMock(mock.WhenCalled(func(*http.Request) {
+		calls = append(calls, "transport")
+	}))
+	httpClient.SetResponse(listClientsPath, &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+	})
+
+	client, err := New(
+		WithBaseURL("https://base.com"),
+		WithHTTPClient(httpClient),
+		WithAPIKey("api_key"),
+		WithMiddleware(record("first"), nil),
+		WithMiddleware(record("second")),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	_, err = client.Accounts().Clients()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	expected := []string{
+		"first:request",
+		"second:request",
+		"transport",
+		"second:response",
+		"first:response",
+	}
+	if diff := cmp.Diff(expected, calls); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	faulty := func(HTTPClient) HTTPClient {
+		return HTTPClientFunc(func(*http.Request) (*http.Response, error) {
+			return nil, mock.ErrDeliberate
+		})
+	}
+
+	httpClient := mock.NewHTTPClientMock()
+	httpClient.SetResponse(listClientsPath, &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+	})
+
+	client, err := New(
+		WithBaseURL("https://base.com"),
+		WithHTTPClient(httpClient),
+		WithAPIKey("api_key"),
+		WithMiddleware(faulty),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	_, err = client.Accounts().Clients()
+	if !errors.Is(err, mock.ErrDeliberate) {
+		t.Errorf("Expected '%v' error, Actual: '%v'", mock.ErrDeliberate, err)
+	}
+	checkErrorType(t, err, false)
+
+	if count := httpClient.Count("/" + listClientsPath); count != 0 {
+		t.Errorf("Expected number of calls to the transport: 0, Actual: %d", count)
+	}
+}
@@ -0,0 +1,90 @@
+package money
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Currency represents an ISO 4217 billing currency code.
+//
+// Only the predefined currencies are supported by Campaign Monitor. Any other code received from the API is kept as is,
+// so that it survives a round-trip, but it is not valid (see IsValid).
+type Currency string
+
+const (
+	// UnknownCurrency unknown currency.
+	UnknownCurrency Currency = ""
+	// USD United States dollar.
+	USD Currency = `USD`
+	// GBP Pound sterling.
+	GBP Currency = `GBP`
+	// EUR Euro.
+	EUR Currency = `EUR`
+	// CAD Canadian dollar.
+	CAD Currency = `CAD`
+	// AUD Australian dollar.
+	AUD Currency = `AUD`
+	// NZD New Zealand dollar.
+	NZD Currency = `NZD`
+)
+
+const unknownCurrencyStr = `unknown`
+
+var currencies = map[Currency]bool{
+	USD: true,
+	GBP: true,
+	EUR: true,
+	CAD: true,
+	AUD: true,
+	NZD: true,
+}
+
+// Currencies returns the list of the supported currencies.
+func Currencies() []Currency {
+	return []Currency{USD, GBP, EUR, CAD, AUD, NZD}
+}
+
+// ParseCurrency returns the currency with the specified ISO 4217 code (case-insensitive), or UnknownCurrency if the
+// code is not supported.
+func ParseCurrency(code string) Currency {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !currencies[c] {
+		return UnknownCurrency
+	}
+	return c
+}
+
+// IsValid returns true if the currency is supported.
+func (c Currency) IsValid() bool {
+	return currencies[c]
+}
+
+// MarshalJSON marshal the object into json bytes.
+func (c Currency) MarshalJSON() ([]byte, error) {
+	if c == UnknownCurrency {
+		return json.Marshal(unknownCurrencyStr)
+	}
+	return json.Marshal(string(c))
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+//
+// Empty and "unknown" values are unmarshalled as UnknownCurrency. Unsupported codes are kept in upper case, and it is up
+// to the caller to validate them.
+func (c *Currency) UnmarshalJSON(b []byte) error {
+	var code string
+	if err := json.Unmarshal(b, &code); err != nil {
+		return err
+	}
+	code = strings.TrimSpace(code)
+	if strings.EqualFold(code, unknownCurrencyStr) {
+		code = ""
+	}
+	*c = Currency(strings.ToUpper(code))
+	return nil
+}
+
+// String Stringer implementation
+func (c Currency) String() string {
+	return string(c)
+}
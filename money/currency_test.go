@@ -0,0 +1,104 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCurrency_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		input    Currency
+		expected string
+	}{
+		{title: "unknown", input: UnknownCurrency, expected: `"unknown"`},
+		{title: "unsupported", input: Currency("JPY"), expected: `"JPY"`},
+		{title: "USD", input: USD, expected: `"USD"`},
+		{title: "GBP", input: GBP, expected: `"GBP"`},
+		{title: "EUR", input: EUR, expected: `"EUR"`},
+		{title: "CAD", input: CAD, expected: `"CAD"`},
+		{title: "AUD", input: AUD, expected: `"AUD"`},
+		{title: "NZD", input: NZD, expected: `"NZD"`},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := json.Marshal(tC.input)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if string(actual) != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCurrency_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected Currency
+	}{
+		{input: `"USD"`, expected: USD},
+		{input: `"gbp"`, expected: GBP},
+		{input: `"Eur"`, expected: EUR},
+		{input: `"CAD"`, expected: CAD},
+		{input: `"AUD"`, expected: AUD},
+		{input: `"NZD"`, expected: NZD},
+		{input: `"JPY"`, expected: Currency("JPY")},
+		{input: `"jpy"`, expected: Currency("JPY")},
+		{input: `"unknown"`, expected: UnknownCurrency},
+		{input: `""`, expected: UnknownCurrency},
+		{input: `null`, expected: UnknownCurrency},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			var actual Currency
+			if err := json.Unmarshal([]byte(tC.input), &actual); err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if actual != tC.expected {
+				t.Errorf("Expected: %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCurrency_String(t *testing.T) {
+	for _, c := range Currencies() {
+		if !c.IsValid() {
+			t.Errorf("Expected %s to be valid", c)
+		}
+		if ParseCurrency(c.String()) != c {
+			t.Errorf("Expected %s to round-trip", c)
+		}
+	}
+	if UnknownCurrency.IsValid() || UnknownCurrency.String() != "" {
+		t.Error("Expected the unknown currency to be invalid with an empty string representation")
+	}
+	if actual := ParseCurrency("JPY"); actual != UnknownCurrency {
+		t.Errorf("Expected: unknown, Actual: %v", actual)
+	}
+	if actual := ParseCurrency(" nzd "); actual != NZD {
+		t.Errorf("Expected: NZD, Actual: %v", actual)
+	}
+}
+
+func TestCurrency_UnsupportedRoundTrip(t *testing.T) {
+	var c Currency
+	if err := json.Unmarshal([]byte(`"JPY"`), &c); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if c.IsValid() {
+		t.Error("Expected JPY to be invalid")
+	}
+	if c.String() != "JPY" {
+		t.Errorf("Expected: JPY, Actual: %s", c)
+	}
+	actual, err := json.Marshal(c)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if string(actual) != `"JPY"` {
+		t.Errorf("Expected: %q, Actual: %s", `"JPY"`, actual)
+	}
+}
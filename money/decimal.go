@@ -0,0 +1,231 @@
+package money
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidDecimal occurs when a string cannot be parsed as a decimal number.
+var ErrInvalidDecimal = errors.New("invalid decimal number")
+
+var ten = big.NewInt(10)
+
+// Decimal represents an arbitrary precision decimal number.
+//
+// Unlike float64, decimals represent monetary values exactly, and preserve the number of fractional digits
+// through JSON encoding. The zero value is 0.
+type Decimal struct {
+	unscaled *big.Int
+	scale    int32
+}
+
+// New creates a new decimal number with the value of unscaled * 10^-scale.
+//
+// For example, New(1050, 2) is 10.50.
+func New(unscaled int64, scale int32) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// NewFromInt creates a new decimal number from an integer value.
+func NewFromInt(value int64) Decimal {
+	return New(value, 0)
+}
+
+// Parse parses a decimal number (eg. "10.50", "-0.125" or "1.5e-3").
+func Parse(value string) (Decimal, error) {
+	s := strings.TrimSpace(value)
+	exponent := int64(0)
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.ParseInt(s[i+1:], 10, 16)
+		if err != nil {
+			return Decimal{}, ErrInvalidDecimal
+		}
+		exponent = e
+		s = s[:i]
+	}
+
+	sign := ""
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+
+	integer, fraction := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		integer, fraction = s[:i], s[i+1:]
+	}
+	if len(integer)+len(fraction) == 0 || !isDigits(integer) || !isDigits(fraction) {
+		return Decimal{}, ErrInvalidDecimal
+	}
+
+	unscaled, ok := new(big.Int).SetString(sign+integer+fraction, 10)
+	if !ok {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	scale := int64(len(fraction)) - exponent
+	if scale < -(1<<31) || scale > 1<<31-1 {
+		return Decimal{}, ErrInvalidDecimal
+	}
+	return newDecimal(unscaled, int32(scale)), nil
+}
+
+// MustParse parses a decimal number and panics if the value is invalid.
+func MustParse(value string) Decimal {
+	d, err := Parse(value)
+	if err != nil {
+		panic(err.Error() + ": " + value)
+	}
+	return d
+}
+
+func newDecimal(unscaled *big.Int, scale int32) Decimal {
+	if scale < 0 {
+		unscaled = new(big.Int).Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// Scale returns the number of fractional digits.
+func (d Decimal) Scale() int32 {
+	return d.scale
+}
+
+// Add returns d + other.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{unscaled: a.Add(a, b), scale: scale}
+}
+
+// Sub returns d - other.
+func (d Decimal) Sub(other Decimal) Decimal {
+	a, b, scale := align(d, other)
+	return Decimal{unscaled: a.Sub(a, b), scale: scale}
+}
+
+// Mul returns d * other.
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal{unscaled: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return Decimal{unscaled: new(big.Int).Neg(d.int()), scale: d.scale}
+}
+
+// Round rounds the number half away from zero to the specified number of fractional digits.
+//
+// The result always has exactly the requested number of fractional digits (eg. 1.5 rounded to 2 places is 1.50).
+func (d Decimal) Round(places int32) Decimal {
+	if places < 0 {
+		places = 0
+	}
+	if places >= d.scale {
+		return Decimal{unscaled: new(big.Int).Mul(d.int(), pow10(places-d.scale)), scale: places}
+	}
+	divisor := pow10(d.scale - places)
+	quotient, remainder := new(big.Int).QuoRem(d.int(), divisor, new(big.Int))
+	if remainder.Lsh(remainder.Abs(remainder), 1).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(d.Sign())))
+	}
+	return Decimal{unscaled: quotient, scale: places}
+}
+
+// Cmp compares the two numbers and returns -1 if d < other, 0 if d == other and +1 if d > other.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, _ := align(d, other)
+	return a.Cmp(b)
+}
+
+// Equal returns true if both numbers represent the same value, regardless of their scales (eg. 1.5 and 1.50).
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Sign returns -1 if d < 0, 0 if d == 0 and +1 if d > 0.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero returns true if the value is 0.
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// Float64 returns the nearest float64 value of the number.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String Stringer implementation
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	sign := ""
+	if d.Sign() < 0 {
+		sign = "-"
+	}
+	if d.scale == 0 {
+		return sign + digits
+	}
+	if missing := int(d.scale) - len(digits) + 1; missing > 0 {
+		digits = strings.Repeat("0", missing) + digits
+	}
+	point := len(digits) - int(d.scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// MarshalJSON marshal the object into json bytes.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+//
+// Both JSON numbers and strings are accepted.
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	parsed, err := Parse(strings.Trim(string(b), "\""))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+	return d.unscaled
+}
+
+// align returns copies of the unscaled values of both numbers expressed in the larger scale of the two.
+func align(x, y Decimal) (*big.Int, *big.Int, int32) {
+	a, b := new(big.Int).Set(x.int()), new(big.Int).Set(y.int())
+	switch {
+	case x.scale < y.scale:
+		a.Mul(a, pow10(y.scale-x.scale))
+		return a, b, y.scale
+	case x.scale > y.scale:
+		b.Mul(b, pow10(x.scale-y.scale))
+	}
+	return a, b, x.scale
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
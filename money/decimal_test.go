@@ -0,0 +1,198 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expected      string
+		expectedScale int32
+		expectedError error
+	}{
+		{input: "0", expected: "0"},
+		{input: "10", expected: "10"},
+		{input: "10.50", expected: "10.50", expectedScale: 2},
+		{input: " -0.125 ", expected: "-0.125", expectedScale: 3},
+		{input: "+1.1", expected: "1.1", expectedScale: 1},
+		{input: ".5", expected: "0.5", expectedScale: 1},
+		{input: "5.", expected: "5"},
+		{input: "1.5e-3", expected: "0.0015", expectedScale: 4},
+		{input: "1.5E2", expected: "150"},
+		{input: "0.1e1", expected: "1"},
+		{input: "123456789012345678901234567890.123456789", expected: "123456789012345678901234567890.123456789", expectedScale: 9},
+		{input: "", expectedError: ErrInvalidDecimal},
+		{input: "-", expectedError: ErrInvalidDecimal},
+		{input: ".", expectedError: ErrInvalidDecimal},
+		{input: "abc", expectedError: ErrInvalidDecimal},
+		{input: "1.2.3", expectedError: ErrInvalidDecimal},
+		{input: "1,000", expectedError: ErrInvalidDecimal},
+		{input: "--1", expectedError: ErrInvalidDecimal},
+		{input: "1e", expectedError: ErrInvalidDecimal},
+		{input: "1e99999", expectedError: ErrInvalidDecimal},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			actual, err := Parse(tC.input)
+			if !errors.Is(err, tC.expectedError) {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if tC.expectedError != nil {
+				return
+			}
+			if actual.String() != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+			if actual.Scale() != tC.expectedScale {
+				t.Errorf("Expected scale: %d, Actual: %d", tC.expectedScale, actual.Scale())
+			}
+		})
+	}
+}
+
+func TestMustParse(t *testing.T) {
+	if actual := MustParse("1.25"); actual.String() != "1.25" {
+		t.Errorf("Expected: 1.25, Actual: %s", actual)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustParse to panic")
+		}
+	}()
+	MustParse("invalid")
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		actual   Decimal
+		expected string
+	}{
+		{New(1050, 2), "10.50"},
+		{New(-5, 3), "-0.005"},
+		{New(15, -2), "1500"},
+		{NewFromInt(42), "42"},
+		{Decimal{}, "0"},
+	}
+	for _, tC := range testCases {
+		if tC.actual.String() != tC.expected {
+			t.Errorf("Expected: %s, Actual: %s", tC.expected, tC.actual)
+		}
+	}
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a, b := MustParse("10.1"), MustParse("0.25")
+	testCases := []struct {
+		title    string
+		actual   Decimal
+		expected string
+	}{
+		{"add", a.Add(b), "10.35"},
+		{"add to zero value", Decimal{}.Add(b), "0.25"},
+		{"sub", a.Sub(b), "9.85"},
+		{"sub negative result", b.Sub(a), "-9.85"},
+		{"mul", a.Mul(b), "2.525"},
+		{"neg", a.Neg(), "-10.1"},
+		{"neg zero value", Decimal{}.Neg(), "0"},
+		{"exact sum of tenths", MustParse("0.1").Add(MustParse("0.2")), "0.3"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			if tC.actual.String() != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, tC.actual)
+			}
+		})
+	}
+	if a.String() != "10.1" || b.String() != "0.25" {
+		t.Errorf("Expected the operands to remain unchanged, Actual: %s, %s", a, b)
+	}
+}
+
+func TestDecimal_Round(t *testing.T) {
+	testCases := []struct {
+		input    string
+		places   int32
+		expected string
+	}{
+		{"1.234", 2, "1.23"},
+		{"1.235", 2, "1.24"},
+		{"-1.235", 2, "-1.24"},
+		{"-1.234", 2, "-1.23"},
+		{"0.5", 0, "1"},
+		{"-0.5", 0, "-1"},
+		{"0.49", 0, "0"},
+		{"1.5", 2, "1.50"},
+		{"2", 2, "2.00"},
+		{"2.5", -1, "3"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.input, func(t *testing.T) {
+			if actual := MustParse(tC.input).Round(tC.places); actual.String() != tC.expected {
+				t.Errorf("Expected: %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecimal_Comparison(t *testing.T) {
+	testCases := []struct {
+		a, b          string
+		expectedCmp   int
+		expectedEqual bool
+	}{
+		{"1.5", "1.50", 0, true},
+		{"1.5", "1.49", 1, false},
+		{"-1", "0.001", -1, false},
+		{"0", "0.00", 0, true},
+	}
+	for _, tC := range testCases {
+		a, b := MustParse(tC.a), MustParse(tC.b)
+		if actual := a.Cmp(b); actual != tC.expectedCmp {
+			t.Errorf("%s vs %s: Expected: %d, Actual: %d", tC.a, tC.b, tC.expectedCmp, actual)
+		}
+		if actual := a.Equal(b); actual != tC.expectedEqual {
+			t.Errorf("%s == %s: Expected: %v, Actual: %v", tC.a, tC.b, tC.expectedEqual, actual)
+		}
+	}
+
+	if !(Decimal{}).IsZero() || MustParse("0.01").IsZero() {
+		t.Error("IsZero returned an unexpected result")
+	}
+	if MustParse("-0.01").Sign() != -1 || MustParse("0.01").Sign() != 1 || (Decimal{}).Sign() != 0 {
+		t.Error("Sign returned an unexpected result")
+	}
+	if actual := MustParse("10.25").Float64(); actual != 10.25 {
+		t.Errorf("Expected: 10.25, Actual: %v", actual)
+	}
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	type rates struct {
+		Rate    Decimal
+		Markup  Decimal
+		Missing Decimal
+	}
+	input := `{"Rate":10.10,"Markup":"0.000123","Missing":null}`
+	var actual rates
+	if err := json.Unmarshal([]byte(input), &actual); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if actual.Rate.String() != "10.10" || actual.Markup.String() != "0.000123" || !actual.Missing.IsZero() {
+		t.Errorf("Unexpected value: %+v", actual)
+	}
+
+	marshalled, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if expected := `{"Rate":10.10,"Markup":0.000123,"Missing":0}`; string(marshalled) != expected {
+		t.Errorf("Expected: %s, Actual: %s", expected, marshalled)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Rate":true}`), &actual); !errors.Is(err, ErrInvalidDecimal) {
+		t.Errorf("Expected error: %v, Actual: %v", ErrInvalidDecimal, err)
+	}
+}
@@ -2,12 +2,18 @@ package createsend
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cache"
 	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/subscribers"
 	"github.com/xitonix/createsend/transactional"
 )
 
@@ -21,13 +27,27 @@ type Option func(*Options)
 
 // Options client configurations.
 type Options struct {
-	client        HTTPClient
-	auth          *authentication
-	baseURL       string
-	accounts      accounts.API
-	clients       clients.API
-	transactional transactional.API
-	ctx           context.Context
+	client                  HTTPClient
+	auth                    *authentication
+	clientID                string
+	baseURL                 string
+	accounts                accounts.API
+	clients                 clients.API
+	transactional           transactional.API
+	subscribers             subscribers.API
+	ctx                     context.Context
+	middlewares             []Middleware
+	tracerProvider          trace.TracerProvider
+	meterProvider           metric.MeterProvider
+	logger                  *slog.Logger
+	logLevel                slog.Level
+	failureLogLevel         slog.Level
+	logBodies               bool
+	cache                   cache.Cache
+	validation              bool
+	referenceDataValidation bool
+	location                *time.Location
+	detectLocation          bool
 }
 
 func defaultOptions() *Options {
@@ -39,7 +59,9 @@ func defaultOptions() *Options {
 		client: &http.Client{
 			Timeout: 5 * time.Second,
 		},
-		ctx: context.Background(),
+		ctx:             context.Background(),
+		logLevel:        slog.LevelInfo,
+		failureLogLevel: slog.LevelError,
 	}
 }
 
@@ -70,6 +92,15 @@ func WithTransactionalAPI(api transactional.API) Option {
 	}
 }
 
+// WithSubscribersAPI overrides the internal object for accessing Subscribers API.
+//
+// You can override the API to mock out Subscribers API methods altogether.
+func WithSubscribersAPI(api subscribers.API) Option {
+	return func(options *Options) {
+		options.subscribers = api
+	}
+}
+
 // WithContext sets the context for all the HTTP requests.
 func WithContext(ctx context.Context) Option {
 	return func(options *Options) {
@@ -87,6 +118,23 @@ func WithHTTPClient(client HTTPClient) Option {
 	}
 }
 
+// WithMiddleware adds the middlewares to the chain of handlers around the internal HTTP client.
+//
+// Each request flows through the chain in the following order:
+//
+//  1. The User-Agent and authentication headers are applied.
+//  2. The middlewares are executed in the order they have been registered across all WithMiddleware calls.
+//     The first registered middleware is the outermost one, and the first one to see the request.
+//  3. The request is sent using the HTTP client set by WithHTTPClient.
+//
+// Retry and rate limiting middlewares sit after authentication, so they always see fully authenticated requests.
+// Register the retry middleware before the rate limiter if every attempt must be throttled.
+func WithMiddleware(middlewares ...Middleware) Option {
+	return func(options *Options) {
+		options.middlewares = append(options.middlewares, middlewares...)
+	}
+}
+
 // WithBaseURL overrides the base URL.
 func WithBaseURL(url string) Option {
 	return func(options *Options) {
@@ -104,6 +152,17 @@ func WithAPIKey(apiKey string) Option {
 	}
 }
 
+// WithClientAPIKey enables API key authentication using the API key of the specified client.
+//
+// Campaign Monitor does not reveal the client an API key belongs to, so the client ID is used by WhoAmI
+// to confirm the scope of the key.
+func WithClientAPIKey(apiKey, clientID string) Option {
+	return func(options *Options) {
+		WithAPIKey(apiKey)(options)
+		options.clientID = strings.TrimSpace(clientID)
+	}
+}
+
 // WithOAuthToken enables Oauth token authentication.
 func WithOAuthToken(token string) Option {
 	return func(options *Options) {
@@ -113,3 +172,104 @@ func WithOAuthToken(token string) Option {
 		}
 	}
 }
+
+// WithTracerProvider enables tracing using the specified OpenTelemetry tracer provider.
+//
+// A span will be created for each API operation (eg. clients.Get), with the HTTP calls recorded as its children.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(options *Options) {
+		options.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider enables exporting the request count, latency and error metrics of each API operation
+// using the specified OpenTelemetry meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(options *Options) {
+		options.meterProvider = provider
+	}
+}
+
+// WithLogger enables logging the method, path, status, duration and error code of each request.
+//
+// Credentials, client API keys and embedded session URLs are always redacted.
+func WithLogger(logger *slog.Logger) Option {
+	return func(options *Options) {
+		options.logger = logger
+	}
+}
+
+// WithLogLevels sets the levels at which the successful and the failed requests will be logged.
+//
+// The default levels are Info and Error respectively.
+func WithLogLevels(success, failure slog.Level) Option {
+	return func(options *Options) {
+		options.logLevel = success
+		options.failureLogLevel = failure
+	}
+}
+
+// WithBodyLogging enables logging the request headers, and the request and response bodies at Debug level.
+func WithBodyLogging(enabled bool) Option {
+	return func(options *Options) {
+		options.logBodies = enabled
+	}
+}
+
+// WithCache enables caching the responses of the read methods of accounts and clients APIs, such as
+// Countries, Timezones and clients.Get.
+//
+// The cached responses are invalidated by the corresponding mutating calls (eg. clients.Update).
+// Use cache.NewLRU for an in-memory cache. The cached responses are scoped to the credentials, so the sessions
+// created by ForClient, which share the cache, never read the responses cached for the account.
+func WithCache(c cache.Cache) Option {
+	return func(options *Options) {
+		options.cache = c
+	}
+}
+
+// WithValidation enables validating the requests of accounts, clients and transactional APIs before sending them to the server.
+//
+// Invalid requests fail with an *Error of ErrCodeValidationFailed code, which wraps a *ValidationError listing every offending field.
+func WithValidation(enabled bool) Option {
+	return func(options *Options) {
+		options.validation = enabled
+	}
+}
+
+// WithReferenceDataValidation enables the request validation, and validates the country and timezone values
+// of clients against the values returned by accounts.Countries and accounts.Timezones.
+//
+// The reference data is loaded on the first use and kept in the cache set by WithCache, or in memory if caching is disabled.
+func WithReferenceDataValidation(enabled bool) Option {
+	return func(options *Options) {
+		options.referenceDataValidation = enabled
+		if enabled {
+			options.validation = true
+		}
+	}
+}
+
+// WithLocation sets the location in which the dates returned by the server without a UTC offset are interpreted.
+//
+// Campaign Monitor expresses most of the dates (eg. the sent date of campaigns) in the timezone of the account.
+// The dates are interpreted in UTC by default.
+func WithLocation(location *time.Location) Option {
+	return func(options *Options) {
+		options.location = location
+	}
+}
+
+// WithLocationDetection enables detecting the location of the client's or the account's timezone.
+//
+// The location is detected once, before converting the first date returned by the server,
+// and is ignored if a location has been explicitly set by WithLocation.
+// The clients created by ForClient or WithClientAPIKey use the timezone of the client.
+// Otherwise, the account's UTC offset is detected using the account's current date, and is mapped to
+// the Campaign Monitor timezones at the same offset. If these timezones follow different daylight saving rules,
+// a fixed offset is used, in which case the dates across daylight saving changes are interpreted an hour off.
+func WithLocationDetection(enabled bool) Option {
+	return func(options *Options) {
+		options.detectLocation = enabled
+	}
+}
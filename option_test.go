@@ -2,6 +2,7 @@ package createsend
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"testing"
 )
@@ -82,6 +83,15 @@ func TestWithTransactionalAPI(t *testing.T) {
 	}
 }
 
+func TestWithSubscribersAPI(t *testing.T) {
+	ops := defaultOptions()
+	option := WithSubscribersAPI(&subscribersAPI{})
+	option(ops)
+	if ops.subscribers == nil {
+		t.Error("Subscribers API was nil")
+	}
+}
+
 func TestWithHTTPClient(t *testing.T) {
 	ops := defaultOptions()
 	option := WithHTTPClient(&http.Client{})
@@ -128,3 +138,35 @@ func TestWithOAuthToken(t *testing.T) {
 		t.Errorf("Expected authentication token: %s, Actual: %s", expected, ops.auth.token)
 	}
 }
+
+func TestWithMiddleware(t *testing.T) {
+	noop := func(next HTTPClient) HTTPClient { return next }
+	ops := defaultOptions()
+	WithMiddleware(noop)(ops)
+	WithMiddleware(noop, noop)(ops)
+	if len(ops.middlewares) != 3 {
+		t.Errorf("Expected number of middlewares: 3, Actual: %d", len(ops.middlewares))
+	}
+}
+
+func TestLoggingOptions(t *testing.T) {
+	ops := defaultOptions()
+	if ops.logLevel != slog.LevelInfo || ops.failureLogLevel != slog.LevelError {
+		t.Errorf("Expected default log levels: %v/%v, Actual: %v/%v", slog.LevelInfo, slog.LevelError, ops.logLevel, ops.failureLogLevel)
+	}
+
+	logger := slog.Default()
+	WithLogger(logger)(ops)
+	WithLogLevels(slog.LevelDebug, slog.LevelWarn)(ops)
+	WithBodyLogging(true)(ops)
+
+	if ops.logger != logger {
+		t.Error("The logger was not set")
+	}
+	if ops.logLevel != slog.LevelDebug || ops.failureLogLevel != slog.LevelWarn {
+		t.Errorf("Expected log levels: %v/%v, Actual: %v/%v", slog.LevelDebug, slog.LevelWarn, ops.logLevel, ops.failureLogLevel)
+	}
+	if !ops.logBodies {
+		t.Error("Body logging was not enabled")
+	}
+}
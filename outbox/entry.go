@@ -0,0 +1,112 @@
+package outbox
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+// State represents the delivery state of an outbox entry.
+type State uint8
+
+const (
+	// UnknownState unknown state.
+	UnknownState State = iota
+	// Pending the entry is waiting to be sent.
+	Pending
+	// Sending the entry has been claimed by a worker.
+	Sending
+	// Sent the entry has been sent.
+	Sent
+	// Dead the entry has been dead-lettered.
+	Dead
+)
+
+const (
+	unknownStateStr = `unknown`
+	pendingStr      = `pending`
+	sendingStr      = `sending`
+	sentStr         = `sent`
+	deadStr         = `dead`
+)
+
+var (
+	stateToValue = map[string]State{
+		pendingStr: Pending,
+		sendingStr: Sending,
+		sentStr:    Sent,
+		deadStr:    Dead,
+	}
+
+	stateFromValue = map[State]string{
+		Pending: pendingStr,
+		Sending: sendingStr,
+		Sent:    sentStr,
+		Dead:    deadStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (s State) MarshalJSON() ([]byte, error) {
+	typeStr, ok := stateFromValue[s]
+	if !ok {
+		return json.Marshal(unknownStateStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (s *State) UnmarshalJSON(b []byte) error {
+	*s = stateToValue[strings.ToLower(strings.Trim(string(b), "\""))]
+	return nil
+}
+
+// String Stringer implementation
+func (s State) String() string {
+	return stateFromValue[s]
+}
+
+// Entry represents a smart email in the outbox.
+type Entry struct {
+	// Key the idempotency key of the entry.
+	Key string
+	// SmartEmailID the ID of the smart email to send.
+	SmartEmailID string
+	// Message the message to send.
+	Message transactional.SmartEmailMessage
+	// State the delivery state.
+	State State
+	// Attempts the number of delivery attempts.
+	Attempts int
+	// CreatedAt the time when the entry was added to the outbox.
+	CreatedAt time.Time
+	// NextAttemptAt the time of the next delivery attempt of a pending entry,
+	// or the time the claim of an entry being sent expires at.
+	NextAttemptAt time.Time
+	// ClaimedAt the time when the entry was last claimed.
+	ClaimedAt time.Time
+	// InterruptedAt the time when the first delivery attempt whose outcome is unknown was claimed
+	// (eg. the process has crashed while sending the entry), if any.
+	//
+	// Such an entry is only sent again if it cannot be found in the message timeline.
+	InterruptedAt *time.Time `json:",omitempty"`
+	// LastError the error of the last failed delivery attempt, if any.
+	LastError string `json:",omitempty"`
+	// SentAt the time when the entry was sent.
+	SentAt *time.Time `json:",omitempty"`
+	// Results the results returned by the server for each recipient once the entry has been sent.
+	Results []*transactional.SendResult `json:",omitempty"`
+	// Version the version of the entry, which is incremented by the store every time the entry is claimed or updated.
+	//
+	// The store rejects the updates of stale versions, so that a worker whose claim has expired cannot overwrite
+	// the outcome of the worker which has claimed the entry after it.
+	Version int64
+}
+
+func (e *Entry) clone() *Entry {
+	c := *e
+	c.Results = append([]*transactional.SendResult(nil), e.Results...)
+	return &c
+}
@@ -0,0 +1,79 @@
+package outbox
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestState_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		state    State
+		expected string
+	}{
+		{title: "Unknown", expected: fmt.Sprintf("%q", unknownStateStr)},
+		{title: "Pending", state: Pending, expected: fmt.Sprintf("%q", pendingStr)},
+		{title: "Sending", state: Sending, expected: fmt.Sprintf("%q", sendingStr)},
+		{title: "Sent", state: Sent, expected: fmt.Sprintf("%q", sentStr)},
+		{title: "Dead", state: Dead, expected: fmt.Sprintf("%q", deadStr)},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.state.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestState_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected State
+		state    string
+	}{
+		{title: "Unknown", state: "Unknown", expected: UnknownState},
+		{title: "random string", state: "random", expected: UnknownState},
+		{title: "pending lowercase", state: "pending", expected: Pending},
+		{title: "pending mixed case", state: "Pending", expected: Pending},
+		{title: "sending", state: "SENDING", expected: Sending},
+		{title: "sent", state: "Sent", expected: Sent},
+		{title: "dead", state: "dead", expected: Dead},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var actual State
+			err := actual.UnmarshalJSON([]byte(fmt.Sprintf("%q", tC.state)))
+			if err != nil {
+				t.Errorf("Expected no errors, but received %s", err)
+			}
+			if tC.expected != actual {
+				t.Errorf("Expected %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestState_String(t *testing.T) {
+	testCases := []struct {
+		state    State
+		expected string
+	}{
+		{state: UnknownState, expected: ""},
+		{state: Pending, expected: pendingStr},
+		{state: Sending, expected: sendingStr},
+		{state: Sent, expected: sentStr},
+		{state: Dead, expected: deadStr},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.expected, func(t *testing.T) {
+			if actual := tC.state.String(); actual != tC.expected {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, actual)
+			}
+		})
+	}
+}
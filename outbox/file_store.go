@@ -0,0 +1,125 @@
+package outbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	entryFileExtension = ".json"
+	tempFilePattern    = "entry-*.tmp"
+)
+
+// FileStore is a durable Store which keeps each entry in a JSON file within a directory.
+//
+// The entries are loaded into memory when the store is opened, and every change is written to disk atomically
+// before being applied. The directory must not be shared by multiple processes.
+type FileStore struct {
+	*MemoryStore
+	dir string
+}
+
+// OpenFileStore opens the store in the specified directory, and creates the directory if it does not exist.
+//
+// The temporary files left behind by a crash are deleted.
+func OpenFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &FileStore{MemoryStore: NewMemoryStore(), dir: dir}
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		if ok, _ := filepath.Match(tempFilePattern, file.Name()); ok {
+			if err := os.Remove(filepath.Join(dir, file.Name())); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if !strings.HasSuffix(file.Name(), entryFileExtension) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		var entry Entry
+		if err := json.Unmarshal(content, &entry); err != nil {
+			return nil, fmt.Errorf("invalid outbox entry %s: %w", file.Name(), err)
+		}
+		store.entries[entry.Key] = &entry
+	}
+	store.persist = store.write
+	store.remove = store.delete
+	return store, nil
+}
+
+// write writes the entry into a temporary file, and moves it into place so that a crash never leaves a partial entry behind.
+//
+// The directory is synced after the move, so that the new entry survives a power failure.
+func (f *FileStore) write(entry *Entry) error {
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(f.dir, tempFilePattern)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Sync(); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(temp.Name(), f.path(entry.Key)); err != nil {
+		return err
+	}
+	return f.sync()
+}
+
+// delete deletes the file of the entry.
+func (f *FileStore) delete(key string) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return f.sync()
+}
+
+// sync flushes the changes of the directory entries to disk.
+func (f *FileStore) sync() error {
+	dir, err := os.Open(f.dir)
+	if err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+	return dir.Close()
+}
+
+// path returns the file path of the entry. The key is hashed, since it may contain characters which are not valid in file names.
+func (f *FileStore) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:])+entryFileExtension)
+}
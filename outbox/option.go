@@ -0,0 +1,135 @@
+package outbox
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	defaultWorkers      = 4
+	defaultMaxAttempts  = 5
+	defaultBackoff      = time.Second
+	defaultMaxBackoff   = 5 * time.Minute
+	defaultPollInterval = time.Second
+	defaultLease        = time.Minute
+)
+
+type options struct {
+	workers      int
+	maxAttempts  int
+	backoff      time.Duration
+	maxBackoff   time.Duration
+	pollInterval time.Duration
+	lease        time.Duration
+	clientID     string
+	deadLetter   func(entry *Entry)
+	errorHandler func(entry *Entry, err error)
+	now          func() time.Time
+	after        func(time.Duration) <-chan time.Time
+}
+
+func newOptions(opts []Option) *options {
+	ops := &options{
+		workers:      defaultWorkers,
+		maxAttempts:  defaultMaxAttempts,
+		backoff:      defaultBackoff,
+		maxBackoff:   defaultMaxBackoff,
+		pollInterval: defaultPollInterval,
+		lease:        defaultLease,
+		deadLetter:   func(*Entry) {},
+		errorHandler: func(*Entry, error) {},
+		now:          time.Now,
+		after:        time.After,
+	}
+	for _, op := range opts {
+		op(ops)
+	}
+	return ops
+}
+
+// Option represents an outbox option.
+type Option func(ops *options)
+
+// WithWorkers sets the maximum number of the emails which are sent concurrently (default 4).
+func WithWorkers(workers int) Option {
+	return func(ops *options) {
+		if workers > 0 {
+			ops.workers = workers
+		}
+	}
+}
+
+// WithMaxAttempts sets the number of delivery attempts after which an entry is dead-lettered (default 5).
+func WithMaxAttempts(attempts int) Option {
+	return func(ops *options) {
+		if attempts > 0 {
+			ops.maxAttempts = attempts
+		}
+	}
+}
+
+// WithBackoff sets the initial and the maximum delays between the delivery attempts (default 1s and 5m).
+//
+// The delay is doubled after each failed attempt, unless the server specifies the delay.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(ops *options) {
+		if initial > 0 {
+			ops.backoff = initial
+		}
+		if max >= ops.backoff {
+			ops.maxBackoff = max
+		}
+	}
+}
+
+// WithPollInterval sets how often the store is checked for due entries (default 1s).
+//
+// The entries added using Outbox.Enqueue are picked up immediately.
+func WithPollInterval(interval time.Duration) Option {
+	return func(ops *options) {
+		if interval > 0 {
+			ops.pollInterval = interval
+		}
+	}
+}
+
+// WithLease sets how long an entry is claimed for while being sent (default 1m).
+//
+// If the process crashes while sending an entry, the entry is claimed again once the lease expires, and it is only
+// sent again if it cannot be found in the message timeline. The lease must be longer than the HTTP client's timeout.
+func WithLease(lease time.Duration) Option {
+	return func(ops *options) {
+		if lease > 0 {
+			ops.lease = lease
+		}
+	}
+}
+
+// WithClientID sets the ID of the client whose message timeline is searched for the interrupted entries.
+//
+// The client ID is required if the outbox uses an account API key or OAuth.
+func WithClientID(clientID string) Option {
+	return func(ops *options) {
+		ops.clientID = strings.TrimSpace(clientID)
+	}
+}
+
+// WithDeadLetter sets the function which gets called when an entry is dead-lettered.
+func WithDeadLetter(deadLetter func(entry *Entry)) Option {
+	return func(ops *options) {
+		if deadLetter != nil {
+			ops.deadLetter = deadLetter
+		}
+	}
+}
+
+// WithErrorHandler sets the function which gets called when the store fails.
+//
+// The entry is nil if claiming the due entries has failed.
+func WithErrorHandler(handler func(entry *Entry, err error)) Option {
+	return func(ops *options) {
+		if handler != nil {
+			ops.errorHandler = handler
+		}
+	}
+}
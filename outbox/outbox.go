@@ -0,0 +1,274 @@
+// Package outbox provides a durable outbox for smart transactional emails, which are delivered
+// in the background by a pool of workers with retries.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const (
+	timelinePageSize = 100
+	// clockSkew the tolerated difference between the local and the server clocks while searching the message timeline.
+	clockSkew = time.Minute
+)
+
+var (
+	// ErrEmptyKey occurs when the idempotency key of an entry is empty.
+	ErrEmptyKey = errors.New("the idempotency key must not be empty")
+	// ErrClaimExpired is reported as the last error of the entries which are dead-lettered because their last
+	// claim has expired after the maximum delivery attempts.
+	ErrClaimExpired = errors.New("the claim has expired after the maximum delivery attempts")
+)
+
+// Outbox stores the smart emails durably, and delivers them in the background.
+//
+// The idempotency keys prevent the same email from being added to the outbox twice (eg. when the caller retries
+// after a crash). An entry which was being sent when the process crashed (or whose claim expired while it was being
+// sent) is claimed again once its claim expires, and before sending it again, the message timeline of the smart email
+// is searched for the messages sent to any of its recipients since the interrupted attempt. If a message is found,
+// the entry is marked as sent with the found messages as its results.
+//
+// The timeline does not include the email variables, so the messages are matched by smart email and recipient:
+// the same smart email sent to the same recipient by another entry around the same time is taken as sent.
+type Outbox struct {
+	store Store
+	api   transactional.API
+	ops   *options
+	wake  chan struct{}
+}
+
+// New creates a new outbox.
+func New(store Store, api transactional.API, options ...Option) *Outbox {
+	return &Outbox{
+		store: store,
+		api:   api,
+		ops:   newOptions(options),
+		wake:  make(chan struct{}, 1),
+	}
+}
+
+// Enqueue adds the smart email to the outbox under the specified idempotency key.
+//
+// ErrDuplicateKey is returned if an entry with the same key has already been added, in which case the email must not
+// be considered lost. The attachments are validated before the email is added.
+func (o *Outbox) Enqueue(key, smartEmailID string, message transactional.SmartEmailMessage) error {
+	if len(strings.TrimSpace(key)) == 0 {
+		return ErrEmptyKey
+	}
+	if err := transactional.ValidateAttachments(message.Attachments...); err != nil {
+		return err
+	}
+	now := o.ops.now()
+	err := o.store.Add(&Entry{
+		Key:           key,
+		SmartEmailID:  smartEmailID,
+		Message:       message,
+		State:         Pending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	})
+	if err != nil {
+		return err
+	}
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run delivers the due entries until the context is cancelled.
+//
+// Once the context is cancelled, no more entries are claimed, and Run returns after the in-flight deliveries have completed.
+// Store failures are reported to the error handler, and the store is polled again after the poll interval.
+func (o *Outbox) Run(ctx context.Context) {
+	slots := make(chan struct{}, o.ops.workers)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		available := 1
+	acquire:
+		for available < o.ops.workers {
+			select {
+			case slots <- struct{}{}:
+				available++
+			default:
+				break acquire
+			}
+		}
+
+		entries, err := o.store.Claim(o.ops.now(), available, o.ops.lease)
+		if err != nil {
+			o.ops.errorHandler(nil, err)
+		}
+		for i := len(entries); i < available; i++ {
+			<-slots
+		}
+		for _, entry := range entries {
+			wg.Add(1)
+			go func(entry *Entry) {
+				defer wg.Done()
+				defer func() { <-slots }()
+				o.deliver(entry)
+			}(entry)
+		}
+		if err == nil && len(entries) == available {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.wake:
+		case <-o.ops.after(o.ops.pollInterval):
+		}
+	}
+}
+
+func (o *Outbox) deliver(entry *Entry) {
+	// The entry has been claimed again after its last allowed attempt has expired.
+	if entry.Attempts > o.ops.maxAttempts {
+		entry.State = Dead
+		entry.LastError = ErrClaimExpired.Error()
+		o.update(entry)
+		return
+	}
+
+	results, err := o.send(entry)
+	now := o.ops.now()
+	switch {
+	case err == nil:
+		entry.State = Sent
+		entry.SentAt = &now
+		entry.Results = results
+		entry.LastError = ""
+	case entry.Attempts >= o.ops.maxAttempts || !retryable(err):
+		entry.State = Dead
+		entry.LastError = err.Error()
+	default:
+		entry.State = Pending
+		entry.NextAttemptAt = now.Add(retryDelay(err, o.ops.backoff, o.ops.maxBackoff, entry.Attempts))
+		entry.LastError = err.Error()
+	}
+	o.update(entry)
+}
+
+// send sends the entry, unless an interrupted delivery attempt of the entry has already been accepted by the server.
+func (o *Outbox) send(entry *Entry) ([]*transactional.SendResult, error) {
+	if entry.InterruptedAt != nil {
+		results, err := o.findSent(entry)
+		if err != nil || len(results) > 0 {
+			return results, err
+		}
+	}
+	return o.api.SendSmartEmail(entry.SmartEmailID, entry.Message)
+}
+
+// findSent pages through the message timeline of the smart email, newest first, and returns the messages which have
+// been sent to any of the recipients of the entry since it was interrupted.
+func (o *Outbox) findSent(entry *Entry) ([]*transactional.SendResult, error) {
+	recipients := make(map[string]bool)
+	for _, list := range [][]string{entry.Message.To, entry.Message.CC, entry.Message.BCC} {
+		for _, recipient := range list {
+			recipients[emailAddress(recipient)] = true
+		}
+	}
+	since := entry.InterruptedAt.Add(-clockSkew)
+
+	options := []transactional.Option{transactional.WithSmartEmailID(entry.SmartEmailID), transactional.WithCount(timelinePageSize)}
+	if o.ops.clientID != "" {
+		options = append(options, transactional.WithClientID(o.ops.clientID))
+	}
+	base := len(options)
+	var results []*transactional.SendResult
+	for {
+		messages, err := o.api.MessageTimeline(options...)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if message.SentAt.Before(since) {
+				return results, nil
+			}
+			if recipients[emailAddress(message.Recipient)] {
+				results = append(results, &transactional.SendResult{
+					MessageID: message.ID,
+					Status:    message.Status.String(),
+					Recipient: message.Recipient,
+				})
+			}
+		}
+		if len(messages) < timelinePageSize {
+			return results, nil
+		}
+		options = append(options[:base:base], transactional.WithSentBeforeID(messages[len(messages)-1].ID))
+	}
+}
+
+// emailAddress returns the lower case email address of the recipient (eg. "Joe <joe@example.com>").
+func emailAddress(recipient string) string {
+	if address, err := mail.ParseAddress(recipient); err == nil {
+		recipient = address.Address
+	}
+	return strings.ToLower(strings.TrimSpace(recipient))
+}
+
+// update saves the outcome of the delivery, and calls the dead letter function if the entry has been dead-lettered.
+//
+// ErrConflict is reported to the error handler if the claim has expired and the entry has been claimed again.
+func (o *Outbox) update(entry *Entry) {
+	if err := o.store.Update(entry); err != nil {
+		o.ops.errorHandler(entry, err)
+		return
+	}
+	if entry.State == Dead {
+		o.ops.deadLetter(entry)
+	}
+}
+
+// retryable returns true if sending the email again may succeed.
+//
+// The requests rejected by the server (eg. an invalid smart email ID) and the requests which fail the validation
+// are not retried. All the other failures, including the network failures, are retried.
+func retryable(err error) bool {
+	if createsend.IsRetryable(err) || createsend.IsAuthError(err) {
+		return true
+	}
+	var csErr *createsend.Error
+	if !errors.As(err, &csErr) {
+		return true
+	}
+	return !csErr.IsFromServer() && !errors.Is(err, &createsend.Error{Code: int(createsend.ErrCodeValidationFailed)})
+}
+
+// retryDelay returns the delay requested by the server, or an exponential backoff capped at max.
+func retryDelay(err error, backoff, max time.Duration, attempts int) time.Duration {
+	var csErr *createsend.Error
+	if errors.As(err, &csErr) {
+		if delay, ok := csErr.RetryAfter(); ok {
+			return delay
+		}
+	}
+	delay := backoff
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
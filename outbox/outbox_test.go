@@ -0,0 +1,629 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/transactional"
+)
+
+// transactionalStub returns the results of the send and the timeline functions, and counts the concurrent sends.
+type transactionalStub struct {
+	transactional.API
+	send       func(smartEmailID string, message transactional.SmartEmailMessage) ([]*transactional.SendResult, error)
+	timeline   func(options *transactional.Options) ([]*transactional.Message, error)
+	calls      int32
+	inFlight   int32
+	concurrent int32
+}
+
+func (s *transactionalStub) SendSmartEmail(smartEmailID string, message transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+	atomic.AddInt32(&s.calls, 1)
+	current := atomic.AddInt32(&s.inFlight, 1)
+	defer atomic.AddInt32(&s.inFlight, -1)
+	for {
+		max := atomic.LoadInt32(&s.concurrent)
+		if current <= max || atomic.CompareAndSwapInt32(&s.concurrent, max, current) {
+			break
+		}
+	}
+	return s.send(smartEmailID, message)
+}
+
+func (s *transactionalStub) MessageTimeline(options ...transactional.Option) ([]*transactional.Message, error) {
+	if s.timeline == nil {
+		return nil, nil
+	}
+	ops := &transactional.Options{}
+	for _, op := range options {
+		op(ops)
+	}
+	return s.timeline(ops)
+}
+
+func succeed(string, transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+	return []*transactional.SendResult{{MessageID: "message-id", Status: "Accepted", Recipient: "jane@example.com"}}, nil
+}
+
+// failingStore fails the calls after the wrapped store has been called.
+type failingStore struct {
+	Store
+	claimErr  error
+	updateErr error
+}
+
+func (f *failingStore) Claim(now time.Time, limit int, lease time.Duration) ([]*Entry, error) {
+	entries, err := f.Store.Claim(now, limit, lease)
+	if err != nil {
+		return entries, err
+	}
+	return entries, f.claimErr
+}
+
+func (f *failingStore) Update(entry *Entry) error {
+	if f.updateErr != nil {
+		return f.updateErr
+	}
+	return f.Store.Update(entry)
+}
+
+// start runs the outbox in the background, and returns a function which stops it and waits for Run to return.
+func start(t *testing.T, o *Outbox) func() {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		o.Run(ctx)
+	}()
+	return func() {
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Run did not return after the context was cancelled")
+		}
+	}
+}
+
+// waitFor waits until the entry reaches the specified state, and returns it.
+func waitFor(t *testing.T, store Store, key string, state State) *Entry {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		entry, err := store.Get(key)
+		if err == nil && entry.State == state {
+			return entry
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %s to be %v, Actual: %+v (%v)", key, state, entry, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func fastRetries() []Option {
+	return []Option{
+		WithBackoff(time.Millisecond, 2*time.Millisecond),
+		WithPollInterval(time.Millisecond),
+	}
+}
+
+func TestOutbox_Enqueue(t *testing.T) {
+	store := NewMemoryStore()
+	o := New(store, &transactionalStub{send: succeed})
+	message := transactional.SmartEmailMessage{To: []string{"jane@example.com"}}
+
+	if err := o.Enqueue("key", "smart-email-id", message); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	entry, err := store.Get("key")
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if entry.State != Pending || entry.SmartEmailID != "smart-email-id" || entry.CreatedAt.IsZero() || !entry.NextAttemptAt.Equal(entry.CreatedAt) {
+		t.Errorf("Unexpected entry: %+v", entry)
+	}
+
+	testCases := []struct {
+		title    string
+		key      string
+		message  transactional.SmartEmailMessage
+		expected error
+	}{
+		{title: "duplicate key", key: "key", message: message, expected: ErrDuplicateKey},
+		{title: "empty key", key: " ", message: message, expected: ErrEmptyKey},
+		{
+			title: "attachments too large",
+			key:   "other",
+			message: transactional.SmartEmailMessage{Attachments: []*transactional.Attachment{
+				{Name: "large.pdf", Content: strings.Repeat("A", transactional.MaxTotalAttachmentSize/2/3*4+4)},
+				{Name: "large.pdf", Content: strings.Repeat("A", transactional.MaxTotalAttachmentSize/2/3*4+4)},
+			}},
+			expected: transactional.ErrAttachmentsTooLarge,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			err := o.Enqueue(tC.key, "smart-email-id", tC.message)
+			if !errors.Is(err, tC.expected) {
+				t.Errorf("Expected %v, Actual: %v", tC.expected, err)
+			}
+		})
+	}
+}
+
+func TestOutbox_Run(t *testing.T) {
+	serverError := func(status, code int) error {
+		return &createsend.Error{Code: code, StatusCode: status, Header: http.Header{}}
+	}
+	validationError := &createsend.Error{Code: int(createsend.ErrCodeValidationFailed), Message: "invalid"}
+
+	testCases := []struct {
+		title            string
+		failures         []error
+		maxAttempts      int
+		expectedState    State
+		expectedAttempts int
+		expectedError    string
+	}{
+		{
+			title:            "sent at the first attempt",
+			expectedState:    Sent,
+			expectedAttempts: 1,
+		},
+		{
+			title:            "sent after retrying the retryable failures",
+			failures:         []error{serverError(http.StatusServiceUnavailable, 0), serverError(http.StatusUnauthorized, 0), errors.New("connection reset")},
+			expectedState:    Sent,
+			expectedAttempts: 4,
+		},
+		{
+			title:            "dead-lettered after the maximum attempts",
+			failures:         []error{serverError(http.StatusBadGateway, 0), serverError(http.StatusBadGateway, 0), serverError(http.StatusBadGateway, 0)},
+			maxAttempts:      2,
+			expectedState:    Dead,
+			expectedAttempts: 2,
+			expectedError:    serverError(http.StatusBadGateway, 0).Error(),
+		},
+		{
+			title:            "dead-lettered when rejected by the server",
+			failures:         []error{serverError(http.StatusBadRequest, 1)},
+			expectedState:    Dead,
+			expectedAttempts: 1,
+			expectedError:    serverError(http.StatusBadRequest, 1).Error(),
+		},
+		{
+			title:            "dead-lettered when the validation fails",
+			failures:         []error{validationError},
+			expectedState:    Dead,
+			expectedAttempts: 1,
+			expectedError:    validationError.Error(),
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var mux sync.Mutex
+			failures := tC.failures
+			api := &transactionalStub{send: func(id string, m transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+				mux.Lock()
+				defer mux.Unlock()
+				if len(failures) > 0 {
+					err := failures[0]
+					failures = failures[1:]
+					return nil, err
+				}
+				return succeed(id, m)
+			}}
+			var deadLettered []string
+			options := append(fastRetries(), WithDeadLetter(func(entry *Entry) {
+				mux.Lock()
+				defer mux.Unlock()
+				deadLettered = append(deadLettered, entry.Key)
+			}))
+			if tC.maxAttempts > 0 {
+				options = append(options, WithMaxAttempts(tC.maxAttempts))
+			}
+
+			store := NewMemoryStore()
+			o := New(store, api, options...)
+			stop := start(t, o)
+			if err := o.Enqueue("key", "smart-email-id", transactional.SmartEmailMessage{To: []string{"jane@example.com"}}); err != nil {
+				t.Fatalf("Expected no errors, but received %s", err)
+			}
+			entry := waitFor(t, store, "key", tC.expectedState)
+			stop()
+
+			if entry.Attempts != tC.expectedAttempts {
+				t.Errorf("Expected %d attempts, Actual: %d", tC.expectedAttempts, entry.Attempts)
+			}
+			if entry.LastError != tC.expectedError {
+				t.Errorf("Expected %q, Actual: %q", tC.expectedError, entry.LastError)
+			}
+			if int(api.calls) != tC.expectedAttempts {
+				t.Errorf("Expected %d calls, Actual: %d", tC.expectedAttempts, api.calls)
+			}
+			if tC.expectedState == Sent {
+				if diff := cmp.Diff(succeedResults(), entry.Results); diff != "" {
+					t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+				}
+				if entry.SentAt == nil || entry.SentAt.IsZero() {
+					t.Error("Expected SentAt to be set")
+				}
+			}
+			var expectedDeadLettered []string
+			if tC.expectedState == Dead {
+				expectedDeadLettered = []string{"key"}
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			if diff := cmp.Diff(expectedDeadLettered, deadLettered); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func succeedResults() []*transactional.SendResult {
+	results, _ := succeed("", transactional.SmartEmailMessage{})
+	return results
+}
+
+func TestOutbox_Run_Workers(t *testing.T) {
+	const workers = 3
+	release := make(chan struct{})
+	api := &transactionalStub{send: func(id string, m transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+		<-release
+		return succeed(id, m)
+	}}
+	store := NewMemoryStore()
+	o := New(store, api, append(fastRetries(), WithWorkers(workers))...)
+	keys := []string{"a", "b", "c", "d", "e", "f", "g"}
+	for _, key := range keys {
+		if err := o.Enqueue(key, "smart-email-id", transactional.SmartEmailMessage{To: []string{"jane@example.com"}}); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+	}
+
+	stop := start(t, o)
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&api.inFlight) < workers && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(10 * time.Millisecond)
+	if inFlight := atomic.LoadInt32(&api.inFlight); inFlight != workers {
+		t.Errorf("Expected %d in-flight deliveries, Actual: %d", workers, inFlight)
+	}
+	close(release)
+	for _, key := range keys {
+		waitFor(t, store, key, Sent)
+	}
+	stop()
+
+	if concurrent := atomic.LoadInt32(&api.concurrent); concurrent != workers {
+		t.Errorf("Expected at most %d concurrent deliveries, Actual: %d", workers, concurrent)
+	}
+}
+
+func TestOutbox_Run_GracefulShutdown(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	api := &transactionalStub{send: func(id string, m transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+		close(started)
+		<-release
+		return succeed(id, m)
+	}}
+	store := NewMemoryStore()
+	o := New(store, api, fastRetries()...)
+	if err := o.Enqueue("key", "smart-email-id", transactional.SmartEmailMessage{To: []string{"jane@example.com"}}); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		o.Run(ctx)
+	}()
+	<-started
+	cancel()
+	select {
+	case <-done:
+		t.Fatal("Expected Run to wait for the in-flight deliveries")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	<-done
+
+	entry, _ := store.Get("key")
+	if entry.State != Sent {
+		t.Errorf("Expected %v, Actual: %v", Sent, entry.State)
+	}
+}
+
+func TestOutbox_Run_ResumesExpiredClaims(t *testing.T) {
+	store := NewMemoryStore()
+	entry := newEntry("key", epoch)
+	entry.State = Sending
+	entry.Attempts = 1
+	if err := store.Add(entry); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	api := &transactionalStub{send: succeed}
+	stop := start(t, New(store, api, fastRetries()...))
+	sent := waitFor(t, store, "key", Sent)
+	stop()
+	if sent.Attempts != 2 {
+		t.Errorf("Expected 2 attempts, Actual: %d", sent.Attempts)
+	}
+}
+
+func TestOutbox_Run_FindsInterruptedSends(t *testing.T) {
+	interruptedAt := epoch.Add(-time.Hour)
+	message := func(id, recipient string, sentAt time.Time) *transactional.Message {
+		return &transactional.Message{ID: id, Status: transactional.DeliveredMessage, SentAt: sentAt, Recipient: recipient}
+	}
+	firstPage := make([]*transactional.Message, timelinePageSize)
+	for i := range firstPage {
+		firstPage[i] = message("m1", "bob@example.com", epoch)
+	}
+	testCases := []struct {
+		title         string
+		pages         map[string][]*transactional.Message
+		timelineErr   error
+		expectedState State
+		expectedSends int32
+		expected      []*transactional.SendResult
+		expectedPages []string
+	}{
+		{
+			title: "already sent",
+			pages: map[string][]*transactional.Message{
+				"": firstPage,
+				"m1": {
+					message("m2", "JANE@example.com", interruptedAt.Add(time.Second)),
+					message("m3", "jane@example.com", interruptedAt.Add(-2*clockSkew)),
+				},
+			},
+			expectedState: Sent,
+			expected:      []*transactional.SendResult{{MessageID: "m2", Status: "delivered", Recipient: "JANE@example.com"}},
+			expectedPages: []string{"", "m1"},
+		},
+		{
+			title: "not sent",
+			pages: map[string][]*transactional.Message{
+				"": {message("m1", "bob@example.com", epoch), message("m3", "jane@example.com", interruptedAt.Add(-2*clockSkew))},
+			},
+			expectedState: Sent,
+			expectedSends: 1,
+			expected:      succeedResults(),
+			expectedPages: []string{""},
+		},
+		{
+			title:         "timeline failure",
+			timelineErr:   errors.New("connection reset"),
+			expectedState: Pending,
+			expectedPages: []string{""},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			store := NewMemoryStore()
+			entry := newEntry("key", epoch)
+			entry.State = Sending
+			entry.Attempts = 1
+			entry.ClaimedAt = interruptedAt
+			if err := store.Add(entry); err != nil {
+				t.Fatalf("Expected no errors, but received %s", err)
+			}
+			var mux sync.Mutex
+			var pages []string
+			api := &transactionalStub{
+				send: succeed,
+				timeline: func(options *transactional.Options) ([]*transactional.Message, error) {
+					mux.Lock()
+					defer mux.Unlock()
+					if options.SmartEmailID() != "smart-email-id" || options.ClientID() != "client-id" || options.Count() != timelinePageSize {
+						t.Errorf("Unexpected timeline options: %+v", options)
+					}
+					pages = append(pages, options.SentBeforeID())
+					return tC.pages[options.SentBeforeID()], tC.timelineErr
+				},
+			}
+			o := New(store, api, append(fastRetries(), WithClientID(" client-id "), WithBackoff(time.Hour, time.Hour))...)
+			stop := start(t, o)
+			actual := waitFor(t, store, "key", tC.expectedState)
+			stop()
+
+			if calls := atomic.LoadInt32(&api.calls); calls != tC.expectedSends {
+				t.Errorf("Expected %d sends, Actual: %d", tC.expectedSends, calls)
+			}
+			if diff := cmp.Diff(tC.expected, actual.Results); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+			mux.Lock()
+			defer mux.Unlock()
+			if diff := cmp.Diff(tC.expectedPages, pages); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOutbox_Run_DeadLettersExpiredLastAttempts(t *testing.T) {
+	store := NewMemoryStore()
+	entry := newEntry("key", epoch)
+	entry.State = Sending
+	entry.Attempts = 2
+	if err := store.Add(entry); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	api := &transactionalStub{send: succeed}
+	deadLettered := make(chan *Entry, 1)
+	stop := start(t, New(store, api, append(fastRetries(), WithMaxAttempts(2), WithDeadLetter(func(entry *Entry) {
+		deadLettered <- entry
+	}))...))
+	dead := <-deadLettered
+	stop()
+	if dead.Key != "key" || dead.State != Dead || dead.LastError != ErrClaimExpired.Error() {
+		t.Errorf("Unexpected entry: %+v", dead)
+	}
+	if calls := atomic.LoadInt32(&api.calls); calls != 0 {
+		t.Errorf("Expected no send attempts, Actual: %d", calls)
+	}
+}
+
+func TestOutbox_Run_StoreFailures(t *testing.T) {
+	failure := errors.New("disk full")
+
+	t.Run("claim", func(t *testing.T) {
+		store := &failingStore{Store: NewMemoryStore(), claimErr: failure}
+		reported := make(chan error, 1)
+		o := New(store, &transactionalStub{send: succeed}, append(fastRetries(), WithErrorHandler(func(entry *Entry, err error) {
+			if entry != nil {
+				t.Errorf("Expected a nil entry, Actual: %+v", entry)
+			}
+			select {
+			case reported <- err:
+			default:
+			}
+		}))...)
+		stop := start(t, o)
+		defer stop()
+		if err := <-reported; !errors.Is(err, failure) {
+			t.Errorf("Expected %v, Actual: %v", failure, err)
+		}
+	})
+
+	t.Run("update", func(t *testing.T) {
+		store := &failingStore{Store: NewMemoryStore(), updateErr: failure}
+		reported := make(chan *Entry, 1)
+		o := New(store, &transactionalStub{send: succeed}, append(fastRetries(), WithErrorHandler(func(entry *Entry, err error) {
+			if !errors.Is(err, failure) {
+				t.Errorf("Expected %v, Actual: %v", failure, err)
+			}
+			select {
+			case reported <- entry:
+			default:
+			}
+		}))...)
+		if err := o.Enqueue("key", "smart-email-id", transactional.SmartEmailMessage{To: []string{"jane@example.com"}}); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		stop := start(t, o)
+		defer stop()
+		if entry := <-reported; entry.Key != "key" || entry.State != Sent {
+			t.Errorf("Unexpected entry: %+v", entry)
+		}
+	})
+}
+
+func TestRetryDelay(t *testing.T) {
+	rateLimited := &createsend.Error{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{"30"}}}
+	testCases := []struct {
+		title    string
+		err      error
+		attempts int
+		expected time.Duration
+	}{
+		{title: "first attempt", err: errors.New("failed"), attempts: 1, expected: time.Second},
+		{title: "second attempt", err: errors.New("failed"), attempts: 2, expected: 2 * time.Second},
+		{title: "fourth attempt", err: errors.New("failed"), attempts: 4, expected: 8 * time.Second},
+		{title: "capped", err: errors.New("failed"), attempts: 40, expected: 10 * time.Second},
+		{title: "requested by the server", err: rateLimited, attempts: 1, expected: 30 * time.Second},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual := retryDelay(tC.err, time.Second, 10*time.Second, tC.attempts)
+			if actual != tC.expected {
+				t.Errorf("Expected %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNewOptions(t *testing.T) {
+	deadLetter := func(*Entry) {}
+	testCases := []struct {
+		title    string
+		options  []Option
+		expected options
+	}{
+		{
+			title: "defaults",
+			expected: options{
+				workers:      defaultWorkers,
+				maxAttempts:  defaultMaxAttempts,
+				backoff:      defaultBackoff,
+				maxBackoff:   defaultMaxBackoff,
+				pollInterval: defaultPollInterval,
+				lease:        defaultLease,
+			},
+		},
+		{
+			title: "custom",
+			options: []Option{
+				WithWorkers(10),
+				WithMaxAttempts(3),
+				WithBackoff(2*time.Second, time.Hour),
+				WithPollInterval(time.Minute),
+				WithLease(time.Hour),
+				WithClientID(" client-id "),
+				WithDeadLetter(deadLetter),
+			},
+			expected: options{
+				workers:      10,
+				maxAttempts:  3,
+				backoff:      2 * time.Second,
+				maxBackoff:   time.Hour,
+				pollInterval: time.Minute,
+				lease:        time.Hour,
+				clientID:     "client-id",
+			},
+		},
+		{
+			title: "invalid values",
+			options: []Option{
+				WithWorkers(0),
+				WithMaxAttempts(-1),
+				WithBackoff(time.Minute, time.Second),
+				WithPollInterval(0),
+				WithLease(-time.Second),
+				WithDeadLetter(nil),
+				WithErrorHandler(nil),
+			},
+			expected: options{
+				workers:      defaultWorkers,
+				maxAttempts:  defaultMaxAttempts,
+				backoff:      time.Minute,
+				maxBackoff:   defaultMaxBackoff,
+				pollInterval: defaultPollInterval,
+				lease:        defaultLease,
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual := newOptions(tC.options)
+			if actual.deadLetter == nil || actual.errorHandler == nil || actual.now == nil || actual.after == nil {
+				t.Fatal("Expected the functions to be set")
+			}
+			if diff := cmp.Diff(tC.expected, *actual, cmp.AllowUnexported(options{}), cmpopts.IgnoreFields(options{}, "deadLetter", "errorHandler", "now", "after")); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+package outbox
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrDuplicateKey occurs when an entry with the same idempotency key has already been added to the outbox.
+	ErrDuplicateKey = errors.New("an entry with the same key already exists")
+	// ErrNotFound occurs when the entry does not exist.
+	ErrNotFound = errors.New("the entry does not exist")
+	// ErrConflict occurs when the entry has been changed since it was read (eg. it has been claimed again
+	// by another worker after its claim expired).
+	ErrConflict = errors.New("the entry has been changed by someone else")
+)
+
+// Store represents the durable storage of the outbox entries.
+//
+// The implementations must be safe for concurrent use.
+type Store interface {
+	// Add adds a new entry, or returns ErrDuplicateKey if an entry with the same key already exists in any state.
+	Add(entry *Entry) error
+	// Claim changes the state of up to limit entries which are due for delivery at now to Sending,
+	// sets their ClaimedAt to now and their NextAttemptAt to the claim expiry time, increments their attempts
+	// and returns them.
+	//
+	// An entry is due if it is pending and its NextAttemptAt has passed, or if its claim has expired
+	// (eg. the process has crashed while sending it). If the claim has expired, InterruptedAt must be set
+	// to the previous ClaimedAt, unless it has already been set. The oldest entries must be claimed first.
+	// The version of the claimed entries must be incremented.
+	Claim(now time.Time, limit int, lease time.Duration) ([]*Entry, error)
+	// Update saves the entry and increments its version, or returns ErrNotFound if the entry does not exist.
+	//
+	// ErrConflict must be returned if the version of the entry does not match the stored version.
+	Update(entry *Entry) error
+	// Get returns the entry with the specified key, or ErrNotFound if the entry does not exist.
+	Get(key string) (*Entry, error)
+	// Purge deletes the sent and the dead entries which have been created before the specified time,
+	// and returns the number of the deleted entries.
+	//
+	// The keys of the deleted entries can be added to the outbox again.
+	Purge(before time.Time) (int, error)
+}
+
+// MemoryStore is a non-durable Store which keeps the entries in memory.
+type MemoryStore struct {
+	mux     sync.Mutex
+	entries map[string]*Entry
+	persist func(entry *Entry) error
+	remove  func(key string) error
+}
+
+// NewMemoryStore creates a new in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		entries: make(map[string]*Entry),
+		persist: func(*Entry) error { return nil },
+		remove:  func(string) error { return nil },
+	}
+}
+
+// Add adds a new entry, or returns ErrDuplicateKey if an entry with the same key already exists.
+func (m *MemoryStore) Add(entry *Entry) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, ok := m.entries[entry.Key]; ok {
+		return ErrDuplicateKey
+	}
+	return m.save(entry)
+}
+
+// Claim claims up to limit entries which are due for delivery.
+func (m *MemoryStore) Claim(now time.Time, limit int, lease time.Duration) ([]*Entry, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var due []*Entry
+	for _, entry := range m.entries {
+		if (entry.State == Pending || entry.State == Sending) && !entry.NextAttemptAt.After(now) {
+			due = append(due, entry)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].CreatedAt.Equal(due[j].CreatedAt) {
+			return due[i].CreatedAt.Before(due[j].CreatedAt)
+		}
+		return due[i].Key < due[j].Key
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]*Entry, 0, len(due))
+	for _, entry := range due {
+		c := entry.clone()
+		if entry.State == Sending && c.InterruptedAt == nil {
+			interruptedAt := entry.ClaimedAt
+			c.InterruptedAt = &interruptedAt
+		}
+		c.State = Sending
+		c.Attempts++
+		c.Version++
+		c.ClaimedAt = now
+		c.NextAttemptAt = now.Add(lease)
+		if err := m.save(c); err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, c.clone())
+	}
+	return claimed, nil
+}
+
+// Update saves the entry and increments its version, or returns ErrNotFound if the entry does not exist.
+//
+// ErrConflict is returned if the entry has been changed since it was read.
+func (m *MemoryStore) Update(entry *Entry) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	stored, ok := m.entries[entry.Key]
+	if !ok {
+		return ErrNotFound
+	}
+	if stored.Version != entry.Version {
+		return ErrConflict
+	}
+	c := entry.clone()
+	c.Version++
+	if err := m.save(c); err != nil {
+		return err
+	}
+	entry.Version = c.Version
+	return nil
+}
+
+// Get returns the entry with the specified key, or ErrNotFound if the entry does not exist.
+func (m *MemoryStore) Get(key string) (*Entry, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return entry.clone(), nil
+}
+
+// Purge deletes the sent and the dead entries which have been created before the specified time.
+func (m *MemoryStore) Purge(before time.Time) (int, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	var purged int
+	for key, entry := range m.entries {
+		if (entry.State != Sent && entry.State != Dead) || !entry.CreatedAt.Before(before) {
+			continue
+		}
+		if err := m.remove(key); err != nil {
+			return purged, err
+		}
+		delete(m.entries, key)
+		purged++
+	}
+	return purged, nil
+}
+
+// save persists a copy of the entry, and keeps it in memory if it has been persisted successfully.
+func (m *MemoryStore) save(entry *Entry) error {
+	c := entry.clone()
+	if err := m.persist(c); err != nil {
+		return err
+	}
+	m.entries[c.Key] = c
+	return nil
+}
@@ -0,0 +1,353 @@
+package outbox
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+var epoch = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newEntry(key string, createdAt time.Time) *Entry {
+	return &Entry{
+		Key:          key,
+		SmartEmailID: "smart-email-id",
+		Message: transactional.SmartEmailMessage{
+			To:   []string{"Jane <jane@example.com>"},
+			Data: map[string]interface{}{"name": "Jane"},
+		},
+		State:         Pending,
+		CreatedAt:     createdAt,
+		NextAttemptAt: createdAt,
+	}
+}
+
+func keys(entries []*Entry) []string {
+	result := make([]string, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Key
+	}
+	return result
+}
+
+// testStore runs the tests every Store implementation must pass. The open function must return a new, empty store.
+func testStore(t *testing.T, open func(t *testing.T) Store) {
+	t.Run("add and get", func(t *testing.T) {
+		store := open(t)
+		entry := newEntry("key", epoch)
+		if err := store.Add(entry); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		entry.State = Dead
+		actual, err := store.Get("key")
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if diff := cmp.Diff(newEntry("key", epoch), actual); diff != "" {
+			t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+		}
+	})
+
+	t.Run("get non-existent entry", func(t *testing.T) {
+		_, err := open(t).Get("key")
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected %v, Actual: %v", ErrNotFound, err)
+		}
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		store := open(t)
+		if err := store.Add(newEntry("key", epoch)); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		sent := newEntry("key", epoch)
+		sent.State = Sent
+		if err := store.Update(sent); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		err := store.Add(newEntry("key", epoch.Add(time.Hour)))
+		if !errors.Is(err, ErrDuplicateKey) {
+			t.Errorf("Expected %v, Actual: %v", ErrDuplicateKey, err)
+		}
+	})
+
+	t.Run("update non-existent entry", func(t *testing.T) {
+		err := open(t).Update(newEntry("key", epoch))
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected %v, Actual: %v", ErrNotFound, err)
+		}
+	})
+
+	t.Run("claim", func(t *testing.T) {
+		store := open(t)
+		future := newEntry("future", epoch)
+		future.NextAttemptAt = epoch.Add(time.Minute)
+		sent := newEntry("sent", epoch)
+		sent.State = Sent
+		dead := newEntry("dead", epoch)
+		dead.State = Dead
+		expired := newEntry("expired", epoch.Add(2*time.Second))
+		expired.State = Sending
+		expired.Attempts = 1
+		expired.ClaimedAt = epoch
+		leased := newEntry("leased", epoch)
+		leased.State = Sending
+		leased.NextAttemptAt = epoch.Add(time.Minute)
+		for _, entry := range []*Entry{
+			newEntry("third", epoch.Add(time.Second)),
+			newEntry("second", epoch),
+			newEntry("first", epoch),
+			future,
+			sent,
+			dead,
+			expired,
+			leased,
+		} {
+			if err := store.Add(entry); err != nil {
+				t.Fatalf("Expected no errors, but received %s", err)
+			}
+		}
+
+		now := epoch.Add(10 * time.Second)
+		claimed, err := store.Claim(now, 3, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if diff := cmp.Diff([]string{"first", "second", "third"}, keys(claimed)); diff != "" {
+			t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+		}
+		for _, entry := range claimed {
+			if entry.State != Sending || entry.Attempts != 1 || !entry.ClaimedAt.Equal(now) || !entry.NextAttemptAt.Equal(now.Add(time.Minute)) || entry.InterruptedAt != nil {
+				t.Errorf("Expected %s to be claimed, Actual: %+v", entry.Key, entry)
+			}
+			stored, _ := store.Get(entry.Key)
+			if diff := cmp.Diff(entry, stored); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		}
+
+		claimed, err = store.Claim(now, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if diff := cmp.Diff([]string{"expired"}, keys(claimed)); diff != "" {
+			t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+		}
+		if claimed[0].Attempts != 2 {
+			t.Errorf("Expected 2 attempts, Actual: %d", claimed[0].Attempts)
+		}
+		if claimed[0].InterruptedAt == nil || !claimed[0].InterruptedAt.Equal(epoch) {
+			t.Errorf("Expected the entry to be interrupted at %v, Actual: %v", epoch, claimed[0].InterruptedAt)
+		}
+
+		claimed, err = store.Claim(now, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if len(claimed) != 0 {
+			t.Errorf("Expected no claimed entries, Actual: %v", keys(claimed))
+		}
+	})
+
+	t.Run("stale update", func(t *testing.T) {
+		store := open(t)
+		if err := store.Add(newEntry("key", epoch)); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		first, err := store.Claim(epoch, 1, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		second, err := store.Claim(epoch.Add(2*time.Minute), 1, time.Minute)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if len(first) != 1 || len(second) != 1 {
+			t.Fatalf("Expected the entry to be claimed twice, Actual: %v, %v", keys(first), keys(second))
+		}
+
+		second[0].State = Sent
+		if err := store.Update(second[0]); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		first[0].State = Dead
+		if err := store.Update(first[0]); !errors.Is(err, ErrConflict) {
+			t.Errorf("Expected %v, Actual: %v", ErrConflict, err)
+		}
+		stored, _ := store.Get("key")
+		if stored.State != Sent || stored.Version != 3 {
+			t.Errorf("Expected the entry to be sent at version 3, Actual: %v at version %d", stored.State, stored.Version)
+		}
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		store := open(t)
+		old := epoch.Add(-time.Hour)
+		sent := newEntry("sent", old)
+		sent.State = Sent
+		dead := newEntry("dead", old)
+		dead.State = Dead
+		sending := newEntry("sending", old)
+		sending.State = Sending
+		recent := newEntry("recent", epoch)
+		recent.State = Sent
+		for _, entry := range []*Entry{sent, dead, sending, newEntry("pending", old), recent} {
+			if err := store.Add(entry); err != nil {
+				t.Fatalf("Expected no errors, but received %s", err)
+			}
+		}
+
+		purged, err := store.Purge(epoch)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if purged != 2 {
+			t.Errorf("Expected 2 purged entries, Actual: %d", purged)
+		}
+		for _, key := range []string{"sent", "dead"} {
+			if _, err := store.Get(key); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Expected %s to be purged, Actual: %v", key, err)
+			}
+		}
+		for _, key := range []string{"sending", "pending", "recent"} {
+			if _, err := store.Get(key); err != nil {
+				t.Errorf("Expected %s to be kept, Actual: %v", key, err)
+			}
+		}
+		if err := store.Add(newEntry("sent", epoch)); err != nil {
+			t.Errorf("Expected the purged key to be added again, Actual: %v", err)
+		}
+	})
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, func(*testing.T) Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestMemoryStore_PersistFailure(t *testing.T) {
+	store := NewMemoryStore()
+	if err := store.Add(newEntry("first", epoch)); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if err := store.Add(newEntry("second", epoch.Add(time.Second))); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	failure := errors.New("disk full")
+	store.persist = func(entry *Entry) error {
+		if entry.Key == "second" {
+			return failure
+		}
+		return nil
+	}
+
+	claimed, err := store.Claim(epoch.Add(time.Minute), 10, time.Minute)
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected %v, Actual: %v", failure, err)
+	}
+	if diff := cmp.Diff([]string{"first"}, keys(claimed)); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+	second, _ := store.Get("second")
+	if second.State != Pending {
+		t.Errorf("Expected the entry not to be changed, Actual: %v", second.State)
+	}
+	if err := store.Add(newEntry("second", epoch)); !errors.Is(err, ErrDuplicateKey) {
+		t.Errorf("Expected %v, Actual: %v", ErrDuplicateKey, err)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	testStore(t, func(t *testing.T) Store {
+		store, err := OpenFileStore(filepath.Join(t.TempDir(), "outbox"))
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		return store
+	})
+}
+
+func TestFileStore_Reopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	for _, key := range []string{"first", "second", "../third"} {
+		if err := store.Add(newEntry(key, epoch)); err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+	}
+	sent := newEntry("second", epoch)
+	sent.State = Sent
+	sent.Attempts = 1
+	sentAt := epoch.Add(time.Second)
+	sent.SentAt = &sentAt
+	sent.Results = []*transactional.SendResult{{MessageID: "message-id", Status: "Accepted", Recipient: "jane@example.com"}}
+	if err := store.Update(sent); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "entry-123.tmp"), []byte("{"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := OpenFileStore(dir)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	for _, expected := range []*Entry{newEntry("first", epoch), sent, newEntry("../third", epoch)} {
+		actual, err := reopened.Get(expected.Key)
+		if err != nil {
+			t.Fatalf("Expected no errors, but received %s", err)
+		}
+		if diff := cmp.Diff(expected, actual); diff != "" {
+			t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Errorf("Expected 3 entry files, Actual: %d", len(files))
+	}
+	if temp, _ := filepath.Glob(filepath.Join(dir, "*.tmp")); len(temp) != 0 {
+		t.Errorf("Expected the temporary files to be deleted, Actual: %v", temp)
+	}
+
+	if _, err := reopened.Purge(epoch.Add(time.Second)); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if files, _ := filepath.Glob(filepath.Join(dir, "*.json")); len(files) != 2 {
+		t.Errorf("Expected 2 entry files after purging, Actual: %d", len(files))
+	}
+}
+
+func TestOpenFileStore_Errors(t *testing.T) {
+	t.Run("invalid entry", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "entry.json"), []byte("{"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenFileStore(dir); err == nil {
+			t.Error("Expected an error, but received nil")
+		}
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		if err := os.WriteFile(file, nil, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := OpenFileStore(file); err == nil {
+			t.Error("Expected an error, but received nil")
+		}
+	})
+}
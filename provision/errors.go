@@ -0,0 +1,54 @@
+package provision
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrAmbiguousBilling occurs when both the monthly and the PAYG billing rates have been specified.
+	ErrAmbiguousBilling = errors.New("monthly and PAYG billing rates are mutually exclusive")
+	// ErrUnknownPrimaryContact occurs when the primary contact is not one of the people in the spec.
+	ErrUnknownPrimaryContact = errors.New("the primary contact must be one of the people in the spec")
+)
+
+// Error represents a provisioning failure.
+//
+// The underlying API error can be retrieved using errors.Is and errors.As.
+type Error struct {
+	// Step the failed step.
+	Step Step
+	// EmailAddress the email address of the person the failed step has been executed for, if any.
+	EmailAddress string
+	// ClientID the ID of the partially provisioned client, or an empty string if the client has not been created.
+	ClientID string
+	// RolledBack is true if the partially provisioned client has been deleted.
+	RolledBack bool
+	// RollbackErr the error occurred while rolling back, if any.
+	RollbackErr error
+	// Err the error returned by the failed step.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *Error) Error() string {
+	step := e.Step.String()
+	if e.EmailAddress != "" {
+		step = fmt.Sprintf("%s %s", step, e.EmailAddress)
+	}
+	msg := fmt.Sprintf("failed to %s: %v", step, e.Err)
+	switch {
+	case e.RollbackErr != nil:
+		msg += fmt.Sprintf("; failed to delete client %s: %v", e.ClientID, e.RollbackErr)
+	case e.RolledBack:
+		msg += fmt.Sprintf("; client %s has been deleted", e.ClientID)
+	case e.ClientID != "":
+		msg += fmt.Sprintf("; client %s has been partially provisioned", e.ClientID)
+	}
+	return msg
+}
+
+// Unwrap returns the error returned by the failed step.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,20 @@
+package provision
+
+// Option represents a provisioner option.
+type Option func(p *Provisioner)
+
+// WithProgress sets the function which gets called after each step, including the rollback.
+func WithProgress(progress func(Progress)) Option {
+	return func(p *Provisioner) {
+		if progress != nil {
+			p.progress = progress
+		}
+	}
+}
+
+// WithKeepPartial keeps the partially provisioned clients on failure instead of deleting them.
+func WithKeepPartial(enabled bool) Option {
+	return func(p *Provisioner) {
+		p.keepPartial = enabled
+	}
+}
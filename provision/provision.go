@@ -0,0 +1,123 @@
+// Package provision provisions new clients from a declarative spec, and rolls back partially provisioned clients on failure.
+package provision
+
+import (
+	"github.com/xitonix/createsend/clients"
+)
+
+// Provisioner creates and configures new clients.
+type Provisioner struct {
+	api         clients.API
+	progress    func(Progress)
+	keepPartial bool
+}
+
+// New creates a new provisioner.
+func New(api clients.API, options ...Option) *Provisioner {
+	p := &Provisioner{
+		api:      api,
+		progress: func(Progress) {},
+	}
+	for _, op := range options {
+		op(p)
+	}
+	return p
+}
+
+// Provision creates the client, sets the billing rates, adds the people, sets the primary contact
+// and suppresses the email addresses, in that order.
+//
+// If any of the steps fails, the client is deleted unless the provisioner has been created using WithKeepPartial,
+// and an *Error is returned. The ID of the new client is returned on success.
+func (p *Provisioner) Provision(spec ClientSpec) (string, error) {
+	if err := spec.validate(); err != nil {
+		return "", err
+	}
+
+	run := &run{Provisioner: p, total: spec.steps()}
+	clientID, err := p.api.Create(spec.Details)
+	if !run.report(CreateClient, "", err) {
+		return "", run.fail(CreateClient, "", err)
+	}
+	run.clientID = clientID
+
+	if spec.Monthly != nil || spec.PAYG != nil {
+		if spec.Monthly != nil {
+			err = p.api.SetMonthlyBilling(clientID, *spec.Monthly)
+		} else {
+			err = p.api.SetPAYGBilling(clientID, *spec.PAYG)
+		}
+		if !run.report(SetBilling, "", err) {
+			return "", run.fail(SetBilling, "", err)
+		}
+	}
+
+	for _, person := range spec.People {
+		_, err = p.api.AddPerson(clientID, person)
+		if !run.report(AddPerson, person.EmailAddress, err) {
+			return "", run.fail(AddPerson, person.EmailAddress, err)
+		}
+	}
+
+	if spec.PrimaryContact != "" {
+		_, err = p.api.SetPrimaryContact(clientID, spec.PrimaryContact)
+		if !run.report(SetPrimaryContact, spec.PrimaryContact, err) {
+			return "", run.fail(SetPrimaryContact, spec.PrimaryContact, err)
+		}
+	}
+
+	for start := 0; start < len(spec.Suppressions); start += suppressBatchSize {
+		end := min(start+suppressBatchSize, len(spec.Suppressions))
+		err = p.api.Suppress(clientID, spec.Suppressions[start:end]...)
+		if !run.report(Suppress, "", err) {
+			return "", run.fail(Suppress, "", err)
+		}
+	}
+
+	return clientID, nil
+}
+
+// run keeps track of a single provisioning run.
+type run struct {
+	*Provisioner
+	clientID string
+	number   int
+	total    int
+}
+
+// report reports the outcome of a step and returns true if the step has succeeded.
+func (r *run) report(step Step, email string, err error) bool {
+	r.number++
+	r.progress(Progress{
+		Step:         step,
+		Number:       r.number,
+		Total:        r.total,
+		ClientID:     r.clientID,
+		EmailAddress: email,
+		Err:          err,
+	})
+	return err == nil
+}
+
+// fail rolls back the partially provisioned client if required, and returns the provisioning error.
+func (r *run) fail(step Step, email string, err error) error {
+	pErr := &Error{
+		Step:         step,
+		EmailAddress: email,
+		ClientID:     r.clientID,
+		Err:          err,
+	}
+	if r.clientID == "" || r.keepPartial {
+		return pErr
+	}
+
+	pErr.RollbackErr = r.api.Delete(r.clientID)
+	pErr.RolledBack = pErr.RollbackErr == nil
+	r.progress(Progress{
+		Step:     Rollback,
+		Total:    r.total,
+		ClientID: r.clientID,
+		Err:      pErr.RollbackErr,
+	})
+	return pErr
+}
@@ -0,0 +1,331 @@
+package provision
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/money"
+)
+
+var errAPI = errors.New("api error")
+
+// clientsAPIStub records the provisioning calls and fails the specified call.
+type clientsAPIStub struct {
+	clients.API
+	calls     []string
+	failOn    string
+	deleteErr error
+}
+
+func (c *clientsAPIStub) call(name string) error {
+	c.calls = append(c.calls, name)
+	if c.failOn == name {
+		return errAPI
+	}
+	return nil
+}
+
+func (c *clientsAPIStub) Create(details clients.BasicDetails) (string, error) {
+	if err := c.call("Create " + details.Company); err != nil {
+		return "", err
+	}
+	return "client_id", nil
+}
+
+func (c *clientsAPIStub) SetMonthlyBilling(clientID string, _ clients.MonthlyRates) error {
+	return c.call("SetMonthlyBilling " + clientID)
+}
+
+func (c *clientsAPIStub) SetPAYGBilling(clientID string, _ clients.PAYGRates) error {
+	return c.call("SetPAYGBilling " + clientID)
+}
+
+func (c *clientsAPIStub) AddPerson(clientID string, person clients.Person) (string, error) {
+	return person.EmailAddress, c.call("AddPerson " + person.EmailAddress)
+}
+
+func (c *clientsAPIStub) SetPrimaryContact(clientID string, emailAddress string) (string, error) {
+	return emailAddress, c.call("SetPrimaryContact " + emailAddress)
+}
+
+func (c *clientsAPIStub) Suppress(clientID string, emails ...string) error {
+	return c.call(fmt.Sprintf("Suppress %s %d", clientID, len(emails)))
+}
+
+func (c *clientsAPIStub) Delete(clientID string) error {
+	c.calls = append(c.calls, "Delete "+clientID)
+	return c.deleteErr
+}
+
+func person(email string) clients.Person {
+	return clients.Person{PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: email, Name: "name", AccessLevel: clients.FullAccess}}
+}
+
+func TestProvisioner_Provision(t *testing.T) {
+	fullSpec := ClientSpec{
+		Details:        clients.BasicDetails{Company: "company"},
+		PAYG:           &clients.PAYGRates{Currency: money.USD},
+		People:         []clients.Person{person("a@b.com"), person("c@d.com")},
+		PrimaryContact: "C@D.com",
+	}
+	testCases := []struct {
+		title             string
+		spec              ClientSpec
+		failOn            string
+		deleteErr         error
+		keepPartial       bool
+		expectedID        string
+		expectedCalls     []string
+		expectedError     error
+		expectedErrorText string
+		expectedProgress  []string
+	}{
+		{
+			title:      "full spec",
+			spec:       fullSpec,
+			expectedID: "client_id",
+			expectedCalls: []string{
+				"Create company",
+				"SetPAYGBilling client_id",
+				"AddPerson a@b.com",
+				"AddPerson c@d.com",
+				"SetPrimaryContact C@D.com",
+			},
+			expectedProgress: []string{
+				"1/5 create client  <nil>",
+				"2/5 set billing client_id <nil>",
+				"3/5 add person client_id a@b.com <nil>",
+				"4/5 add person client_id c@d.com <nil>",
+				"5/5 set primary contact client_id C@D.com <nil>",
+			},
+		},
+		{
+			title: "monthly billing",
+			spec: ClientSpec{
+				Details: clients.BasicDetails{Company: "company"},
+				Monthly: &clients.MonthlyRates{Currency: money.AUD},
+			},
+			expectedID:       "client_id",
+			expectedCalls:    []string{"Create company", "SetMonthlyBilling client_id"},
+			expectedProgress: []string{"1/2 create client  <nil>", "2/2 set billing client_id <nil>"},
+		},
+		{
+			title: "suppressions",
+			spec: ClientSpec{
+				Details:      clients.BasicDetails{Company: "company"},
+				Suppressions: suppressions(101),
+			},
+			expectedID:    "client_id",
+			expectedCalls: []string{"Create company", "Suppress client_id 100", "Suppress client_id 1"},
+			expectedProgress: []string{
+				"1/3 create client  <nil>",
+				"2/3 suppress client_id <nil>",
+				"3/3 suppress client_id <nil>",
+			},
+		},
+		{
+			title: "suppression failure",
+			spec: ClientSpec{
+				Details:      clients.BasicDetails{Company: "company"},
+				Suppressions: suppressions(101),
+			},
+			failOn:            "Suppress client_id 1",
+			expectedCalls:     []string{"Create company", "Suppress client_id 100", "Suppress client_id 1", "Delete client_id"},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to suppress: api error; client client_id has been deleted",
+			expectedProgress: []string{
+				"1/3 create client  <nil>",
+				"2/3 suppress client_id <nil>",
+				"3/3 suppress client_id api error",
+				"0/3 rollback client_id <nil>",
+			},
+		},
+		{
+			title:            "create only",
+			spec:             ClientSpec{Details: clients.BasicDetails{Company: "company"}},
+			expectedID:       "client_id",
+			expectedCalls:    []string{"Create company"},
+			expectedProgress: []string{"1/1 create client  <nil>"},
+		},
+		{
+			title: "ambiguous billing",
+			spec: ClientSpec{
+				Monthly: &clients.MonthlyRates{},
+				PAYG:    &clients.PAYGRates{},
+			},
+			expectedError:     ErrAmbiguousBilling,
+			expectedErrorText: ErrAmbiguousBilling.Error(),
+		},
+		{
+			title: "unknown primary contact",
+			spec: ClientSpec{
+				People:         []clients.Person{person("a@b.com")},
+				PrimaryContact: "c@d.com",
+			},
+			expectedError:     ErrUnknownPrimaryContact,
+			expectedErrorText: ErrUnknownPrimaryContact.Error(),
+		},
+		{
+			title:             "create failure",
+			spec:              fullSpec,
+			failOn:            "Create company",
+			expectedCalls:     []string{"Create company"},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to create client: api error",
+			expectedProgress:  []string{"1/5 create client  api error"},
+		},
+		{
+			title:             "billing failure",
+			spec:              fullSpec,
+			failOn:            "SetPAYGBilling client_id",
+			expectedCalls:     []string{"Create company", "SetPAYGBilling client_id", "Delete client_id"},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to set billing: api error; client client_id has been deleted",
+			expectedProgress: []string{
+				"1/5 create client  <nil>",
+				"2/5 set billing client_id api error",
+				"0/5 rollback client_id <nil>",
+			},
+		},
+		{
+			title:  "add person failure",
+			spec:   fullSpec,
+			failOn: "AddPerson c@d.com",
+			expectedCalls: []string{
+				"Create company",
+				"SetPAYGBilling client_id",
+				"AddPerson a@b.com",
+				"AddPerson c@d.com",
+				"Delete client_id",
+			},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to add person c@d.com: api error; client client_id has been deleted",
+			expectedProgress: []string{
+				"1/5 create client  <nil>",
+				"2/5 set billing client_id <nil>",
+				"3/5 add person client_id a@b.com <nil>",
+				"4/5 add person client_id c@d.com api error",
+				"0/5 rollback client_id <nil>",
+			},
+		},
+		{
+			title:       "primary contact failure with partial state kept",
+			spec:        fullSpec,
+			failOn:      "SetPrimaryContact C@D.com",
+			keepPartial: true,
+			expectedCalls: []string{
+				"Create company",
+				"SetPAYGBilling client_id",
+				"AddPerson a@b.com",
+				"AddPerson c@d.com",
+				"SetPrimaryContact C@D.com",
+			},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to set primary contact C@D.com: api error; client client_id has been partially provisioned",
+			expectedProgress: []string{
+				"1/5 create client  <nil>",
+				"2/5 set billing client_id <nil>",
+				"3/5 add person client_id a@b.com <nil>",
+				"4/5 add person client_id c@d.com <nil>",
+				"5/5 set primary contact client_id C@D.com api error",
+			},
+		},
+		{
+			title:             "rollback failure",
+			spec:              fullSpec,
+			failOn:            "AddPerson a@b.com",
+			deleteErr:         errors.New("delete error"),
+			expectedCalls:     []string{"Create company", "SetPAYGBilling client_id", "AddPerson a@b.com", "Delete client_id"},
+			expectedError:     errAPI,
+			expectedErrorText: "failed to add person a@b.com: api error; failed to delete client client_id: delete error",
+			expectedProgress: []string{
+				"1/5 create client  <nil>",
+				"2/5 set billing client_id <nil>",
+				"3/5 add person client_id a@b.com api error",
+				"0/5 rollback client_id delete error",
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			api := &clientsAPIStub{failOn: tC.failOn, deleteErr: tC.deleteErr}
+			var progress []string
+			p := New(api,
+				WithKeepPartial(tC.keepPartial),
+				WithProgress(func(p Progress) {
+					progress = append(progress, fmt.Sprintf("%d/%d %s %s %s",
+						p.Number, p.Total, p.Step, p.ClientID, strings.TrimSpace(p.EmailAddress+" "+errorString(p.Err))))
+				}))
+
+			actual, err := p.Provision(tC.spec)
+			if !errors.Is(err, tC.expectedError) {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if err != nil && err.Error() != tC.expectedErrorText {
+				t.Errorf("Expected error message: %q, Actual: %q", tC.expectedErrorText, err.Error())
+			}
+			if actual != tC.expectedID {
+				t.Errorf("Expected client ID: %q, Actual: %q", tC.expectedID, actual)
+			}
+			if diff := cmp.Diff(tC.expectedCalls, api.calls); diff != "" {
+				t.Errorf("Calls mismatch (-expected +actual):\n%s", diff)
+			}
+			if diff := cmp.Diff(tC.expectedProgress, progress); diff != "" {
+				t.Errorf("Progress mismatch (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProvisioner_Error(t *testing.T) {
+	api := &clientsAPIStub{failOn: "SetMonthlyBilling client_id"}
+	_, err := New(api, WithProgress(nil)).Provision(ClientSpec{Monthly: &clients.MonthlyRates{}})
+
+	var pErr *Error
+	if !errors.As(err, &pErr) {
+		t.Fatalf("Expected a *provision.Error, Actual: %T", err)
+	}
+	if pErr.Step != SetBilling || pErr.ClientID != "client_id" || !pErr.RolledBack || pErr.RollbackErr != nil || pErr.Err != errAPI {
+		t.Errorf("Unexpected error: %+v", pErr)
+	}
+}
+
+func TestStep_String(t *testing.T) {
+	testCases := []struct {
+		step     Step
+		expected string
+	}{
+		{CreateClient, "create client"},
+		{SetBilling, "set billing"},
+		{AddPerson, "add person"},
+		{SetPrimaryContact, "set primary contact"},
+		{Suppress, "suppress"},
+		{Rollback, "rollback"},
+		{0, "unknown"},
+	}
+	for _, tC := range testCases {
+		if actual := tC.step.String(); actual != tC.expected {
+			t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+		}
+	}
+}
+
+func suppressions(count int) []string {
+	emails := make([]string, count)
+	for i := range emails {
+		emails[i] = fmt.Sprintf("%d@x.com", i)
+	}
+	return emails
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+	return err.Error()
+}
@@ -0,0 +1,58 @@
+package provision
+
+import (
+	"strings"
+
+	"github.com/xitonix/createsend/clients"
+)
+
+// ClientSpec declares the desired state of a new client.
+type ClientSpec struct {
+	// Details the client's basic details.
+	Details clients.BasicDetails
+	// Monthly the monthly billing rates. Monthly and PAYG are mutually exclusive.
+	Monthly *clients.MonthlyRates
+	// PAYG the pay-as-you-go billing rates. Monthly and PAYG are mutually exclusive.
+	PAYG *clients.PAYGRates
+	// People the people to add to the client.
+	People []clients.Person
+	// PrimaryContact the email address of the person to set as the primary contact (optional).
+	//
+	// The primary contact must be one of the people listed in the spec.
+	PrimaryContact string
+	// Suppressions the email addresses to add to the client's suppression list (optional).
+	//
+	// The addresses are suppressed in batches of up to 100 addresses.
+	Suppressions []string
+}
+
+// suppressBatchSize the maximum number of email addresses suppressed per request.
+const suppressBatchSize = 100
+
+func (s ClientSpec) validate() error {
+	if s.Monthly != nil && s.PAYG != nil {
+		return ErrAmbiguousBilling
+	}
+	if s.PrimaryContact == "" {
+		return nil
+	}
+	for _, person := range s.People {
+		if strings.EqualFold(person.EmailAddress, s.PrimaryContact) {
+			return nil
+		}
+	}
+	return ErrUnknownPrimaryContact
+}
+
+// steps returns the number of steps required to provision the client, excluding the rollback.
+func (s ClientSpec) steps() int {
+	steps := 1 + len(s.People)
+	if s.Monthly != nil || s.PAYG != nil {
+		steps++
+	}
+	if s.PrimaryContact != "" {
+		steps++
+	}
+	steps += (len(s.Suppressions) + suppressBatchSize - 1) / suppressBatchSize
+	return steps
+}
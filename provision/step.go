@@ -0,0 +1,55 @@
+package provision
+
+// Step represents a provisioning step.
+type Step int8
+
+const (
+	// CreateClient creates the client.
+	CreateClient Step = iota + 1
+	// SetBilling sets the client's monthly or PAYG billing rates.
+	SetBilling
+	// AddPerson adds a person to the client.
+	AddPerson
+	// SetPrimaryContact sets the client's primary contact.
+	SetPrimaryContact
+	// Suppress adds a batch of email addresses to the client's suppression list.
+	Suppress
+	// Rollback deletes the partially provisioned client.
+	Rollback
+)
+
+// String Stringer implementation
+func (s Step) String() string {
+	switch s {
+	case CreateClient:
+		return "create client"
+	case SetBilling:
+		return "set billing"
+	case AddPerson:
+		return "add person"
+	case SetPrimaryContact:
+		return "set primary contact"
+	case Suppress:
+		return "suppress"
+	case Rollback:
+		return "rollback"
+	default:
+		return "unknown"
+	}
+}
+
+// Progress represents the outcome of a provisioning step.
+type Progress struct {
+	// Step the step which has been executed.
+	Step Step
+	// Number the sequence number of the step starting from 1, or 0 for the rollback.
+	Number int
+	// Total the total number of the steps, excluding the rollback.
+	Total int
+	// ClientID the ID of the client, or an empty string if the client has not been created.
+	ClientID string
+	// EmailAddress the email address of the person the step has been executed for, if any.
+	EmailAddress string
+	// Err the step error, or nil if the step has succeeded.
+	Err error
+}
@@ -0,0 +1,134 @@
+package reconcile
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xitonix/createsend/clients"
+)
+
+// ActionType represents the type of a reconciliation action.
+type ActionType int8
+
+const (
+	// AddAdministrator adds a new account administrator.
+	AddAdministrator ActionType = iota + 1
+	// UpdateAdministrator updates the name of an account administrator.
+	UpdateAdministrator
+	// DeleteAdministrator deletes an account administrator.
+	DeleteAdministrator
+	// SetAccountPrimaryContact sets the account primary contact.
+	SetAccountPrimaryContact
+	// AddPerson adds a new person to a client.
+	AddPerson
+	// UpdatePerson updates the name and/or the access level of a client person.
+	UpdatePerson
+	// DeletePerson deletes a client person.
+	DeletePerson
+	// SetPrimaryContact sets the primary contact of a client.
+	SetPrimaryContact
+)
+
+// String Stringer implementation
+func (t ActionType) String() string {
+	switch t {
+	case AddAdministrator:
+		return "add administrator"
+	case UpdateAdministrator:
+		return "update administrator"
+	case DeleteAdministrator:
+		return "delete administrator"
+	case SetAccountPrimaryContact:
+		return "set account primary contact"
+	case AddPerson:
+		return "add person"
+	case UpdatePerson:
+		return "update person"
+	case DeletePerson:
+		return "delete person"
+	case SetPrimaryContact:
+		return "set primary contact"
+	default:
+		return "unknown"
+	}
+}
+
+// Action represents a single change required to reconcile the current state with the desired state.
+type Action struct {
+	// Type the action type.
+	Type ActionType
+	// ClientID the ID of the client, or an empty string for the account level actions.
+	ClientID string
+	// EmailAddress the email address of the administrator or the person the action applies to.
+	EmailAddress string
+	// Name the desired name.
+	Name string
+	// AccessLevel the desired access level of the person.
+	AccessLevel clients.AccessLevel
+	// CurrentName the current name, for the update actions.
+	CurrentName string
+	// CurrentAccessLevel the current access level of the person, for the update actions.
+	CurrentAccessLevel clients.AccessLevel
+	// CurrentPrimaryContact the email address of the current primary contact, for the primary contact actions.
+	CurrentPrimaryContact string
+}
+
+// String returns the human-readable representation of the action.
+func (a *Action) String() string {
+	scope := "account"
+	if a.ClientID != "" {
+		scope = "client " + a.ClientID
+	}
+	switch a.Type {
+	case AddAdministrator:
+		return fmt.Sprintf("+ %s: add administrator %s (%s)", scope, a.EmailAddress, a.Name)
+	case AddPerson:
+		return fmt.Sprintf("+ %s: add person %s (%s, access: %s)", scope, a.EmailAddress, a.Name, a.AccessLevel)
+	case UpdateAdministrator, UpdatePerson:
+		changes := make([]string, 0, 2)
+		if a.CurrentName != a.Name {
+			changes = append(changes, fmt.Sprintf("name %q => %q", a.CurrentName, a.Name))
+		}
+		if a.Type == UpdatePerson && a.CurrentAccessLevel != a.AccessLevel {
+			changes = append(changes, fmt.Sprintf("access %q => %q", a.CurrentAccessLevel, a.AccessLevel))
+		}
+		return fmt.Sprintf("~ %s: %s %s: %s", scope, a.Type, a.EmailAddress, strings.Join(changes, ", "))
+	case DeleteAdministrator, DeletePerson:
+		return fmt.Sprintf("- %s: %s %s", scope, a.Type, a.EmailAddress)
+	case SetAccountPrimaryContact, SetPrimaryContact:
+		current := a.CurrentPrimaryContact
+		if current == "" {
+			current = "none"
+		}
+		return fmt.Sprintf("~ %s: primary contact %s => %s", scope, current, a.EmailAddress)
+	default:
+		return fmt.Sprintf("? %s: %s %s", scope, a.Type, a.EmailAddress)
+	}
+}
+
+// Plan represents the ordered list of the actions required to reconcile the current state with the desired state.
+type Plan struct {
+	// Actions the actions in the order they will be applied.
+	Actions []*Action
+}
+
+// IsEmpty returns true if the current state matches the desired state.
+func (p *Plan) IsEmpty() bool {
+	return len(p.Actions) == 0
+}
+
+// String returns the human-readable representation of the plan, one action per line.
+func (p *Plan) String() string {
+	if p.IsEmpty() {
+		return "No changes."
+	}
+	lines := make([]string, len(p.Actions))
+	for i, action := range p.Actions {
+		lines[i] = action.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (p *Plan) add(action *Action) {
+	p.Actions = append(p.Actions, action)
+}
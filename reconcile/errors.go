@@ -0,0 +1,55 @@
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrDuplicateEmailAddress occurs when an email address is listed more than once within the same scope.
+	ErrDuplicateEmailAddress = errors.New("duplicate email address")
+	// ErrUnknownPrimaryContact occurs when the desired primary contact is not one of the desired members.
+	ErrUnknownPrimaryContact = errors.New("the primary contact must be one of the desired members")
+	// ErrUnknownAction occurs when applying an action of an unknown type.
+	ErrUnknownAction = errors.New("unknown action")
+)
+
+// StateError represents an invalid desired state.
+type StateError struct {
+	// Scope the scope of the invalid member (eg. "account" or "client <client id>").
+	Scope string
+	// EmailAddress the email address of the invalid member.
+	EmailAddress string
+	// Err the reason.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *StateError) Error() string {
+	return fmt.Sprintf("%s: %s: %v", e.Scope, e.EmailAddress, e.Err)
+}
+
+// Unwrap returns the reason.
+func (e *StateError) Unwrap() error {
+	return e.Err
+}
+
+// ApplyError represents a plan which has been partially applied.
+type ApplyError struct {
+	// Action the failed action.
+	Action *Action
+	// Applied the number of the actions which have been applied successfully before the failure.
+	Applied int
+	// Err the error returned by the failed action.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *ApplyError) Error() string {
+	return fmt.Sprintf("failed to apply %q after %d successful action(s): %v", e.Action, e.Applied, e.Err)
+}
+
+// Unwrap returns the error returned by the failed action.
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
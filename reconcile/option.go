@@ -0,0 +1,26 @@
+package reconcile
+
+import "github.com/xitonix/createsend/clients"
+
+// Option represents a reconciler option.
+type Option func(r *Reconciler)
+
+// WithPasswordGenerator sets the function which generates the passwords of the new client people.
+//
+// By default, a random password is generated for each person.
+func WithPasswordGenerator(password func(clientID string, person clients.PersonBasicDetails) (string, error)) Option {
+	return func(r *Reconciler) {
+		if password != nil {
+			r.password = password
+		}
+	}
+}
+
+// WithDeletion enables or disables the deletion of the administrators and the people who are not in the desired state.
+//
+// Deletion is disabled by default, so that a partial desired state never removes access.
+func WithDeletion(enabled bool) Option {
+	return func(r *Reconciler) {
+		r.deletion = enabled
+	}
+}
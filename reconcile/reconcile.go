@@ -0,0 +1,233 @@
+// Package reconcile reconciles the account administrators and the client people with a desired state.
+//
+// The changes are planned first, so that they can be reviewed before being applied.
+package reconcile
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/internal"
+)
+
+// Reconciler plans and applies the changes required to reconcile an account with a desired state.
+type Reconciler struct {
+	accounts accounts.API
+	clients  clients.API
+	password func(clientID string, person clients.PersonBasicDetails) (string, error)
+	deletion bool
+}
+
+// New creates a new reconciler.
+func New(accountsAPI accounts.API, clientsAPI clients.API, options ...Option) *Reconciler {
+	r := &Reconciler{
+		accounts: accountsAPI,
+		clients:  clientsAPI,
+		password: randomPassword,
+	}
+	for _, op := range options {
+		op(r)
+	}
+	return r
+}
+
+// Plan compares the current state of the account with the desired state, and returns the required changes.
+//
+// Within each scope, the members are added and updated first, then the primary contact is set,
+// and finally the members who are not in the desired state are deleted. The account level changes
+// are planned before the client level changes, and the clients are planned in the order of their IDs.
+func (r *Reconciler) Plan(desired State) (*Plan, error) {
+	if err := desired.validate(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{}
+	if err := r.planAccount(plan, desired); err != nil {
+		return nil, err
+	}
+
+	clientIDs := make([]string, 0, len(desired.Clients))
+	for clientID := range desired.Clients {
+		clientIDs = append(clientIDs, clientID)
+	}
+	sort.Strings(clientIDs)
+	for _, clientID := range clientIDs {
+		if err := r.planClient(plan, clientID, desired.Clients[clientID]); err != nil {
+			return nil, err
+		}
+	}
+	return plan, nil
+}
+
+func (r *Reconciler) planAccount(plan *Plan, desired State) error {
+	admins, err := r.accounts.Administrators()
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*accounts.AdministratorDetails, len(admins))
+	for _, admin := range admins {
+		current[strings.ToLower(admin.EmailAddress)] = admin
+	}
+
+	wanted := make(map[string]bool, len(desired.Administrators))
+	for _, admin := range desired.Administrators {
+		key := strings.ToLower(admin.EmailAddress)
+		wanted[key] = true
+		existing, ok := current[key]
+		switch {
+		case !ok:
+			plan.add(&Action{Type: AddAdministrator, EmailAddress: admin.EmailAddress, Name: admin.Name})
+		case existing.Name != admin.Name:
+			plan.add(&Action{
+				Type:         UpdateAdministrator,
+				EmailAddress: existing.EmailAddress,
+				Name:         admin.Name,
+				CurrentName:  existing.Name,
+			})
+		}
+	}
+
+	if desired.PrimaryContact != "" {
+		primary, err := r.accounts.PrimaryContact()
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(primary, desired.PrimaryContact) {
+			plan.add(&Action{
+				Type:                  SetAccountPrimaryContact,
+				EmailAddress:          desired.PrimaryContact,
+				CurrentPrimaryContact: primary,
+			})
+		}
+	}
+
+	if r.deletion {
+		for _, admin := range admins {
+			if !wanted[strings.ToLower(admin.EmailAddress)] {
+				plan.add(&Action{Type: DeleteAdministrator, EmailAddress: admin.EmailAddress, CurrentName: admin.Name})
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) planClient(plan *Plan, clientID string, desired ClientState) error {
+	people, err := r.clients.People(clientID)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*clients.PersonDetails, len(people))
+	for _, person := range people {
+		current[strings.ToLower(person.EmailAddress)] = person
+	}
+
+	wanted := make(map[string]bool, len(desired.People))
+	for _, person := range desired.People {
+		key := strings.ToLower(person.EmailAddress)
+		wanted[key] = true
+		existing, ok := current[key]
+		switch {
+		case !ok:
+			plan.add(&Action{
+				Type:         AddPerson,
+				ClientID:     clientID,
+				EmailAddress: person.EmailAddress,
+				Name:         person.Name,
+				AccessLevel:  person.AccessLevel,
+			})
+		case existing.Name != person.Name || existing.AccessLevel != person.AccessLevel:
+			plan.add(&Action{
+				Type:               UpdatePerson,
+				ClientID:           clientID,
+				EmailAddress:       existing.EmailAddress,
+				Name:               person.Name,
+				AccessLevel:        person.AccessLevel,
+				CurrentName:        existing.Name,
+				CurrentAccessLevel: existing.AccessLevel,
+			})
+		}
+	}
+
+	if desired.PrimaryContact != "" {
+		primary, err := r.clients.PrimaryContact(clientID)
+		if err != nil {
+			return err
+		}
+		if !strings.EqualFold(primary, desired.PrimaryContact) {
+			plan.add(&Action{
+				Type:                  SetPrimaryContact,
+				ClientID:              clientID,
+				EmailAddress:          desired.PrimaryContact,
+				CurrentPrimaryContact: primary,
+			})
+		}
+	}
+
+	if r.deletion {
+		for _, person := range people {
+			if !wanted[strings.ToLower(person.EmailAddress)] {
+				plan.add(&Action{
+					Type:               DeletePerson,
+					ClientID:           clientID,
+					EmailAddress:       person.EmailAddress,
+					CurrentName:        person.Name,
+					CurrentAccessLevel: person.AccessLevel,
+				})
+			}
+		}
+	}
+	return nil
+}
+
+// Apply applies the actions of the plan in order, and stops at the first failure.
+//
+// The failures are reported as *ApplyError.
+func (r *Reconciler) Apply(plan *Plan) error {
+	for i, action := range plan.Actions {
+		if err := r.apply(action); err != nil {
+			return &ApplyError{Action: action, Applied: i, Err: err}
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) apply(action *Action) error {
+	switch action.Type {
+	case AddAdministrator:
+		return r.accounts.AddAdministrator(accounts.Administrator{EmailAddress: action.EmailAddress, Name: action.Name})
+	case UpdateAdministrator:
+		return r.accounts.UpdateAdministrator(action.EmailAddress, accounts.Administrator{EmailAddress: action.EmailAddress, Name: action.Name})
+	case DeleteAdministrator:
+		return r.accounts.DeleteAdministrator(action.EmailAddress)
+	case SetAccountPrimaryContact:
+		return r.accounts.SetAsPrimaryContact(action.EmailAddress)
+	case AddPerson:
+		details := action.person()
+		password, err := r.password(action.ClientID, details)
+		if err != nil {
+			return err
+		}
+		_, err = r.clients.AddPerson(action.ClientID, clients.Person{PersonBasicDetails: details, Password: password})
+		return err
+	case UpdatePerson:
+		_, err := r.clients.UpdatePerson(action.ClientID, action.EmailAddress, clients.Person{PersonBasicDetails: action.person()})
+		return err
+	case DeletePerson:
+		return r.clients.DeletePerson(action.ClientID, action.EmailAddress)
+	case SetPrimaryContact:
+		_, err := r.clients.SetPrimaryContact(action.ClientID, action.EmailAddress)
+		return err
+	default:
+		return ErrUnknownAction
+	}
+}
+
+func (a *Action) person() clients.PersonBasicDetails {
+	return clients.PersonBasicDetails{EmailAddress: a.EmailAddress, Name: a.Name, AccessLevel: a.AccessLevel}
+}
+
+func randomPassword(string, clients.PersonBasicDetails) (string, error) {
+	return internal.RandomPassword()
+}
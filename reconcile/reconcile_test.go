@@ -0,0 +1,430 @@
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+)
+
+var errAPI = errors.New("api error")
+
+// recorder records the calls and fails the specified call.
+type recorder struct {
+	calls  []string
+	failOn string
+}
+
+func (r *recorder) call(format string, args ...interface{}) error {
+	name := fmt.Sprintf(format, args...)
+	r.calls = append(r.calls, name)
+	if name == r.failOn {
+		return errAPI
+	}
+	return nil
+}
+
+type accountsStub struct {
+	accounts.API
+	*recorder
+	admins  []*accounts.AdministratorDetails
+	primary string
+}
+
+func (a *accountsStub) Administrators() ([]*accounts.AdministratorDetails, error) {
+	return a.admins, a.call("Administrators")
+}
+
+func (a *accountsStub) PrimaryContact() (string, error) {
+	return a.primary, a.call("PrimaryContact")
+}
+
+func (a *accountsStub) AddAdministrator(admin accounts.Administrator) error {
+	return a.call("AddAdministrator %s %s", admin.EmailAddress, admin.Name)
+}
+
+func (a *accountsStub) UpdateAdministrator(current string, admin accounts.Administrator) error {
+	return a.call("UpdateAdministrator %s %s %s", current, admin.EmailAddress, admin.Name)
+}
+
+func (a *accountsStub) DeleteAdministrator(email string) error {
+	return a.call("DeleteAdministrator %s", email)
+}
+
+func (a *accountsStub) SetAsPrimaryContact(email string) error {
+	return a.call("SetAsPrimaryContact %s", email)
+}
+
+type clientsStub struct {
+	clients.API
+	*recorder
+	people  map[string][]*clients.PersonDetails
+	primary map[string]string
+}
+
+func (c *clientsStub) People(clientID string) ([]*clients.PersonDetails, error) {
+	return c.people[clientID], c.call("People %s", clientID)
+}
+
+func (c *clientsStub) PrimaryContact(clientID string) (string, error) {
+	return c.primary[clientID], c.call("PrimaryContact %s", clientID)
+}
+
+func (c *clientsStub) AddPerson(clientID string, person clients.Person) (string, error) {
+	return person.EmailAddress, c.call("AddPerson %s %s %s %d %s", clientID, person.EmailAddress, person.Name, person.AccessLevel, person.Password)
+}
+
+func (c *clientsStub) UpdatePerson(clientID, email string, person clients.Person) (string, error) {
+	return person.EmailAddress, c.call("UpdatePerson %s %s %s %s %d", clientID, email, person.EmailAddress, person.Name, person.AccessLevel)
+}
+
+func (c *clientsStub) DeletePerson(clientID, email string) error {
+	return c.call("DeletePerson %s %s", clientID, email)
+}
+
+func (c *clientsStub) SetPrimaryContact(clientID, email string) (string, error) {
+	return email, c.call("SetPrimaryContact %s %s", clientID, email)
+}
+
+func admin(email, name string) *accounts.AdministratorDetails {
+	return &accounts.AdministratorDetails{
+		Administrator: accounts.Administrator{EmailAddress: email, Name: name},
+		Status:        accounts.ActiveAdministrator,
+	}
+}
+
+func person(email, name string, level clients.AccessLevel) *clients.PersonDetails {
+	return &clients.PersonDetails{
+		PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: email, Name: name, AccessLevel: level},
+		Status:             clients.ActivePerson,
+	}
+}
+
+func newStubs(failOn string) (*recorder, *accountsStub, *clientsStub) {
+	rec := &recorder{failOn: failOn}
+	accountsAPI := &accountsStub{
+		recorder: rec,
+		admins:   []*accounts.AdministratorDetails{admin("keep@a.com", "Keep"), admin("Rename@a.com", "Old"), admin("gone@a.com", "Gone")},
+		primary:  "gone@a.com",
+	}
+	clientsAPI := &clientsStub{
+		recorder: rec,
+		people: map[string][]*clients.PersonDetails{
+			"c1": {
+				person("keep@c.com", "Keep", clients.FullAccess),
+				person("change@c.com", "Change", clients.ReportsOnlyAccess),
+				person("gone@c.com", "Gone", clients.FullAccess),
+			},
+			"c2": {person("p@c.com", "P", clients.FullAccess)},
+		},
+		primary: map[string]string{"c1": "gone@c.com", "c2": "P@c.com"},
+	}
+	return rec, accountsAPI, clientsAPI
+}
+
+func desiredState() State {
+	return State{
+		Administrators: []accounts.Administrator{
+			{EmailAddress: "keep@a.com", Name: "Keep"},
+			{EmailAddress: "rename@a.com", Name: "New"},
+			{EmailAddress: "new@a.com", Name: "New Admin"},
+		},
+		PrimaryContact: "keep@a.com",
+		Clients: map[string]ClientState{
+			"c2": {
+				People:         []clients.PersonBasicDetails{{EmailAddress: "p@c.com", Name: "P", AccessLevel: clients.FullAccess}},
+				PrimaryContact: "p@c.com",
+			},
+			"c1": {
+				People: []clients.PersonBasicDetails{
+					{EmailAddress: "keep@c.com", Name: "Keep", AccessLevel: clients.FullAccess},
+					{EmailAddress: "change@c.com", Name: "Changed", AccessLevel: clients.CampaignManagerAccess},
+					{EmailAddress: "new@c.com", Name: "New", AccessLevel: clients.ReportsOnlyAccess},
+				},
+				PrimaryContact: "keep@c.com",
+			},
+		},
+	}
+}
+
+const expectedPlan = `~ account: update administrator Rename@a.com: name "Old" => "New"
++ account: add administrator new@a.com (New Admin)
+~ account: primary contact gone@a.com => keep@a.com
+- account: delete administrator gone@a.com
+~ client c1: update person change@c.com: name "Change" => "Changed", access "reports" => "reports, subscribers, create/send campaigns, import subscribers"
++ client c1: add person new@c.com (New, access: reports)
+~ client c1: primary contact gone@c.com => keep@c.com
+- client c1: delete person gone@c.com`
+
+func TestReconciler_PlanApply(t *testing.T) {
+	rec, accountsAPI, clientsAPI := newStubs("")
+	r := New(accountsAPI, clientsAPI, WithDeletion(true), WithPasswordGenerator(func(clientID string, person clients.PersonBasicDetails) (string, error) {
+		return "pwd_" + clientID, nil
+	}))
+
+	plan, err := r.Plan(desiredState())
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(expectedPlan, plan.String()); diff != "" {
+		t.Errorf("Plan mismatch (-expected +actual):\n%s", diff)
+	}
+
+	rec.calls = nil
+	if err := r.Apply(plan); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expectedCalls := []string{
+		"UpdateAdministrator Rename@a.com Rename@a.com New",
+		"AddAdministrator new@a.com New Admin",
+		"SetAsPrimaryContact keep@a.com",
+		"DeleteAdministrator gone@a.com",
+		"UpdatePerson c1 change@c.com change@c.com Changed 23",
+		"AddPerson c1 new@c.com New 1 pwd_c1",
+		"SetPrimaryContact c1 keep@c.com",
+		"DeletePerson c1 gone@c.com",
+	}
+	if diff := cmp.Diff(expectedCalls, rec.calls); diff != "" {
+		t.Errorf("Calls mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestReconciler_Plan_WithoutDeletion(t *testing.T) {
+	_, accountsAPI, clientsAPI := newStubs("")
+	plan, err := New(accountsAPI, clientsAPI).Plan(desiredState())
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	for _, action := range plan.Actions {
+		if action.Type == DeleteAdministrator || action.Type == DeletePerson {
+			t.Errorf("Did not expect a delete action, Actual: %s", action)
+		}
+	}
+	if len(plan.Actions) != 6 {
+		t.Errorf("Expected 6 actions, Actual: %d", len(plan.Actions))
+	}
+}
+
+func TestReconciler_Plan_NoChanges(t *testing.T) {
+	_, accountsAPI, clientsAPI := newStubs("")
+	plan, err := New(accountsAPI, clientsAPI).Plan(State{
+		Administrators: []accounts.Administrator{
+			{EmailAddress: "KEEP@a.com", Name: "Keep"},
+			{EmailAddress: "rename@a.com", Name: "Old"},
+			{EmailAddress: "gone@a.com", Name: "Gone"},
+		},
+		PrimaryContact: "Gone@a.com",
+		Clients: map[string]ClientState{
+			"c2": {
+				People:         []clients.PersonBasicDetails{{EmailAddress: "p@c.com", Name: "P", AccessLevel: clients.FullAccess}},
+				PrimaryContact: "p@c.com",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if !plan.IsEmpty() || plan.String() != "No changes." {
+		t.Errorf("Expected an empty plan, Actual: %s", plan)
+	}
+}
+
+func TestReconciler_Plan_Failure(t *testing.T) {
+	testCases := []struct {
+		title         string
+		failOn        string
+		state         State
+		expectedError error
+	}{
+		{
+			title:         "administrators failure",
+			failOn:        "Administrators",
+			state:         desiredState(),
+			expectedError: errAPI,
+		},
+		{
+			title:         "account primary contact failure",
+			failOn:        "PrimaryContact",
+			state:         desiredState(),
+			expectedError: errAPI,
+		},
+		{
+			title:         "people failure",
+			failOn:        "People c2",
+			state:         desiredState(),
+			expectedError: errAPI,
+		},
+		{
+			title:         "client primary contact failure",
+			failOn:        "PrimaryContact c1",
+			state:         desiredState(),
+			expectedError: errAPI,
+		},
+		{
+			title: "duplicate administrator",
+			state: State{Administrators: []accounts.Administrator{
+				{EmailAddress: "a@b.com"},
+				{EmailAddress: "A@b.com"},
+			}},
+			expectedError: ErrDuplicateEmailAddress,
+		},
+		{
+			title: "unknown account primary contact",
+			state: State{
+				Administrators: []accounts.Administrator{{EmailAddress: "a@b.com"}},
+				PrimaryContact: "c@d.com",
+			},
+			expectedError: ErrUnknownPrimaryContact,
+		},
+		{
+			title: "duplicate person",
+			state: State{Clients: map[string]ClientState{
+				"c1": {People: []clients.PersonBasicDetails{{EmailAddress: "a@b.com"}, {EmailAddress: "a@b.com"}}},
+			}},
+			expectedError: ErrDuplicateEmailAddress,
+		},
+		{
+			title: "unknown client primary contact",
+			state: State{Clients: map[string]ClientState{
+				"c1": {PrimaryContact: "a@b.com"},
+			}},
+			expectedError: ErrUnknownPrimaryContact,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			_, accountsAPI, clientsAPI := newStubs(tC.failOn)
+			plan, err := New(accountsAPI, clientsAPI).Plan(tC.state)
+			if !errors.Is(err, tC.expectedError) {
+				t.Fatalf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if plan != nil {
+				t.Errorf("Expected a nil plan, Actual: %s", plan)
+			}
+		})
+	}
+}
+
+func TestStateError_Error(t *testing.T) {
+	err := &StateError{Scope: "client c1", EmailAddress: "a@b.com", Err: ErrDuplicateEmailAddress}
+	if expected := "client c1: a@b.com: duplicate email address"; err.Error() != expected {
+		t.Errorf("Expected: %q, Actual: %q", expected, err.Error())
+	}
+}
+
+func TestReconciler_Apply_Failure(t *testing.T) {
+	plan := &Plan{Actions: []*Action{
+		{Type: AddAdministrator, EmailAddress: "a@b.com", Name: "A"},
+		{Type: UpdateAdministrator, EmailAddress: "a@b.com", Name: "A"},
+		{Type: DeleteAdministrator, EmailAddress: "a@b.com"},
+		{Type: SetAccountPrimaryContact, EmailAddress: "a@b.com"},
+		{Type: AddPerson, ClientID: "c1", EmailAddress: "a@b.com", Name: "A", AccessLevel: clients.FullAccess},
+		{Type: UpdatePerson, ClientID: "c1", EmailAddress: "a@b.com", Name: "A", AccessLevel: clients.FullAccess},
+		{Type: DeletePerson, ClientID: "c1", EmailAddress: "a@b.com"},
+		{Type: SetPrimaryContact, ClientID: "c1", EmailAddress: "a@b.com"},
+	}}
+	calls := []string{
+		"AddAdministrator a@b.com A",
+		"UpdateAdministrator a@b.com a@b.com A",
+		"DeleteAdministrator a@b.com",
+		"SetAsPrimaryContact a@b.com",
+		"AddPerson c1 a@b.com A 127 pwd",
+		"UpdatePerson c1 a@b.com a@b.com A 127",
+		"DeletePerson c1 a@b.com",
+		"SetPrimaryContact c1 a@b.com",
+	}
+	for i, failOn := range calls {
+		t.Run(failOn, func(t *testing.T) {
+			_, accountsAPI, clientsAPI := newStubs(failOn)
+			r := New(accountsAPI, clientsAPI, WithPasswordGenerator(func(string, clients.PersonBasicDetails) (string, error) {
+				return "pwd", nil
+			}))
+			err := r.Apply(plan)
+			var applyErr *ApplyError
+			if !errors.As(err, &applyErr) {
+				t.Fatalf("Expected an *ApplyError, Actual: %v", err)
+			}
+			if !errors.Is(err, errAPI) || applyErr.Applied != i || applyErr.Action != plan.Actions[i] {
+				t.Errorf("Unexpected error: %+v", applyErr)
+			}
+		})
+	}
+
+	t.Run("password generator failure", func(t *testing.T) {
+		_, accountsAPI, clientsAPI := newStubs("")
+		r := New(accountsAPI, clientsAPI, WithPasswordGenerator(func(string, clients.PersonBasicDetails) (string, error) {
+			return "", errAPI
+		}))
+		err := r.Apply(&Plan{Actions: plan.Actions[4:5]})
+		if !errors.Is(err, errAPI) {
+			t.Fatalf("Expected error: %v, Actual: %v", errAPI, err)
+		}
+		expected := `failed to apply "+ client c1: add person a@b.com (A, access: full)" after 0 successful action(s): api error`
+		if err.Error() != expected {
+			t.Errorf("Expected: %q, Actual: %q", expected, err.Error())
+		}
+	})
+
+	t.Run("unknown action", func(t *testing.T) {
+		_, accountsAPI, clientsAPI := newStubs("")
+		err := New(accountsAPI, clientsAPI).Apply(&Plan{Actions: []*Action{{Type: 0, EmailAddress: "a@b.com"}}})
+		if !errors.Is(err, ErrUnknownAction) {
+			t.Fatalf("Expected error: %v, Actual: %v", ErrUnknownAction, err)
+		}
+	})
+}
+
+func TestAction_String(t *testing.T) {
+	testCases := []struct {
+		action   *Action
+		expected string
+	}{
+		{
+			action:   &Action{Type: SetPrimaryContact, ClientID: "c1", EmailAddress: "a@b.com"},
+			expected: "~ client c1: primary contact none => a@b.com",
+		},
+		{
+			action:   &Action{Type: UpdatePerson, ClientID: "c1", EmailAddress: "a@b.com", Name: "A", CurrentName: "A", AccessLevel: clients.FullAccess},
+			expected: `~ client c1: update person a@b.com: access "none" => "full"`,
+		},
+		{
+			action:   &Action{Type: 0, EmailAddress: "a@b.com"},
+			expected: "? account: unknown a@b.com",
+		},
+	}
+	for _, tC := range testCases {
+		if actual := tC.action.String(); actual != tC.expected {
+			t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+		}
+	}
+}
+
+func TestActionType_String(t *testing.T) {
+	expected := []string{
+		"unknown",
+		"add administrator",
+		"update administrator",
+		"delete administrator",
+		"set account primary contact",
+		"add person",
+		"update person",
+		"delete person",
+		"set primary contact",
+	}
+	for i, e := range expected {
+		if actual := ActionType(i).String(); actual != e {
+			t.Errorf("Expected: %q, Actual: %q", e, actual)
+		}
+	}
+}
+
+func TestRandomPassword(t *testing.T) {
+	password, err := randomPassword("c1", clients.PersonBasicDetails{})
+	if err != nil || len(password) != 24 {
+		t.Errorf("Expected a 24 character password, Actual: %q, %v", password, err)
+	}
+}
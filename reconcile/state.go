@@ -0,0 +1,67 @@
+package reconcile
+
+import (
+	"strings"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+)
+
+// State represents the desired access configuration of an account.
+type State struct {
+	// Administrators the desired account administrators.
+	Administrators []accounts.Administrator
+	// PrimaryContact the email address of the desired account primary contact (optional).
+	//
+	// The primary contact must be one of the desired administrators.
+	PrimaryContact string
+	// Clients the desired people of each managed client, keyed by client ID.
+	//
+	// The people of the clients which are not listed here are left untouched.
+	Clients map[string]ClientState
+}
+
+// ClientState represents the desired people of a client.
+type ClientState struct {
+	// People the desired people of the client.
+	People []clients.PersonBasicDetails
+	// PrimaryContact the email address of the desired primary contact of the client (optional).
+	//
+	// The primary contact must be one of the desired people.
+	PrimaryContact string
+}
+
+func (s State) validate() error {
+	emails := make([]string, 0, len(s.Administrators))
+	for _, admin := range s.Administrators {
+		emails = append(emails, admin.EmailAddress)
+	}
+	if err := validateMembers("account", emails, s.PrimaryContact); err != nil {
+		return err
+	}
+	for clientID, client := range s.Clients {
+		emails = emails[:0]
+		for _, person := range client.People {
+			emails = append(emails, person.EmailAddress)
+		}
+		if err := validateMembers("client "+clientID, emails, client.PrimaryContact); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMembers(scope string, emails []string, primaryContact string) error {
+	seen := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		key := strings.ToLower(email)
+		if seen[key] {
+			return &StateError{Scope: scope, EmailAddress: email, Err: ErrDuplicateEmailAddress}
+		}
+		seen[key] = true
+	}
+	if primaryContact != "" && !seen[strings.ToLower(primaryContact)] {
+		return &StateError{Scope: scope, EmailAddress: primaryContact, Err: ErrUnknownPrimaryContact}
+	}
+	return nil
+}
@@ -0,0 +1,263 @@
+package render
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// ErrNotList occurs when a repeater refers to a variable which is not a list.
+var ErrNotList = errors.New("the repeater variable is not a list")
+
+// scope represents the data a template is rendered with. The nested scopes are created by the repeaters.
+type scope struct {
+	value  interface{}
+	parent *scope
+	// prefix the path of the repeater variable followed by a dot, which is used to report the missing variables.
+	prefix string
+	index  int
+	size   int
+}
+
+// execution renders the nodes of a template, and keeps track of the missing and the used variables.
+type execution struct {
+	html bool
+	out  strings.Builder
+	*usage
+}
+
+// usage keeps track of the variables the templates refer to, by their full names (eg. items.name).
+type usage struct {
+	// missing the variables which do not exist in the data, and do not have a fallback.
+	missing map[string]struct{}
+	// fallbacks the variables which have been replaced by their fallback text.
+	fallbacks map[string]struct{}
+	// rendered the variables whose entire value has been rendered.
+	rendered map[string]struct{}
+	// referenced the variables and their parents which have been referred to by a variable, a repeater or a conditional.
+	referenced map[string]struct{}
+}
+
+func newUsage() *usage {
+	return &usage{
+		missing:    make(map[string]struct{}),
+		fallbacks:  make(map[string]struct{}),
+		rendered:   make(map[string]struct{}),
+		referenced: make(map[string]struct{}),
+	}
+}
+
+func (e *execution) render(nodes []*node, s *scope) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			e.out.WriteString(n.text)
+		case variableNode:
+			e.renderVariable(n, s)
+		case ifNode, unlessNode:
+			value, _, _ := e.resolve(s, n.path)
+			body := n.body
+			if isTruthy(value) == (n.kind == unlessNode) {
+				body = n.alt
+			}
+			if err := e.render(body, s); err != nil {
+				return err
+			}
+		case eachNode:
+			if err := e.renderEach(n, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (e *execution) renderVariable(n *node, s *scope) {
+	value, name, found := e.resolve(s, n.path)
+	text := format(value)
+	e.rendered[name] = struct{}{}
+	if text == "" && n.hasFallback {
+		text = n.fallback
+		e.fallbacks[name] = struct{}{}
+	} else if !found || value == nil {
+		e.missing[name] = struct{}{}
+	}
+	if e.html && !n.raw {
+		text = html.EscapeString(text)
+	}
+	e.out.WriteString(text)
+}
+
+func (e *execution) renderEach(n *node, s *scope) error {
+	value, name, _ := e.resolve(s, n.path)
+	if !isTruthy(value) {
+		return e.render(n.alt, s)
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotList, name)
+	}
+	for i, item := range items {
+		itemScope := &scope{value: item, parent: s, prefix: name + ".", index: i, size: len(items)}
+		if err := e.render(n.body, itemScope); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolve returns the value of the variable, the full name of the variable to report, and whether the variable exists.
+func (e *execution) resolve(s *scope, path string) (interface{}, string, bool) {
+	for strings.HasPrefix(path, "../") {
+		path = path[3:]
+		if s.parent != nil {
+			s = s.parent
+		}
+	}
+	inRepeater := s.parent != nil
+	switch path {
+	case "this":
+		return s.value, strings.TrimSuffix(s.prefix, "."), true
+	case "@index", "@first", "@last":
+		if !inRepeater {
+			return nil, path, false
+		}
+		var value interface{} = json.Number(strconv.Itoa(s.index))
+		switch path {
+		case "@first":
+			value = s.index == 0
+		case "@last":
+			value = s.index == s.size-1
+		}
+		return value, s.prefix + path, true
+	}
+
+	path = strings.TrimPrefix(path, "this.")
+	segments := strings.Split(path, ".")
+	e.reference(s.prefix + path)
+	value := s.value
+	for _, segment := range segments {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, s.prefix + path, false
+		}
+		if value, ok = fields[segment]; !ok {
+			return nil, s.prefix + path, false
+		}
+	}
+	return value, s.prefix + path, true
+}
+
+// reference marks the variable and its parents as referenced.
+func (u *usage) reference(name string) {
+	for i := range name {
+		if name[i] == '.' {
+			u.referenced[name[:i]] = struct{}{}
+		}
+	}
+	u.referenced[name] = struct{}{}
+}
+
+// unused adds the full names of the fields of the value which are neither rendered nor referenced to the result.
+//
+// The fields of the objects within lists are named after the list (eg. items.name).
+func (u *usage) unused(value interface{}, prefix string, result map[string]struct{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			name := prefix + key
+			if _, ok := u.rendered[name]; ok {
+				continue
+			}
+			if hasFields(field) {
+				u.unused(field, name+".", result)
+				continue
+			}
+			if _, ok := u.referenced[name]; !ok {
+				result[name] = struct{}{}
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			u.unused(item, prefix, result)
+		}
+	}
+}
+
+// hasFields returns true if the value is a non-empty object, or a list of objects.
+func hasFields(value interface{}) bool {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return len(v) > 0
+	case []interface{}:
+		for _, item := range v {
+			if hasFields(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// exists returns true if the variable exists in the value.
+//
+// The variables within lists must exist in at least one item, unless the list is empty.
+func exists(value interface{}, segments []string) bool {
+	if len(segments) == 0 {
+		return value != nil
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		field, ok := v[segments[0]]
+		return ok && exists(field, segments[1:])
+	case []interface{}:
+		if len(v) == 0 {
+			return true
+		}
+		for _, item := range v {
+			if exists(item, segments) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTruthy returns false for missing, null, false, zero and empty values.
+func isTruthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case json.Number:
+		f, err := v.Float64()
+		return err != nil || f != 0
+	case []interface{}:
+		return len(v) > 0
+	default:
+		return true
+	}
+}
+
+// format returns the text representation of the value. Lists and objects are rendered as JSON.
+func format(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, _ := json.Marshal(v)
+		return string(b)
+	}
+}
@@ -0,0 +1,13 @@
+package render
+
+// Option represents a renderer option.
+type Option func(r *Renderer)
+
+// WithStrict makes Render fail with ErrMissingVariables if any of the variables without a fallback is missing.
+//
+// The rendered result is returned alongside the error.
+func WithStrict(strict bool) Option {
+	return func(r *Renderer) {
+		r.strict = strict
+	}
+}
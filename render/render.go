@@ -0,0 +1,148 @@
+// Package render renders the content of smart transactional emails locally, without sending them.
+//
+// The supported syntax is a subset of the smart email variables:
+//
+//	{{name}}                           the value of the variable (HTML escaped in the HTML content)
+//	{{{name}}}                         the value of the variable without HTML escaping
+//	{{name | "fallback"}}              the fallback text if the variable is missing or empty
+//	{{order.total}}                    a nested variable
+//	{{#each items}}...{{/each}}        a repeater; {{this}}, {{@index}}, {{@first}}, {{@last}} and {{../name}} are available within
+//	{{#if name}}...{{else}}...{{/if}}  a conditional; {{#unless name}}...{{/unless}} is its negation
+//	{{! comment}}                      a comment
+//
+// Repeaters and {{#if}} blocks may also have an {{else}} section, which is rendered if the variable is missing or empty.
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+// ErrMissingVariables occurs in strict mode when the content refers to variables which do not exist in the data.
+var ErrMissingVariables = errors.New("missing variables")
+
+// Result represents the rendered content of a smart email.
+type Result struct {
+	// Subject the rendered subject line.
+	Subject string
+	// HTML the rendered HTML content.
+	HTML string
+	// Text the rendered text content.
+	Text string
+	// Missing the sorted names of the variables which do not exist in the data, and do not have a fallback.
+	//
+	// The variables declared by the smart email (EmailVariables) are included, even if the content does not refer to them.
+	// The variables within repeaters are prefixed with the name of the repeater (eg. items.name).
+	Missing []string
+	// Unused the sorted names of the data fields which are neither referred to by the content, nor declared by the smart email.
+	//
+	// The nested fields are separated by dots, and the fields of the objects within lists are prefixed
+	// with the name of the list (eg. items.name).
+	Unused []string
+}
+
+// Renderer renders the content of smart emails.
+type Renderer struct {
+	strict bool
+}
+
+// New creates a new renderer.
+func New(options ...Option) *Renderer {
+	r := &Renderer{}
+	for _, option := range options {
+		option(r)
+	}
+	return r
+}
+
+// Render renders the subject, the HTML and the text content of the smart email with the specified data.
+//
+// The data is converted to JSON first, exactly as it would be sent by transactional.API.SendSmartEmail,
+// so that structs are rendered using their JSON field names.
+func (r *Renderer) Render(details *transactional.SmartEmailDetails, data map[string]interface{}) (*Result, error) {
+	normalised, err := normalise(data)
+	if err != nil {
+		return nil, err
+	}
+	root := &scope{value: normalised}
+	u := newUsage()
+
+	result := &Result{}
+	for _, content := range []struct {
+		name   string
+		source string
+		html   bool
+		target *string
+	}{
+		{name: "Subject", source: details.Subject, target: &result.Subject},
+		{name: "HTML", source: details.HTML, html: true, target: &result.HTML},
+		{name: "Text", source: details.Text, target: &result.Text},
+	} {
+		nodes, err := parse(content.name, content.source)
+		if err != nil {
+			return nil, err
+		}
+		e := &execution{html: content.html, usage: u}
+		if err := e.render(nodes, root); err != nil {
+			return nil, fmt.Errorf("%s: %w", content.name, err)
+		}
+		*content.target = e.out.String()
+	}
+
+	for _, variable := range details.EmailVariables {
+		segments := strings.Split(variable, ".")
+		if _, ok := u.fallbacks[variable]; !ok && !exists(normalised, segments) {
+			u.missing[variable] = struct{}{}
+		}
+		u.reference(variable)
+		u.rendered[variable] = struct{}{}
+	}
+	result.Missing = sortedKeys(u.missing)
+
+	unused := make(map[string]struct{})
+	if _, ok := u.rendered[""]; !ok {
+		u.unused(normalised, "", unused)
+	}
+	result.Unused = sortedKeys(unused)
+
+	if r.strict && len(result.Missing) > 0 {
+		return result, fmt.Errorf("%w: %s", ErrMissingVariables, strings.Join(result.Missing, ", "))
+	}
+	return result, nil
+}
+
+// normalise converts the data into the JSON representation the server receives.
+func normalise(data map[string]interface{}) (map[string]interface{}, error) {
+	if data == nil {
+		return map[string]interface{}{}, nil
+	}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the data: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	var normalised map[string]interface{}
+	if err := decoder.Decode(&normalised); err != nil {
+		return nil, fmt.Errorf("failed to decode the data: %w", err)
+	}
+	return normalised, nil
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	if len(set) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,263 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+type item struct {
+	Name     string  `json:"name"`
+	Quantity int     `json:"quantity"`
+	Price    float64 `json:"price"`
+}
+
+func TestRenderer_Render(t *testing.T) {
+	testCases := []struct {
+		title    string
+		html     string
+		data     map[string]interface{}
+		expected *Result
+	}{
+		{
+			title:    "no variables",
+			html:     "<p>Hello</p>",
+			expected: &Result{HTML: "<p>Hello</p>"},
+		},
+		{
+			title:    "variables",
+			html:     "<p>Hi {{ firstname }}, you have {{count}} items, {{active}} {{nothing}}</p>",
+			data:     map[string]interface{}{"firstname": "Jane", "count": 3, "active": true, "nothing": nil},
+			expected: &Result{HTML: "<p>Hi Jane, you have 3 items, true </p>", Missing: []string{"nothing"}},
+		},
+		{
+			title:    "html escaping",
+			html:     "{{name}} {{{name}}}",
+			data:     map[string]interface{}{"name": "<b>Tom & Jerry</b>"},
+			expected: &Result{HTML: "&lt;b&gt;Tom &amp; Jerry&lt;/b&gt; <b>Tom & Jerry</b>"},
+		},
+		{
+			title: "fallbacks",
+			html:  `{{firstname | "there"}} {{lastname|fallback: "<none>"}} {{city | 'Sydney'}}`,
+			data:  map[string]interface{}{"lastname": "", "city": "Melbourne"},
+			expected: &Result{
+				HTML: "there &lt;none&gt; Melbourne",
+			},
+		},
+		{
+			title: "missing and unused variables",
+			html:  "{{a}} {{b.c}} {{d}}",
+			data:  map[string]interface{}{"b": map[string]interface{}{"x": 1}, "d": "d", "e": "e", "f": "f"},
+			expected: &Result{
+				HTML:    "  d",
+				Missing: []string{"a", "b.c"},
+				Unused:  []string{"b.x", "e", "f"},
+			},
+		},
+		{
+			title:    "nested variables and objects",
+			html:     "{{order.total}} {{order.lines}} {{order}} {{name.first}}",
+			data:     map[string]interface{}{"order": map[string]interface{}{"total": 12.5, "lines": []int{1, 2}}, "name": "Jane"},
+			expected: &Result{HTML: `12.5 [1,2] {&#34;lines&#34;:[1,2],&#34;total&#34;:12.5} `, Missing: []string{"name.first"}},
+		},
+		{
+			title: "repeaters",
+			html:  "{{#each items}}{{@index}}:{{name}} x{{this.quantity}} @{{price}} {{#if @first}}first{{/if}}{{#if @last}}last{{/if}} {{../currency}} {{colour}};{{/each}}",
+			data: map[string]interface{}{
+				"currency": "AUD",
+				"items":    []item{{Name: "Pen", Quantity: 2, Price: 1.5}, {Name: "Ink", Quantity: 1, Price: 10}},
+			},
+			expected: &Result{
+				HTML:    "0:Pen x2 @1.5 first AUD ;1:Ink x1 @10 last AUD ;",
+				Missing: []string{"items.colour"},
+			},
+		},
+		{
+			title: "nested repeaters",
+			html:  "{{#each orders}}[{{#each tags}}{{this}}{{#unless @last}},{{/unless}}{{/each}}]{{/each}}",
+			data: map[string]interface{}{
+				"orders": []map[string]interface{}{{"tags": []string{"a", "b"}}, {"tags": []string{}}, {"id": 1}},
+			},
+			expected: &Result{HTML: "[a,b][][]", Unused: []string{"orders.id"}},
+		},
+		{
+			title:    "empty repeater",
+			html:     "{{#each items}}{{name}}{{else}}No items{{/each}}{{#each missing}}{{name}}{{/each}}",
+			data:     map[string]interface{}{"items": []string{}},
+			expected: &Result{HTML: "No items"},
+		},
+		{
+			title:    "repeater variables outside repeaters",
+			html:     "{{@index}}{{@first}}{{@last}}",
+			expected: &Result{Missing: []string{"@first", "@index", "@last"}},
+		},
+		{
+			title: "conditionals",
+			html:  "{{#if vip}}VIP{{else}}Regular{{/if}} {{#if points}}{{points}}{{else}}no points{{/if}} {{#unless name}}anonymous{{else}}{{name}}{{/unless}} {{#if missing}}x{{/if}}",
+			data:  map[string]interface{}{"vip": false, "points": 0, "name": "Jane"},
+			expected: &Result{
+				HTML: "Regular no points Jane ",
+			},
+		},
+		{
+			title: "truthiness",
+			html:  "{{#if a}}a{{/if}}{{#if b}}b{{/if}}{{#if c}}c{{/if}}{{#if d}}d{{/if}}{{#if e}}e{{/if}}{{#if f}}f{{/if}}{{#if g}}g{{/if}}",
+			data:  map[string]interface{}{"a": "", "b": "x", "c": []int{}, "d": []int{1}, "e": map[string]interface{}{}, "f": 0.5, "g": nil},
+			expected: &Result{
+				HTML: "bdef",
+			},
+		},
+		{
+			title:    "comments",
+			html:     "a{{! ignored }}b",
+			expected: &Result{HTML: "ab"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := New().Render(&transactional.SmartEmailDetails{HTML: tC.html}, tC.data)
+			if err != nil {
+				t.Fatalf("Expected no errors, but received %s", err)
+			}
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_AllContent(t *testing.T) {
+	details := &transactional.SmartEmailDetails{
+		Subject: "Order {{order_id}} for {{name}}",
+		HTML:    "<p>{{name}}</p>",
+		Text:    "{{name}} <{{email}}>",
+	}
+	data := map[string]interface{}{"order_id": 42, "name": "A & B", "email": "a@b.com", "extra": 1}
+
+	actual, err := New().Render(details, data)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	expected := &Result{
+		Subject: "Order 42 for A & B",
+		HTML:    "<p>A &amp; B</p>",
+		Text:    "A & B <a@b.com>",
+		Unused:  []string{"extra"},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRenderer_Render_EmailVariables(t *testing.T) {
+	details := &transactional.SmartEmailDetails{
+		HTML:           "{{name}} {{city | \"Sydney\"}} {{#each items}}{{name}}{{/each}}",
+		EmailVariables: []string{"name", "city", "order_id", "items.name", "items.sku", "coupon", "tags.label"},
+	}
+	data := map[string]interface{}{
+		"name":     "Jane",
+		"order_id": 1,
+		"items":    []map[string]interface{}{{"name": "Pen"}},
+		"tags":     []interface{}{},
+		"coupon":   map[string]interface{}{"code": "SAVE10"},
+		"extra":    map[string]interface{}{"a": 1, "b": []map[string]interface{}{{"c": 1}}},
+	}
+
+	actual, err := New().Render(details, data)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	expected := &Result{
+		HTML:    "Jane Sydney Pen",
+		Missing: []string{"items.sku"},
+		Unused:  []string{"extra.a", "extra.b.c"},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRenderer_Render_Errors(t *testing.T) {
+	testCases := []struct {
+		title         string
+		details       *transactional.SmartEmailDetails
+		data          map[string]interface{}
+		strict        bool
+		expectedErr   error
+		expectResult  bool
+		expectedError string
+	}{
+		{
+			title:         "syntax error",
+			details:       &transactional.SmartEmailDetails{Text: "{{#if a}}"},
+			expectedError: "Text:1:10: {{#if}} is not closed",
+		},
+		{
+			title:         "not a list",
+			details:       &transactional.SmartEmailDetails{Subject: "{{#each items}}{{/each}}"},
+			data:          map[string]interface{}{"items": "x"},
+			expectedErr:   ErrNotList,
+			expectedError: "Subject: the repeater variable is not a list: items",
+		},
+		{
+			title:         "not a nested list",
+			details:       &transactional.SmartEmailDetails{HTML: "{{#each items}}{{#each tags}}{{/each}}{{/each}}"},
+			data:          map[string]interface{}{"items": []interface{}{map[string]interface{}{"tags": 1}}},
+			expectedErr:   ErrNotList,
+			expectedError: "HTML: the repeater variable is not a list: items.tags",
+		},
+		{
+			title:         "not a list within a conditional",
+			details:       &transactional.SmartEmailDetails{HTML: "{{#if a}}{{#each a}}{{/each}}{{/if}}"},
+			data:          map[string]interface{}{"a": true},
+			expectedErr:   ErrNotList,
+			expectedError: "HTML: the repeater variable is not a list: a",
+		},
+		{
+			title:         "invalid data",
+			details:       &transactional.SmartEmailDetails{},
+			data:          map[string]interface{}{"a": make(chan int)},
+			expectedError: "failed to encode the data: json: unsupported type: chan int",
+		},
+		{
+			title:         "strict mode",
+			details:       &transactional.SmartEmailDetails{HTML: "{{a}}{{b|x}}{{c}}"},
+			strict:        true,
+			expectedErr:   ErrMissingVariables,
+			expectResult:  true,
+			expectedError: "missing variables: a, c",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			result, err := New(WithStrict(tC.strict)).Render(tC.details, tC.data)
+			if err == nil {
+				t.Fatal("Expected an error, but received nil")
+			}
+			if err.Error() != tC.expectedError {
+				t.Errorf("Expected %s, Actual: %s", tC.expectedError, err)
+			}
+			if tC.expectedErr != nil && !errors.Is(err, tC.expectedErr) {
+				t.Errorf("Expected %v, Actual: %v", tC.expectedErr, err)
+			}
+			if (result != nil) != tC.expectResult {
+				t.Errorf("Expected result: %v, Actual: %v", tC.expectResult, result)
+			}
+		})
+	}
+}
+
+func TestRenderer_Render_StrictWithoutMissingVariables(t *testing.T) {
+	result, err := New(WithStrict(true)).Render(&transactional.SmartEmailDetails{HTML: "{{a}}"}, map[string]interface{}{"a": "x"})
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if result.HTML != "x" {
+		t.Errorf("Expected x, Actual: %s", result.HTML)
+	}
+}
@@ -0,0 +1,65 @@
+package render
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrSnapshotMismatch occurs when the rendered result does not match the snapshot file.
+var ErrSnapshotMismatch = errors.New("the result does not match the snapshot")
+
+// Write writes the result in a stable, human-readable format which is suitable for snapshot files.
+func (r *Result) Write(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "Subject: %s\nMissing: %s\nUnused: %s\n\n--- HTML ---\n%s\n--- Text ---\n%s\n",
+		r.Subject,
+		strings.Join(r.Missing, ", "),
+		strings.Join(r.Unused, ", "),
+		r.HTML,
+		r.Text)
+	return err
+}
+
+// MatchSnapshot compares the result with the snapshot file, or overwrites the file if update is true.
+//
+// The returned error wraps ErrSnapshotMismatch, and reports the first line which is different.
+func (r *Result) MatchSnapshot(path string, update bool) error {
+	var actual bytes.Buffer
+	if err := r.Write(&actual); err != nil {
+		return err
+	}
+	if update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, actual.Bytes(), 0644)
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(expected, actual.Bytes()) {
+		return nil
+	}
+	expectedLines := strings.Split(string(expected), "\n")
+	actualLines := strings.Split(actual.String(), "\n")
+	for i := 0; i < len(expectedLines) || i < len(actualLines); i++ {
+		e, a := line(expectedLines, i), line(actualLines, i)
+		if e != a {
+			return fmt.Errorf("%w: %s:%d: expected %q, actual %q", ErrSnapshotMismatch, path, i+1, e, a)
+		}
+	}
+	return fmt.Errorf("%w: %s: expected %d lines, actual %d", ErrSnapshotMismatch, path, len(expectedLines), len(actualLines))
+}
+
+func line(lines []string, i int) string {
+	if i < len(lines) {
+		return lines[i]
+	}
+	return ""
+}
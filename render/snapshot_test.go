@@ -0,0 +1,105 @@
+package render
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+func receipt(t *testing.T) *Result {
+	t.Helper()
+	details := &transactional.SmartEmailDetails{
+		Subject: "Your order #{{order_id}}",
+		HTML:    "<h1>Hi {{name}},</h1>\n<ul>{{#each items}}<li>{{name}} x{{quantity}} ({{sku}})</li>{{/each}}</ul>\n<p>Total: {{total}} {{currency | \"AUD\"}}</p>",
+		Text:    "Hi {{name}},\n{{#each items}}- {{name}} x{{quantity}}\n{{/each}}Total: {{total}} {{currency | \"AUD\"}}",
+	}
+	data := map[string]interface{}{
+		"order_id": 1001,
+		"name":     "Jane & co",
+		"items":    []item{{Name: "Pen", Quantity: 2, Price: 1.5}, {Name: "Ink", Quantity: 1, Price: 10}},
+		"total":    13,
+		"promo":    "SAVE10",
+	}
+	result, err := New().Render(details, data)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	return result
+}
+
+func TestResult_MatchSnapshot(t *testing.T) {
+	if err := receipt(t).MatchSnapshot(filepath.Join("testdata", "receipt.snap"), false); err != nil {
+		t.Errorf("Expected no errors, but received %s", err)
+	}
+}
+
+func TestResult_MatchSnapshot_Update(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshots", "receipt.snap")
+	result := receipt(t)
+	if err := result.MatchSnapshot(path, true); err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	expected, _ := os.ReadFile(filepath.Join("testdata", "receipt.snap"))
+	actual, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Expected no errors, but received %s", err)
+	}
+	if string(expected) != string(actual) {
+		t.Errorf("Expected %s, Actual: %s", expected, actual)
+	}
+	if err := result.MatchSnapshot(path, false); err != nil {
+		t.Errorf("Expected no errors, but received %s", err)
+	}
+}
+
+func TestResult_MatchSnapshot_Mismatch(t *testing.T) {
+	testCases := []struct {
+		title         string
+		snapshot      string
+		expectedError string
+	}{
+		{
+			title:         "different line",
+			snapshot:      "Subject: Hi\nMissing: \n",
+			expectedError: `the result does not match the snapshot: %s:1: expected "Subject: Hi", actual "Subject: Hello"`,
+		},
+		{
+			title:         "extra line",
+			snapshot:      "Subject: Hello\nMissing: \nUnused: \n\n--- HTML ---\n\n--- Text ---\n\nextra\n",
+			expectedError: `the result does not match the snapshot: %s:9: expected "extra", actual ""`,
+		},
+		{
+			title:         "extra empty line",
+			snapshot:      "Subject: Hello\nMissing: \nUnused: \n\n--- HTML ---\n\n--- Text ---\n\n\n",
+			expectedError: `the result does not match the snapshot: %s: expected 10 lines, actual 9`,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "result.snap")
+			if err := os.WriteFile(path, []byte(tC.snapshot), 0600); err != nil {
+				t.Fatal(err)
+			}
+			err := (&Result{Subject: "Hello"}).MatchSnapshot(path, false)
+			if !errors.Is(err, ErrSnapshotMismatch) {
+				t.Fatalf("Expected %v, Actual: %v", ErrSnapshotMismatch, err)
+			}
+			expected := strings.Replace(tC.expectedError, "%s", path, 1)
+			if err.Error() != expected {
+				t.Errorf("Expected %s, Actual: %s", expected, err)
+			}
+		})
+	}
+}
+
+func TestResult_MatchSnapshot_MissingFile(t *testing.T) {
+	err := (&Result{}).MatchSnapshot(filepath.Join(t.TempDir(), "missing.snap"), false)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected %v, Actual: %v", os.ErrNotExist, err)
+	}
+}
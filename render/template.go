@@ -0,0 +1,228 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+)
+
+type nodeKind uint8
+
+const (
+	textNode nodeKind = iota
+	variableNode
+	eachNode
+	ifNode
+	unlessNode
+)
+
+var blockKinds = map[string]nodeKind{
+	"each":   eachNode,
+	"if":     ifNode,
+	"unless": unlessNode,
+}
+
+// node represents a piece of text, a variable or a block of a template.
+type node struct {
+	kind        nodeKind
+	text        string
+	path        string
+	fallback    string
+	hasFallback bool
+	raw         bool
+	body        []*node
+	alt         []*node
+}
+
+// SyntaxError occurs when the content of a smart email is not a valid template.
+type SyntaxError struct {
+	// Template the name of the template (Subject, HTML or Text).
+	Template string
+	// Line the line number of the invalid tag, starting from 1.
+	Line int
+	// Column the column number of the invalid tag, starting from 1.
+	Column int
+	// Message the description of the problem.
+	Message string
+}
+
+// Error returns the string representation of the error.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.Template, e.Line, e.Column, e.Message)
+}
+
+// parser parses a template into a tree of nodes.
+type parser struct {
+	name    string
+	content string
+	stack   []*node
+	inElse  []bool
+}
+
+// parse parses the content of the template with the specified name.
+func parse(name, content string) ([]*node, error) {
+	p := &parser{
+		name:    name,
+		content: content,
+		stack:   []*node{{}},
+		inElse:  []bool{false},
+	}
+	offset := 0
+	for {
+		start := strings.Index(content[offset:], "{{")
+		if start < 0 {
+			p.appendText(content[offset:])
+			break
+		}
+		start += offset
+		p.appendText(content[offset:start])
+
+		open, end := "{{", "}}"
+		if strings.HasPrefix(content[start:], "{{{") {
+			open, end = "{{{", "}}}"
+		}
+		length := strings.Index(content[start+len(open):], end)
+		if length < 0 {
+			return nil, p.errorf(start, "%s is not closed", open)
+		}
+		tag := strings.TrimSpace(content[start+len(open) : start+len(open)+length])
+		offset = start + len(open) + length + len(end)
+		if err := p.parseTag(start, tag, open == "{{{"); err != nil {
+			return nil, err
+		}
+	}
+	if len(p.stack) > 1 {
+		return nil, p.errorf(len(content), "{{#%s}} is not closed", blockName(p.top().kind))
+	}
+	return p.stack[0].body, nil
+}
+
+func (p *parser) parseTag(position int, tag string, raw bool) error {
+	switch {
+	case raw:
+		return p.parseVariable(position, tag, true)
+	case strings.HasPrefix(tag, "!"):
+		return nil
+	case strings.HasPrefix(tag, "#"):
+		fields := strings.Fields(tag[1:])
+		if len(fields) == 0 {
+			return p.errorf(position, "missing block helper")
+		}
+		kind, ok := blockKinds[fields[0]]
+		if !ok {
+			return p.errorf(position, "unsupported block helper %q", fields[0])
+		}
+		if len(fields) != 2 || !isValidPath(fields[1]) {
+			return p.errorf(position, "{{#%s}} requires a single variable", fields[0])
+		}
+		block := &node{kind: kind, path: fields[1]}
+		p.append(block)
+		p.stack = append(p.stack, block)
+		p.inElse = append(p.inElse, false)
+		return nil
+	case strings.HasPrefix(tag, "/"):
+		name := strings.TrimSpace(tag[1:])
+		if len(p.stack) == 1 || blockName(p.top().kind) != name {
+			return p.errorf(position, "unexpected {{/%s}}", name)
+		}
+		p.stack = p.stack[:len(p.stack)-1]
+		p.inElse = p.inElse[:len(p.inElse)-1]
+		return nil
+	case tag == "else":
+		if len(p.stack) == 1 || p.inElse[len(p.inElse)-1] {
+			return p.errorf(position, "unexpected {{else}}")
+		}
+		p.inElse[len(p.inElse)-1] = true
+		return nil
+	default:
+		return p.parseVariable(position, tag, false)
+	}
+}
+
+// parseVariable parses a variable tag in the form of name or name | "fallback".
+//
+// The fallback may also be written as fallback: "text", and the quotes are optional.
+func (p *parser) parseVariable(position int, tag string, raw bool) error {
+	v := &node{kind: variableNode, path: tag, raw: raw}
+	if i := strings.Index(tag, "|"); i >= 0 {
+		v.path = strings.TrimSpace(tag[:i])
+		v.fallback, v.hasFallback = parseFallback(tag[i+1:]), true
+	}
+	if !isValidPath(v.path) {
+		return p.errorf(position, "invalid variable %q", v.path)
+	}
+	p.append(v)
+	return nil
+}
+
+func parseFallback(value string) string {
+	value = strings.TrimSpace(value)
+	for _, prefix := range []string{"fallback:", "fallback="} {
+		if len(value) >= len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = strings.TrimSpace(value[len(prefix):])
+			break
+		}
+	}
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// isValidPath returns true if the value is a dot separated variable path,
+// optionally prefixed with ../ to refer to the enclosing scopes.
+func isValidPath(path string) bool {
+	for strings.HasPrefix(path, "../") {
+		path = path[3:]
+	}
+	switch path {
+	case "this", "@index", "@first", "@last":
+		return true
+	}
+	path = strings.TrimPrefix(path, "this.")
+	for _, segment := range strings.Split(path, ".") {
+		if segment == "" || strings.ContainsAny(segment, " \t\r\n{}|\"'#/@") {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *parser) top() *node {
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *parser) append(n *node) {
+	top := p.top()
+	if p.inElse[len(p.inElse)-1] {
+		top.alt = append(top.alt, n)
+		return
+	}
+	top.body = append(top.body, n)
+}
+
+func (p *parser) appendText(text string) {
+	if text != "" {
+		p.append(&node{kind: textNode, text: text})
+	}
+}
+
+func (p *parser) errorf(position int, format string, a ...interface{}) error {
+	before := p.content[:position]
+	line := strings.Count(before, "\n") + 1
+	column := position - strings.LastIndex(before, "\n")
+	return &SyntaxError{
+		Template: p.name,
+		Line:     line,
+		Column:   column,
+		Message:  fmt.Sprintf(format, a...),
+	}
+}
+
+func blockName(kind nodeKind) string {
+	for name, k := range blockKinds {
+		if k == kind {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,137 @@
+package render
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParse_SyntaxErrors(t *testing.T) {
+	testCases := []struct {
+		title    string
+		content  string
+		expected *SyntaxError
+	}{
+		{
+			title:    "unclosed variable",
+			content:  "Hi {{name",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 4, Message: "{{ is not closed"},
+		},
+		{
+			title:    "unclosed raw variable",
+			content:  "Hi\n  {{{name}}",
+			expected: &SyntaxError{Template: "HTML", Line: 2, Column: 3, Message: "{{{ is not closed"},
+		},
+		{
+			title:    "empty variable",
+			content:  "{{ }}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: `invalid variable ""`},
+		},
+		{
+			title:    "invalid variable",
+			content:  "{{first name}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: `invalid variable "first name"`},
+		},
+		{
+			title:    "empty path segment",
+			content:  "{{order..total}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: `invalid variable "order..total"`},
+		},
+		{
+			title:    "raw block",
+			content:  "{{{#if name}}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: `invalid variable "#if name"`},
+		},
+		{
+			title:    "missing block helper",
+			content:  "{{#}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: "missing block helper"},
+		},
+		{
+			title:    "unsupported block helper",
+			content:  "{{#with order}}{{/with}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: `unsupported block helper "with"`},
+		},
+		{
+			title:    "block without variable",
+			content:  "{{#if}}{{/if}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: "{{#if}} requires a single variable"},
+		},
+		{
+			title:    "block with multiple variables",
+			content:  "{{#each a b}}{{/each}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: "{{#each}} requires a single variable"},
+		},
+		{
+			title:    "unclosed block",
+			content:  "{{#each items}}\n{{name}}\n",
+			expected: &SyntaxError{Template: "HTML", Line: 3, Column: 1, Message: "{{#each}} is not closed"},
+		},
+		{
+			title:    "unexpected close",
+			content:  "{{name}}{{/if}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 9, Message: "unexpected {{/if}}"},
+		},
+		{
+			title:    "mismatched close",
+			content:  "{{#if a}}{{/each}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 10, Message: "unexpected {{/each}}"},
+		},
+		{
+			title:    "else outside block",
+			content:  "{{else}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 1, Message: "unexpected {{else}}"},
+		},
+		{
+			title:    "duplicate else",
+			content:  "{{#if a}}{{else}}{{else}}{{/if}}",
+			expected: &SyntaxError{Template: "HTML", Line: 1, Column: 18, Message: "unexpected {{else}}"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			_, err := parse("HTML", tC.content)
+			var actual *SyntaxError
+			if !errors.As(err, &actual) {
+				t.Fatalf("Expected a syntax error, Actual: %v", err)
+			}
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyntaxError_Error(t *testing.T) {
+	err := &SyntaxError{Template: "Text", Line: 2, Column: 5, Message: "unexpected {{else}}"}
+	expected := "Text:2:5: unexpected {{else}}"
+	if actual := err.Error(); actual != expected {
+		t.Errorf("Expected %s, Actual: %s", expected, actual)
+	}
+}
+
+func TestParseFallback(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected string
+	}{
+		{value: ` "there"`, expected: "there"},
+		{value: `'there'`, expected: "there"},
+		{value: `there `, expected: "there"},
+		{value: `fallback: "my friend"`, expected: "my friend"},
+		{value: `Fallback=friend`, expected: "friend"},
+		{value: `""`, expected: ""},
+		{value: `"`, expected: `"`},
+		{value: `"friend'`, expected: `"friend'`},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.value, func(t *testing.T) {
+			if actual := parseFallback(tC.value); actual != tC.expected {
+				t.Errorf("Expected %q, Actual: %q", tC.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package createsend
+
+// ServerErrorCode server side error codes reported by Campaign Monitor.
+type ServerErrorCode int
+
+const (
+	// ErrCodeInvalidEmailAddress the provided email address was invalid.
+	ErrCodeInvalidEmailAddress ServerErrorCode = 1
+	// ErrCodeMustBeLoggedIn the request was not authenticated.
+	ErrCodeMustBeLoggedIn ServerErrorCode = 50
+	// ErrCodeInvalidAPIKey the provided API key was invalid.
+	ErrCodeInvalidAPIKey ServerErrorCode = 100
+	// ErrCodeInvalidListID the requested list ID was invalid.
+	ErrCodeInvalidListID ServerErrorCode = 101
+	// ErrCodeInvalidClientID the requested client ID was invalid.
+	ErrCodeInvalidClientID ServerErrorCode = 102
+	// ErrCodeInvalidOAuthToken the provided OAuth token was invalid.
+	ErrCodeInvalidOAuthToken ServerErrorCode = 120
+	// ErrCodeExpiredOAuthToken the provided OAuth token has expired.
+	ErrCodeExpiredOAuthToken ServerErrorCode = 121
+	// ErrCodeRevokedOAuthToken the provided OAuth token has been revoked.
+	ErrCodeRevokedOAuthToken ServerErrorCode = 122
+	// ErrCodeClientNotFound the requested client could not be found.
+	ErrCodeClientNotFound ServerErrorCode = 203
+)
+
+var (
+	// ErrInvalidEmailAddress occurs when the server rejects an email address.
+	ErrInvalidEmailAddress = newServerError(ErrCodeInvalidEmailAddress)
+	// ErrMustBeLoggedIn occurs when the server receives an unauthenticated request.
+	ErrMustBeLoggedIn = newServerError(ErrCodeMustBeLoggedIn)
+	// ErrInvalidAPIKey occurs when the server rejects the API key.
+	ErrInvalidAPIKey = newServerError(ErrCodeInvalidAPIKey)
+	// ErrInvalidListID occurs when the server cannot find the requested list.
+	ErrInvalidListID = newServerError(ErrCodeInvalidListID)
+	// ErrInvalidClientID occurs when the server rejects the requested client ID.
+	ErrInvalidClientID = newServerError(ErrCodeInvalidClientID)
+	// ErrInvalidOAuthToken occurs when the server rejects the OAuth token.
+	ErrInvalidOAuthToken = newServerError(ErrCodeInvalidOAuthToken)
+	// ErrExpiredOAuthToken occurs when the OAuth token has expired.
+	ErrExpiredOAuthToken = newServerError(ErrCodeExpiredOAuthToken)
+	// ErrRevokedOAuthToken occurs when the OAuth token has been revoked.
+	ErrRevokedOAuthToken = newServerError(ErrCodeRevokedOAuthToken)
+	// ErrClientNotFound occurs when the server cannot find the requested client.
+	ErrClientNotFound = newServerError(ErrCodeClientNotFound)
+)
+
+// String returns the string representation of the error code.
+func (c ServerErrorCode) String() string {
+	switch c {
+	case ErrCodeInvalidEmailAddress:
+		return "invalid email address"
+	case ErrCodeMustBeLoggedIn:
+		return "must be logged in"
+	case ErrCodeInvalidAPIKey:
+		return "invalid API key"
+	case ErrCodeInvalidListID:
+		return "invalid list ID"
+	case ErrCodeInvalidClientID:
+		return "invalid client ID"
+	case ErrCodeInvalidOAuthToken:
+		return "invalid OAuth token"
+	case ErrCodeExpiredOAuthToken:
+		return "expired OAuth token"
+	case ErrCodeRevokedOAuthToken:
+		return "revoked OAuth token"
+	case ErrCodeClientNotFound:
+		return "client not found"
+	default:
+		return "server error"
+	}
+}
@@ -0,0 +1,119 @@
+package snapshot
+
+import (
+	"time"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const defaultPageSize = 1000
+
+// Exporter takes snapshots of an account.
+type Exporter struct {
+	apis     APIs
+	pageSize int
+	now      func() time.Time
+}
+
+// NewExporter creates a new exporter.
+func NewExporter(apis APIs, options ...ExportOption) *Exporter {
+	e := &Exporter{
+		apis:     apis,
+		pageSize: defaultPageSize,
+		now:      time.Now,
+	}
+	for _, op := range options {
+		op(e)
+	}
+	return e
+}
+
+// Export walks through all the clients of the account, and collects their configuration.
+func (e *Exporter) Export() (*Snapshot, error) {
+	accountClients, err := e.apis.Accounts().Clients()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		Version:   Version,
+		CreatedAt: e.now(),
+		Clients:   make([]*Client, 0, len(accountClients)),
+	}
+	for _, c := range accountClients {
+		client, err := e.exportClient(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.Clients = append(snapshot.Clients, client)
+	}
+	return snapshot, nil
+}
+
+func (e *Exporter) exportClient(clientID string) (*Client, error) {
+	api := e.apis.Clients()
+	details, err := api.Get(clientID)
+	if err != nil {
+		return nil, err
+	}
+	// The API key is cleared on a copy to leave the details returned by the API intact.
+	exported := *details
+	exported.APIKey = ""
+
+	client := &Client{Details: &exported}
+	if client.People, err = api.People(clientID); err != nil {
+		return nil, err
+	}
+	if client.PrimaryContact, err = api.PrimaryContact(clientID); err != nil {
+		return nil, err
+	}
+	if client.Lists, err = api.Lists(clientID); err != nil {
+		return nil, err
+	}
+	if client.Segments, err = api.Segments(clientID); err != nil {
+		return nil, err
+	}
+	if client.Templates, err = api.Templates(clientID); err != nil {
+		return nil, err
+	}
+	if client.Suppressions, err = e.exportSuppressions(api, clientID); err != nil {
+		return nil, err
+	}
+	if client.SmartEmails, err = e.exportSmartEmails(clientID); err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (e *Exporter) exportSuppressions(api clients.API, clientID string) ([]*clients.SuppressionDetails, error) {
+	var entries []*clients.SuppressionDetails
+	for page := 1; ; page++ {
+		list, err := api.SuppressionList(clientID, e.pageSize, page, order.BySuppressedEmailAddress, order.ASC)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, list.Entries...)
+		if page >= list.NumberOfPages {
+			return entries, nil
+		}
+	}
+}
+
+func (e *Exporter) exportSmartEmails(clientID string) ([]*transactional.SmartEmailDetails, error) {
+	api := e.apis.Transactional()
+	emails, err := api.SmartEmails(transactional.WithClientID(clientID))
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*transactional.SmartEmailDetails, 0, len(emails))
+	for _, email := range emails {
+		details, err := api.SmartEmail(email.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, details)
+	}
+	return result, nil
+}
@@ -0,0 +1,46 @@
+package snapshot
+
+import (
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/provision"
+)
+
+// ExportOption represents an exporter option.
+type ExportOption func(e *Exporter)
+
+// WithPageSize sets the page size used to export the suppression lists (default 1000).
+func WithPageSize(pageSize int) ExportOption {
+	return func(e *Exporter) {
+		if pageSize > 0 {
+			e.pageSize = pageSize
+		}
+	}
+}
+
+// RestoreOption represents a restorer option.
+type RestoreOption func(r *Restorer)
+
+// WithPasswordGenerator sets the function which generates the passwords of the restored people.
+//
+// The passwords are not exported. By default, a random password is generated for each person.
+func WithPasswordGenerator(password func(person *clients.PersonDetails) (string, error)) RestoreOption {
+	return func(r *Restorer) {
+		if password != nil {
+			r.password = password
+		}
+	}
+}
+
+// WithProgress sets the function which gets called after each provisioning step.
+func WithProgress(progress func(provision.Progress)) RestoreOption {
+	return func(r *Restorer) {
+		r.progress = progress
+	}
+}
+
+// WithKeepPartial keeps the partially restored clients on failure instead of deleting them.
+func WithKeepPartial(enabled bool) RestoreOption {
+	return func(r *Restorer) {
+		r.keepPartial = enabled
+	}
+}
@@ -0,0 +1,122 @@
+package snapshot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/internal"
+	"github.com/xitonix/createsend/provision"
+)
+
+// Restorer recreates the clients of a snapshot.
+//
+// The clients, their billing settings, people, primary contacts and suppression lists are restored.
+// Lists, segments, templates and smart emails are exported for reference only, and are not restored.
+type Restorer struct {
+	api         clients.API
+	password    func(person *clients.PersonDetails) (string, error)
+	progress    func(provision.Progress)
+	keepPartial bool
+}
+
+// NewRestorer creates a new restorer.
+func NewRestorer(api clients.API, options ...RestoreOption) *Restorer {
+	r := &Restorer{
+		api:      api,
+		password: randomPassword,
+	}
+	for _, op := range options {
+		op(r)
+	}
+	return r
+}
+
+// Restore recreates the clients of the snapshot in order.
+//
+// If restoring a client fails, the client is deleted unless the restorer has been created using WithKeepPartial,
+// and a *provision.Error is returned.
+//
+// The returned map contains the IDs of the restored clients keyed by their IDs in the snapshot,
+// including the clients which have been restored before an error occurred.
+func (r *Restorer) Restore(snapshot *Snapshot) (map[string]string, error) {
+	if snapshot.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, snapshot.Version)
+	}
+
+	options := []provision.Option{provision.WithKeepPartial(r.keepPartial)}
+	if r.progress != nil {
+		options = append(options, provision.WithProgress(r.progress))
+	}
+	provisioner := provision.New(r.api, options...)
+
+	ids := make(map[string]string, len(snapshot.Clients))
+	for _, client := range snapshot.Clients {
+		spec, err := r.spec(client)
+		if err != nil {
+			return ids, err
+		}
+		clientID, err := provisioner.Provision(spec)
+		if err != nil {
+			return ids, err
+		}
+		ids[client.Details.ID] = clientID
+	}
+	return ids, nil
+}
+
+func (r *Restorer) spec(client *Client) (provision.ClientSpec, error) {
+	details := client.Details
+	spec := provision.ClientSpec{
+		Details: clients.BasicDetails{
+			Company:  details.Company,
+			Country:  details.Country,
+			Timezone: details.Timezone,
+		},
+		People:       make([]clients.Person, 0, len(client.People)),
+		Suppressions: make([]string, 0, len(client.Suppressions)),
+	}
+
+	if billing := details.Billing; billing != nil {
+		switch {
+		case billing.Monthly != nil:
+			spec.Monthly = &clients.MonthlyRates{
+				Currency:         billing.Currency,
+				ClientPays:       billing.ClientPays,
+				MarkupPercentage: billing.Monthly.MarkupPercentage,
+				Scheme:           billing.Monthly.Scheme,
+			}
+		case billing.PAYG != nil:
+			spec.PAYG = &clients.PAYGRates{
+				Currency:               billing.Currency,
+				CanPurchaseCredits:     billing.PAYG.CanPurchaseCredits,
+				ClientPays:             billing.ClientPays,
+				MarkupOnDelivery:       billing.PAYG.MarkupOnDelivery,
+				MarkupPerRecipient:     billing.PAYG.MarkupPerRecipient,
+				MarkupOnDesignSpamTest: billing.PAYG.MarkupOnDesignSpamTest,
+			}
+		}
+	}
+
+	for _, person := range client.People {
+		password, err := r.password(person)
+		if err != nil {
+			return spec, err
+		}
+		spec.People = append(spec.People, clients.Person{
+			PersonBasicDetails: person.PersonBasicDetails,
+			Password:           password,
+		})
+		if strings.EqualFold(person.EmailAddress, client.PrimaryContact) {
+			spec.PrimaryContact = person.EmailAddress
+		}
+	}
+	for _, s := range client.Suppressions {
+		spec.Suppressions = append(spec.Suppressions, s.EmailAddress)
+	}
+	return spec, nil
+}
+
+func randomPassword(*clients.PersonDetails) (string, error) {
+	return internal.RandomPassword()
+}
@@ -0,0 +1,86 @@
+// Package snapshot exports the configuration of a Campaign Monitor account into a versioned snapshot,
+// and restores the snapshots into the same or a different account.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/transactional"
+)
+
+// Version the current snapshot format version.
+const Version = 1
+
+// ErrUnsupportedVersion occurs when the snapshot format version is not supported.
+var ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+
+// APIs provides access to the Campaign Monitor APIs.
+//
+// *createsend.Client implements the interface.
+type APIs interface {
+	// Accounts returns the accounts API.
+	Accounts() accounts.API
+	// Clients returns the clients API.
+	Clients() clients.API
+	// Transactional returns the transactional API.
+	Transactional() transactional.API
+}
+
+// Snapshot represents the configuration of an account at a point in time.
+type Snapshot struct {
+	// Version the snapshot format version.
+	Version int
+	// CreatedAt the time when the snapshot was taken.
+	CreatedAt time.Time
+	// Clients the clients of the account.
+	Clients []*Client
+}
+
+// Client represents the configuration of a client.
+type Client struct {
+	// Details the client details. The client API key is never exported.
+	Details *clients.ClientDetails
+	// People the people associated with the client.
+	People []*clients.PersonDetails
+	// PrimaryContact the email address of the client's primary contact.
+	PrimaryContact string
+	// Lists the subscriber lists of the client.
+	Lists []*clients.List
+	// Segments the list segments of the client.
+	Segments []*clients.Segment
+	// Templates the templates of the client.
+	Templates []*clients.Template
+	// Suppressions the client's entire suppression list.
+	Suppressions []*clients.SuppressionDetails
+	// SmartEmails the smart transactional emails of the client.
+	SmartEmails []*transactional.SmartEmailDetails
+}
+
+// Write writes the snapshot to w in indented JSON format.
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// Read reads a JSON snapshot from r.
+func Read(r io.Reader) (*Snapshot, error) {
+	var s Snapshot
+	if err := json.NewDecoder(r).Decode(&s); err != nil {
+		return nil, err
+	}
+	return s.validate()
+}
+
+func (s *Snapshot) validate() (*Snapshot, error) {
+	if s.Version != Version {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, s.Version)
+	}
+	return s, nil
+}
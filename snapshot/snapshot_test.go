@@ -0,0 +1,478 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/money"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/provision"
+	"github.com/xitonix/createsend/transactional"
+)
+
+var (
+	errAPI  = errors.New("api error")
+	created = time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+// apisStub serves a fixed account configuration, and fails the specified call.
+type apisStub struct {
+	calls       []string
+	failOn      string
+	nextID      int
+	pageEntries int
+}
+
+func (a *apisStub) Accounts() accounts.API           { return accountsStub{apisStub: a} }
+func (a *apisStub) Clients() clients.API             { return clientsStub{apisStub: a} }
+func (a *apisStub) Transactional() transactional.API { return transactionalStub{apisStub: a} }
+
+func (a *apisStub) call(format string, args ...interface{}) error {
+	name := fmt.Sprintf(format, args...)
+	a.calls = append(a.calls, name)
+	if name == a.failOn {
+		return errAPI
+	}
+	return nil
+}
+
+type accountsStub struct {
+	accounts.API
+	*apisStub
+}
+
+func (a accountsStub) Clients() ([]*accounts.Client, error) {
+	if err := a.call("Accounts.Clients"); err != nil {
+		return nil, err
+	}
+	return []*accounts.Client{{ID: "c1", Name: "Client 1"}, {ID: "c2", Name: "Client 2"}}, nil
+}
+
+type clientsStub struct {
+	clients.API
+	*apisStub
+}
+
+func (c clientsStub) Get(clientID string) (*clients.ClientDetails, error) {
+	if err := c.call("Get %s", clientID); err != nil {
+		return nil, err
+	}
+	return clientDetails(clientID), nil
+}
+
+func (c clientsStub) People(clientID string) ([]*clients.PersonDetails, error) {
+	return []*clients.PersonDetails{personDetails("a@" + clientID + ".com")}, c.call("People %s", clientID)
+}
+
+func (c clientsStub) PrimaryContact(clientID string) (string, error) {
+	return "A@" + clientID + ".com", c.call("PrimaryContact %s", clientID)
+}
+
+func (c clientsStub) Lists(clientID string) ([]*clients.List, error) {
+	return []*clients.List{{ID: "l_" + clientID, Name: "list"}}, c.call("Lists %s", clientID)
+}
+
+func (c clientsStub) Segments(clientID string) ([]*clients.Segment, error) {
+	return []*clients.Segment{{ID: "s_" + clientID, Title: "segment", ListID: "l_" + clientID}}, c.call("Segments %s", clientID)
+}
+
+func (c clientsStub) Templates(clientID string) ([]*clients.Template, error) {
+	return []*clients.Template{{ID: "t_" + clientID, Name: "template"}}, c.call("Templates %s", clientID)
+}
+
+func (c clientsStub) SuppressionList(clientID string, pageSize, page int, orderBy order.SuppressionListField, direction order.Direction) (*clients.SuppressionList, error) {
+	if err := c.call("SuppressionList %s %d %d %s %s", clientID, pageSize, page, orderBy, direction); err != nil {
+		return nil, err
+	}
+	entries := make([]*clients.SuppressionDetails, c.pageEntries)
+	for i := range entries {
+		entries[i] = suppression(fmt.Sprintf("s%d_%d@%s.com", page, i, clientID))
+	}
+	return &clients.SuppressionList{Entries: entries, PageNumber: page, NumberOfPages: 2}, nil
+}
+
+func (c clientsStub) Create(details clients.BasicDetails) (string, error) {
+	if err := c.call("Create %s", details.Company); err != nil {
+		return "", err
+	}
+	c.nextID++
+	return fmt.Sprintf("new%d", c.nextID), nil
+}
+
+func (c clientsStub) SetMonthlyBilling(clientID string, rates clients.MonthlyRates) error {
+	return c.call("SetMonthlyBilling %s %s %s %d", clientID, rates.Currency, rates.Scheme, rates.MarkupPercentage)
+}
+
+func (c clientsStub) SetPAYGBilling(clientID string, rates clients.PAYGRates) error {
+	return c.call("SetPAYGBilling %s %s %s", clientID, rates.Currency, rates.MarkupOnDelivery)
+}
+
+func (c clientsStub) AddPerson(clientID string, person clients.Person) (string, error) {
+	return person.EmailAddress, c.call("AddPerson %s %s %s %s", clientID, person.EmailAddress, person.AccessLevel, person.Password)
+}
+
+func (c clientsStub) SetPrimaryContact(clientID string, emailAddress string) (string, error) {
+	return emailAddress, c.call("SetPrimaryContact %s %s", clientID, emailAddress)
+}
+
+func (c clientsStub) Suppress(clientID string, emails ...string) error {
+	return c.call("Suppress %s %d", clientID, len(emails))
+}
+
+func (c clientsStub) Delete(clientID string) error {
+	return c.call("Delete %s", clientID)
+}
+
+type transactionalStub struct {
+	transactional.API
+	*apisStub
+}
+
+func (t transactionalStub) SmartEmails(options ...transactional.Option) ([]*transactional.SmartEmailBasicDetails, error) {
+	opts := &transactional.Options{}
+	for _, op := range options {
+		op(opts)
+	}
+	if err := t.call("SmartEmails %s", opts.ClientID()); err != nil {
+		return nil, err
+	}
+	return []*transactional.SmartEmailBasicDetails{{ID: "e_" + opts.ClientID()}}, nil
+}
+
+func (t transactionalStub) SmartEmail(smartEmailID string) (*transactional.SmartEmailDetails, error) {
+	if err := t.call("SmartEmail %s", smartEmailID); err != nil {
+		return nil, err
+	}
+	return smartEmail(smartEmailID), nil
+}
+
+func clientDetails(clientID string) *clients.ClientDetails {
+	details := &clients.ClientDetails{
+		APIKey:   "secret",
+		ID:       clientID,
+		Company:  "company " + clientID,
+		Country:  "Australia",
+		Timezone: "(GMT+10:00) Canberra, Melbourne, Sydney",
+		Billing:  &clients.BillingDetails{Currency: money.AUD, ClientPays: true},
+	}
+	if clientID == "c1" {
+		details.Billing.Mode = clients.MonthlyBilling
+		details.Billing.Monthly = &clients.MonthlyBillingDetails{Scheme: "Basic", MarkupPercentage: 20, Rate: money.MustParse("9.99")}
+	} else {
+		details.Billing.Mode = clients.PAYGBilling
+		details.Billing.PAYG = &clients.PayAsYouGoBillingDetails{MarkupOnDelivery: money.MustParse("0.15")}
+	}
+	return details
+}
+
+func personDetails(email string) *clients.PersonDetails {
+	return &clients.PersonDetails{
+		PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: email, Name: "name", AccessLevel: clients.ReportsOnlyAccess},
+		Status:             clients.ActivePerson,
+	}
+}
+
+func suppression(email string) *clients.SuppressionDetails {
+	return &clients.SuppressionDetails{
+		Reason:       clients.UnsubscribedSuppression,
+		EmailAddress: email,
+		Date:         created,
+		State:        clients.Suppressed,
+	}
+}
+
+func smartEmail(id string) *transactional.SmartEmailDetails {
+	return &transactional.SmartEmailDetails{
+		SmartEmailBasicDetails: transactional.SmartEmailBasicDetails{ID: id, Name: "email", CreatedAt: created, Status: transactional.ActiveSmartEmail},
+		Subject:                "subject",
+	}
+}
+
+func expectedClient(clientID string, suppressions ...string) *Client {
+	details := clientDetails(clientID)
+	details.APIKey = ""
+	client := &Client{
+		Details:        details,
+		People:         []*clients.PersonDetails{personDetails("a@" + clientID + ".com")},
+		PrimaryContact: "A@" + clientID + ".com",
+		Lists:          []*clients.List{{ID: "l_" + clientID, Name: "list"}},
+		Segments:       []*clients.Segment{{ID: "s_" + clientID, Title: "segment", ListID: "l_" + clientID}},
+		Templates:      []*clients.Template{{ID: "t_" + clientID, Name: "template"}},
+		SmartEmails:    []*transactional.SmartEmailDetails{smartEmail("e_" + clientID)},
+	}
+	for _, email := range suppressions {
+		client.Suppressions = append(client.Suppressions, suppression(email))
+	}
+	return client
+}
+
+func TestExporter_Export(t *testing.T) {
+	apis := &apisStub{pageEntries: 1}
+	exporter := NewExporter(apis, WithPageSize(10))
+	exporter.now = func() time.Time { return created }
+
+	actual, err := exporter.Export()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expected := &Snapshot{
+		Version:   Version,
+		CreatedAt: created,
+		Clients: []*Client{
+			expectedClient("c1", "s1_0@c1.com", "s2_0@c1.com"),
+			expectedClient("c2", "s1_0@c2.com", "s2_0@c2.com"),
+		},
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Snapshot mismatch (-expected +actual):\n%s", diff)
+	}
+	if !strings.Contains(strings.Join(apis.calls, "\n"), "SuppressionList c1 10 2 email asc") {
+		t.Errorf("Expected the suppression list to be exported page by page, Actual calls: %v", apis.calls)
+	}
+}
+
+func TestExporter_Export_Failure(t *testing.T) {
+	calls := []string{
+		"Accounts.Clients",
+		"Get c1",
+		"People c1",
+		"PrimaryContact c1",
+		"Lists c1",
+		"Segments c1",
+		"Templates c1",
+		"SuppressionList c1 1000 2 email asc",
+		"SmartEmails c1",
+		"SmartEmail e_c1",
+		"Get c2",
+	}
+	for _, failOn := range calls {
+		t.Run(failOn, func(t *testing.T) {
+			apis := &apisStub{failOn: failOn}
+			actual, err := NewExporter(apis, WithPageSize(0)).Export()
+			if !errors.Is(err, errAPI) {
+				t.Fatalf("Expected error: %v, Actual: %v", errAPI, err)
+			}
+			if actual != nil {
+				t.Errorf("Expected a nil snapshot, Actual: %+v", actual)
+			}
+		})
+	}
+}
+
+func TestSnapshot_WriteRead(t *testing.T) {
+	apis := &apisStub{pageEntries: 2}
+	exporter := NewExporter(apis)
+	exporter.now = func() time.Time { return created }
+	expected, err := exporter.Export()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	var buf bytes.Buffer
+	if err := expected.Write(&buf); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Error("Expected the client API keys not to be exported")
+	}
+	if !strings.Contains(buf.String(), `"Rate": 9.99`) {
+		t.Errorf("Expected the billing rates to be exported exactly, Actual: %s", buf.String())
+	}
+
+	actual, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Snapshot mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestRead_Failure(t *testing.T) {
+	testCases := []struct {
+		title         string
+		input         string
+		expectedError error
+	}{
+		{
+			title:         "unsupported version",
+			input:         `{"Version":2}`,
+			expectedError: ErrUnsupportedVersion,
+		},
+		{
+			title:         "missing version",
+			input:         `{}`,
+			expectedError: ErrUnsupportedVersion,
+		},
+		{
+			title: "invalid json",
+			input: `{`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := Read(strings.NewReader(tC.input))
+			if err == nil {
+				t.Fatal("Expected an error, but received nil")
+			}
+			if tC.expectedError != nil && !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if actual != nil {
+				t.Errorf("Expected a nil snapshot, Actual: %+v", actual)
+			}
+		})
+	}
+}
+
+func TestRestorer_Restore(t *testing.T) {
+	snapshot := &Snapshot{
+		Version: Version,
+		Clients: []*Client{
+			expectedClient("c1", "x@y.com"),
+			expectedClient("c2"),
+		},
+	}
+	for i := 0; i < 101; i++ {
+		snapshot.Clients[1].Suppressions = append(snapshot.Clients[1].Suppressions, suppression(fmt.Sprintf("%d@y.com", i)))
+	}
+
+	apis := &apisStub{}
+	var progress int
+	restorer := NewRestorer(apis.Clients(),
+		WithPasswordGenerator(func(person *clients.PersonDetails) (string, error) {
+			return "pwd_" + person.EmailAddress, nil
+		}),
+		WithProgress(func(provision.Progress) { progress++ }),
+	)
+	actual, err := restorer.Restore(snapshot)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(map[string]string{"c1": "new1", "c2": "new2"}, actual); diff != "" {
+		t.Errorf("IDs mismatch (-expected +actual):\n%s", diff)
+	}
+	expectedCalls := []string{
+		"Create company c1",
+		"SetMonthlyBilling new1 AUD Basic 20",
+		"AddPerson new1 a@c1.com reports pwd_a@c1.com",
+		"SetPrimaryContact new1 a@c1.com",
+		"Suppress new1 1",
+		"Create company c2",
+		"SetPAYGBilling new2 AUD 0.15",
+		"AddPerson new2 a@c2.com reports pwd_a@c2.com",
+		"SetPrimaryContact new2 a@c2.com",
+		"Suppress new2 100",
+		"Suppress new2 1",
+	}
+	if diff := cmp.Diff(expectedCalls, apis.calls); diff != "" {
+		t.Errorf("Calls mismatch (-expected +actual):\n%s", diff)
+	}
+	if progress != 11 {
+		t.Errorf("Expected 11 progress reports, Actual: %d", progress)
+	}
+}
+
+func TestRestorer_Restore_Failure(t *testing.T) {
+	snapshot := &Snapshot{
+		Version: Version,
+		Clients: []*Client{expectedClient("c1", "x@y.com"), expectedClient("c2")},
+	}
+	testCases := []struct {
+		title         string
+		failOn        string
+		password      func(*clients.PersonDetails) (string, error)
+		keepPartial   bool
+		expectedIDs   map[string]string
+		expectedCalls []string
+	}{
+		{
+			title:         "provisioning failure",
+			failOn:        "Create company c2",
+			expectedIDs:   map[string]string{"c1": "new1"},
+			expectedCalls: []string{"Create company c2"},
+		},
+		{
+			title:         "rollback",
+			failOn:        "SetMonthlyBilling new1 AUD Basic 20",
+			expectedIDs:   map[string]string{},
+			expectedCalls: []string{"Create company c1", "SetMonthlyBilling new1 AUD Basic 20", "Delete new1"},
+		},
+		{
+			title:         "keep partial",
+			failOn:        "SetMonthlyBilling new1 AUD Basic 20",
+			keepPartial:   true,
+			expectedIDs:   map[string]string{},
+			expectedCalls: []string{"Create company c1", "SetMonthlyBilling new1 AUD Basic 20"},
+		},
+		{
+			title:         "suppression failure",
+			failOn:        "Suppress new1 1",
+			expectedIDs:   map[string]string{},
+			expectedCalls: []string{"SetPrimaryContact new1 a@c1.com", "Suppress new1 1", "Delete new1"},
+		},
+		{
+			title:         "suppression failure with partial state kept",
+			failOn:        "Suppress new1 1",
+			keepPartial:   true,
+			expectedIDs:   map[string]string{},
+			expectedCalls: []string{"SetPrimaryContact new1 a@c1.com", "Suppress new1 1"},
+		},
+		{
+			title: "password generator failure",
+			password: func(*clients.PersonDetails) (string, error) {
+				return "", errAPI
+			},
+			expectedIDs: map[string]string{},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			apis := &apisStub{failOn: tC.failOn}
+			restorer := NewRestorer(apis.Clients(), WithKeepPartial(tC.keepPartial), WithPasswordGenerator(tC.password))
+			actual, err := restorer.Restore(snapshot)
+			if !errors.Is(err, errAPI) {
+				t.Fatalf("Expected error: %v, Actual: %v", errAPI, err)
+			}
+			if diff := cmp.Diff(tC.expectedIDs, actual); diff != "" {
+				t.Errorf("IDs mismatch (-expected +actual):\n%s", diff)
+			}
+			calls := apis.calls
+			if len(calls) > len(tC.expectedCalls) {
+				calls = calls[len(calls)-len(tC.expectedCalls):]
+			}
+			if expected, actual := strings.Join(tC.expectedCalls, "\n"), strings.Join(calls, "\n"); actual != expected {
+				t.Errorf("Expected the last calls to be:\n%s\nActual:\n%s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestRestorer_Restore_UnsupportedVersion(t *testing.T) {
+	actual, err := NewRestorer(nil).Restore(&Snapshot{Version: 0})
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Fatalf("Expected error: %v, Actual: %v", ErrUnsupportedVersion, err)
+	}
+	if actual != nil {
+		t.Errorf("Expected nil IDs, Actual: %v", actual)
+	}
+}
+
+func TestRandomPassword(t *testing.T) {
+	first, err := randomPassword(nil)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	second, _ := randomPassword(nil)
+	if len(first) != 24 || first == second {
+		t.Errorf("Expected two different 24 character passwords, Actual: %q, %q", first, second)
+	}
+}
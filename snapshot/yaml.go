@@ -0,0 +1,124 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// WriteYAML writes the snapshot to w in YAML format.
+//
+// The document has the same structure and field names as the JSON snapshot.
+func (s *Snapshot) WriteYAML(w io.Writer) error {
+	// The snapshot is converted through JSON, so that the custom JSON marshallers of the field types are respected.
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return err
+	}
+	resetStyle(&node)
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// ReadYAML reads a YAML snapshot from r.
+func ReadYAML(r io.Reader) (*Snapshot, error) {
+	var node yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&node); err != nil {
+		return nil, err
+	}
+	b, err := nodeToJSON(&node)
+	if err != nil {
+		return nil, err
+	}
+	var s Snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, err
+	}
+	return s.validate()
+}
+
+// resetStyle removes the JSON (flow and double quoted) style from the node tree.
+//
+// The encoder still quotes the strings which would otherwise be resolved to a different type.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
+
+// nodeToJSON converts a YAML node into JSON, keeping the numbers as they are written in the document.
+func nodeToJSON(node *yaml.Node) ([]byte, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return []byte("null"), nil
+		}
+		return nodeToJSON(node.Content[0])
+	case yaml.AliasNode:
+		return nodeToJSON(node.Alias)
+	case yaml.MappingNode:
+		b := []byte{'{'}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			key, err := json.Marshal(node.Content[i].Value)
+			if err != nil {
+				return nil, err
+			}
+			value, err := nodeToJSON(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			b = append(append(append(b, key...), ':'), value...)
+		}
+		return append(b, '}'), nil
+	case yaml.SequenceNode:
+		b := []byte{'['}
+		for i, child := range node.Content {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			value, err := nodeToJSON(child)
+			if err != nil {
+				return nil, err
+			}
+			b = append(b, value...)
+		}
+		return append(b, ']'), nil
+	case yaml.ScalarNode:
+		switch node.ShortTag() {
+		case "!!null":
+			return []byte("null"), nil
+		case "!!bool":
+			var value bool
+			if err := node.Decode(&value); err != nil {
+				return nil, err
+			}
+			return json.Marshal(value)
+		case "!!int", "!!float":
+			if json.Valid([]byte(node.Value)) {
+				return []byte(node.Value), nil
+			}
+			var value float64
+			if err := node.Decode(&value); err != nil {
+				return nil, err
+			}
+			return json.Marshal(value)
+		default:
+			return json.Marshal(node.Value)
+		}
+	}
+	return nil, fmt.Errorf("unsupported YAML node at line %d", node.Line)
+}
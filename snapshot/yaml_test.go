@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSnapshot_WriteReadYAML(t *testing.T) {
+	apis := &apisStub{pageEntries: 2}
+	exporter := NewExporter(apis)
+	exporter.now = func() time.Time { return created }
+	expected, err := exporter.Export()
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	// Strings which look like other YAML types must survive the round-trip.
+	expected.Clients[0].PrimaryContact = "1e3"
+	expected.Clients[1].PrimaryContact = "true"
+
+	var buf bytes.Buffer
+	if err := expected.WriteYAML(&buf); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if strings.Contains(buf.String(), "secret") {
+		t.Error("Expected the client API keys not to be exported")
+	}
+	if !strings.Contains(buf.String(), "Rate: 9.99\n") {
+		t.Errorf("Expected the billing rates to be exported exactly, Actual: %s", buf.String())
+	}
+	if !strings.HasPrefix(buf.String(), "Version: 1\n") {
+		t.Errorf("Expected a block style YAML document, Actual: %s", buf.String())
+	}
+
+	actual, err := ReadYAML(&buf)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Snapshot mismatch (-expected +actual):\n%s", diff)
+	}
+}
+
+func TestReadYAML_Failure(t *testing.T) {
+	testCases := []struct {
+		title         string
+		input         string
+		expectedError error
+	}{
+		{
+			title:         "unsupported version",
+			input:         "Version: 2\n",
+			expectedError: ErrUnsupportedVersion,
+		},
+		{
+			title:         "missing version",
+			input:         "Clients: []\n",
+			expectedError: ErrUnsupportedVersion,
+		},
+		{
+			title: "invalid yaml",
+			input: "Version: [",
+		},
+		{
+			title: "invalid field type",
+			input: "Version: one\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := ReadYAML(strings.NewReader(tC.input))
+			if err == nil {
+				t.Fatal("Expected an error, but received nil")
+			}
+			if tC.expectedError != nil && !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if actual != nil {
+				t.Errorf("Expected a nil snapshot, Actual: %+v", actual)
+			}
+		})
+	}
+}
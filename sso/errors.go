@@ -0,0 +1,37 @@
+package sso
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnauthenticated occurs when the request is not authenticated.
+	ErrUnauthenticated = errors.New("the request is not authenticated")
+	// ErrMethodNotAllowed occurs when the request method is not GET.
+	ErrMethodNotAllowed = errors.New("the request method is not allowed")
+	// ErrInvalidURL occurs when the requested page is not a relative Campaign Monitor path.
+	ErrInvalidURL = errors.New("must be a relative Campaign Monitor path")
+	// ErrInvalidChrome occurs when the requested chrome is not one of all, tabs or none.
+	ErrInvalidChrome = errors.New("must be one of all, tabs, none")
+)
+
+// ParamError represents an invalid query parameter.
+type ParamError struct {
+	// Param the name of the query parameter.
+	Param string
+	// Value the invalid value.
+	Value string
+	// Err the validation error.
+	Err error
+}
+
+// Error returns the string representation of the error.
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("invalid %s parameter %q: %v", e.Param, e.Value, e.Err)
+}
+
+// Unwrap returns the validation error.
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,163 @@
+// Package sso provides an http.Handler which mints embedded Campaign Monitor sessions for the authenticated users of an application.
+package sso
+
+import (
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/xitonix/createsend/accounts"
+)
+
+const (
+	defaultURL = "/"
+
+	urlParam    = "url"
+	chromeParam = "chrome"
+	formatParam = "format"
+	jsonFormat  = "json"
+	jsonMime    = "application/json"
+)
+
+// Identity represents the Campaign Monitor person the session is minted for.
+type Identity struct {
+	// EmailAddress the email address of the person in the client.
+	EmailAddress string
+	// ClientID the ID of the client the person belongs to.
+	ClientID string
+}
+
+// Resolver returns the Campaign Monitor identity of the authenticated user of the request.
+//
+// The resolver must return ErrUnauthenticated (or an error wrapping it) if the request is not authenticated.
+type Resolver func(r *http.Request) (*Identity, error)
+
+// Session represents the JSON response of the handler.
+type Session struct {
+	// URL the single use login URL of the session.
+	URL string `json:"url"`
+}
+
+// Handler mints a new embedded session for the authenticated user on each GET request.
+//
+// The Campaign Monitor page and the navigation can be selected using the "url" and "chrome" query parameters
+// (eg. ?url=/subscribers&chrome=tabs). The handler redirects the browser to the session URL by default, or returns
+// a Session if the "format" query parameter is set to "json" or the request accepts application/json.
+type Handler struct {
+	api          accounts.API
+	integratorID string
+	resolve      Resolver
+	chrome       accounts.Chrome
+	url          string
+	errorHandler func(w http.ResponseWriter, r *http.Request, status int, err error)
+}
+
+// NewHandler creates a new session minting handler.
+func NewHandler(api accounts.API, integratorID string, resolve Resolver, options ...Option) *Handler {
+	h := &Handler{
+		api:          api,
+		integratorID: integratorID,
+		resolve:      resolve,
+		chrome:       accounts.AllChrome,
+		url:          defaultURL,
+		errorHandler: writeError,
+	}
+	for _, option := range options {
+		option(h)
+	}
+	return h
+}
+
+// ServeHTTP mints a new embedded session and redirects to, or returns the session URL.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		h.errorHandler(w, r, http.StatusMethodNotAllowed, ErrMethodNotAllowed)
+		return
+	}
+
+	session, err := h.session(r)
+	if err != nil {
+		h.errorHandler(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	identity, err := h.resolve(r)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrUnauthenticated) {
+			status = http.StatusUnauthorized
+		}
+		h.errorHandler(w, r, status, err)
+		return
+	}
+	if identity == nil {
+		h.errorHandler(w, r, http.StatusUnauthorized, ErrUnauthenticated)
+		return
+	}
+	session.EmailAddress = identity.EmailAddress
+	session.ClientID = identity.ClientID
+
+	sessionURL, err := h.api.NewEmbeddedSession(session)
+	if err != nil {
+		h.errorHandler(w, r, http.StatusBadGateway, err)
+		return
+	}
+
+	if !wantsJSON(r) {
+		http.Redirect(w, r, sessionURL, http.StatusFound)
+		return
+	}
+	w.Header().Set("Content-Type", jsonMime)
+	_ = json.NewEncoder(w).Encode(Session{URL: sessionURL})
+}
+
+func (h *Handler) session(r *http.Request) (accounts.EmbeddedSession, error) {
+	query := r.URL.Query()
+	session := accounts.EmbeddedSession{
+		Chrome:       h.chrome,
+		URL:          h.url,
+		IntegratorID: h.integratorID,
+	}
+	if value := query.Get(urlParam); len(value) > 0 {
+		if !accounts.IsValidSessionURL(value) {
+			return session, &ParamError{Param: urlParam, Value: value, Err: ErrInvalidURL}
+		}
+		session.URL = value
+	}
+	if value := query.Get(chromeParam); len(value) > 0 {
+		chrome := accounts.ParseChrome(value)
+		if !chrome.IsValid() {
+			return session, &ParamError{Param: chromeParam, Value: value, Err: ErrInvalidChrome}
+		}
+		session.Chrome = chrome
+	}
+	return session, nil
+}
+
+func wantsJSON(r *http.Request) bool {
+	if strings.EqualFold(r.URL.Query().Get(formatParam), jsonFormat) {
+		return true
+	}
+	for _, accept := range r.Header.Values("Accept") {
+		for _, value := range strings.Split(accept, ",") {
+			if mediaType, _, err := mime.ParseMediaType(value); err == nil && mediaType == jsonMime {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// writeError writes the status text of the error code, so that the server side errors are never leaked to the browser.
+func writeError(w http.ResponseWriter, _ *http.Request, status int, err error) {
+	var paramErr *ParamError
+	if errors.As(err, &paramErr) {
+		http.Error(w, paramErr.Error(), status)
+		return
+	}
+	http.Error(w, http.StatusText(status), status)
+}
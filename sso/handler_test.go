@@ -0,0 +1,262 @@
+package sso_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/sso"
+)
+
+const sessionURL = "https://example.createsend.com/login?token=abc"
+
+var errDeliberate = errors.New("deliberate error")
+
+type accountsStub struct {
+	accounts.API
+	sessions []accounts.EmbeddedSession
+	err      error
+}
+
+func (a *accountsStub) NewEmbeddedSession(session accounts.EmbeddedSession) (string, error) {
+	a.sessions = append(a.sessions, session)
+	if a.err != nil {
+		return "", a.err
+	}
+	return sessionURL, nil
+}
+
+func resolveAs(identity *sso.Identity, err error) sso.Resolver {
+	return func(*http.Request) (*sso.Identity, error) {
+		return identity, err
+	}
+}
+
+func TestHandler_ServeHTTP(t *testing.T) {
+	identity := &sso.Identity{EmailAddress: "a@b.com", ClientID: "client_id"}
+	session := func(chrome accounts.Chrome, url string) accounts.EmbeddedSession {
+		return accounts.EmbeddedSession{
+			EmailAddress: "a@b.com",
+			Chrome:       chrome,
+			URL:          url,
+			IntegratorID: "integrator_id",
+			ClientID:     "client_id",
+		}
+	}
+	testCases := []struct {
+		title            string
+		method           string
+		target           string
+		accept           string
+		options          []sso.Option
+		resolver         sso.Resolver
+		apiErr           error
+		expectedStatus   int
+		expectedLocation string
+		expectedBody     string
+		expectedSessions []accounts.EmbeddedSession
+	}{
+		{
+			title:            "redirect with the default options",
+			target:           "/sso",
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusFound,
+			expectedLocation: sessionURL,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+		{
+			title:            "redirect with custom default options",
+			target:           "/sso",
+			options:          []sso.Option{sso.WithChrome(accounts.NoChrome), sso.WithDefaultURL("/subscribers")},
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusFound,
+			expectedLocation: sessionURL,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.NoChrome, "/subscribers")},
+		},
+		{
+			title:            "invalid default options are ignored",
+			target:           "/sso",
+			options:          []sso.Option{sso.WithChrome(accounts.UnknownChrome), sso.WithDefaultURL("https://example.com"), sso.WithErrorHandler(nil)},
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusFound,
+			expectedLocation: sessionURL,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+		{
+			title:            "query parameters override the default options",
+			target:           "/sso?url=%2Fcampaigns%3Fpage%3D2&chrome=TABS",
+			options:          []sso.Option{sso.WithChrome(accounts.NoChrome), sso.WithDefaultURL("/subscribers")},
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusFound,
+			expectedLocation: sessionURL,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.TabsChrome, "/campaigns?page=2")},
+		},
+		{
+			title:            "json format parameter",
+			target:           "/sso?format=json",
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"url":"` + sessionURL + `"}`,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+		{
+			title:            "json accept header",
+			target:           "/sso",
+			accept:           "text/html, application/json;q=0.9",
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusOK,
+			expectedBody:     `{"url":"` + sessionURL + `"}`,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+		{
+			title:            "wildcard accept header",
+			target:           "/sso",
+			accept:           "*/*",
+			resolver:         resolveAs(identity, nil),
+			expectedStatus:   http.StatusFound,
+			expectedLocation: sessionURL,
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+		{
+			title:          "method not allowed",
+			method:         http.MethodPost,
+			target:         "/sso",
+			resolver:       resolveAs(identity, nil),
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   "Method Not Allowed",
+		},
+		{
+			title:          "absolute url",
+			target:         "/sso?url=https%3A%2F%2Fexample.com",
+			resolver:       resolveAs(identity, nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `invalid url parameter "https://example.com": must be a relative Campaign Monitor path`,
+		},
+		{
+			title:          "invalid chrome",
+			target:         "/sso?chrome=some",
+			resolver:       resolveAs(identity, nil),
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `invalid chrome parameter "some": must be one of all, tabs, none`,
+		},
+		{
+			title:          "unauthenticated request",
+			target:         "/sso",
+			resolver:       resolveAs(nil, sso.ErrUnauthenticated),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+		},
+		{
+			title:          "nil identity",
+			target:         "/sso",
+			resolver:       resolveAs(nil, nil),
+			expectedStatus: http.StatusUnauthorized,
+			expectedBody:   "Unauthorized",
+		},
+		{
+			title:          "resolver failure",
+			target:         "/sso",
+			resolver:       resolveAs(nil, errDeliberate),
+			expectedStatus: http.StatusInternalServerError,
+			expectedBody:   "Internal Server Error",
+		},
+		{
+			title:            "api failure",
+			target:           "/sso",
+			resolver:         resolveAs(identity, nil),
+			apiErr:           errDeliberate,
+			expectedStatus:   http.StatusBadGateway,
+			expectedBody:     "Bad Gateway",
+			expectedSessions: []accounts.EmbeddedSession{session(accounts.AllChrome, "/")},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			api := &accountsStub{err: tC.apiErr}
+			handler := sso.NewHandler(api, "integrator_id", tC.resolver, tC.options...)
+			method := tC.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			request := httptest.NewRequest(method, tC.target, nil)
+			if tC.accept != "" {
+				request.Header.Set("Accept", tC.accept)
+			}
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, request)
+
+			if recorder.Code != tC.expectedStatus {
+				t.Errorf("Expected status: %d, Actual: %d", tC.expectedStatus, recorder.Code)
+			}
+			if actual := recorder.Header().Get("Cache-Control"); actual != "no-store" {
+				t.Errorf("Expected Cache-Control: no-store, Actual: %q", actual)
+			}
+			if actual := recorder.Header().Get("Location"); actual != tC.expectedLocation {
+				t.Errorf("Expected location: %q, Actual: %q", tC.expectedLocation, actual)
+			}
+			if tC.expectedBody != "" {
+				if actual := strings.TrimSpace(recorder.Body.String()); actual != tC.expectedBody {
+					t.Errorf("Expected body: %s, Actual: %s", tC.expectedBody, actual)
+				}
+			}
+			if diff := cmp.Diff(tC.expectedSessions, api.sessions); diff != "" {
+				t.Errorf("Session mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_JSONResponse(t *testing.T) {
+	handler := sso.NewHandler(&accountsStub{}, "integrator_id", resolveAs(&sso.Identity{EmailAddress: "a@b.com", ClientID: "id"}, nil))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/sso?format=JSON", nil))
+
+	if actual := recorder.Header().Get("Content-Type"); actual != "application/json" {
+		t.Errorf("Expected content type: application/json, Actual: %q", actual)
+	}
+	var actual sso.Session
+	if err := json.NewDecoder(recorder.Body).Decode(&actual); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if diff := cmp.Diff(sso.Session{URL: sessionURL}, actual); diff != "" {
+		t.Errorf("Session mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestWithErrorHandler(t *testing.T) {
+	var (
+		actualStatus int
+		actualErr    error
+	)
+	handler := sso.NewHandler(&accountsStub{}, "integrator_id", resolveAs(nil, nil),
+		sso.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, status int, err error) {
+			actualStatus = status
+			actualErr = err
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	recorder := httptest.NewRecorder()
+
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/sso?chrome=some", nil))
+
+	if recorder.Code != http.StatusTeapot {
+		t.Errorf("Expected status: %d, Actual: %d", http.StatusTeapot, recorder.Code)
+	}
+	if actualStatus != http.StatusBadRequest {
+		t.Errorf("Expected status: %d, Actual: %d", http.StatusBadRequest, actualStatus)
+	}
+	if !errors.Is(actualErr, sso.ErrInvalidChrome) {
+		t.Errorf("Expected error: %v, Actual: %v", sso.ErrInvalidChrome, actualErr)
+	}
+	var paramErr *sso.ParamError
+	if !errors.As(actualErr, &paramErr) || paramErr.Param != "chrome" || paramErr.Value != "some" {
+		t.Errorf("Expected a chrome parameter error, Actual: %v", actualErr)
+	}
+}
@@ -0,0 +1,41 @@
+package sso
+
+import (
+	"net/http"
+
+	"github.com/xitonix/createsend/accounts"
+)
+
+// Option represents a handler option.
+type Option func(h *Handler)
+
+// WithChrome sets the navigation to show if the request does not specify the "chrome" query parameter (default AllChrome).
+func WithChrome(chrome accounts.Chrome) Option {
+	return func(h *Handler) {
+		if chrome.IsValid() {
+			h.chrome = chrome
+		}
+	}
+}
+
+// WithDefaultURL sets the page to load if the request does not specify the "url" query parameter (default "/").
+//
+// The URL is ignored if it is not a relative Campaign Monitor path.
+func WithDefaultURL(url string) Option {
+	return func(h *Handler) {
+		if accounts.IsValidSessionURL(url) {
+			h.url = url
+		}
+	}
+}
+
+// WithErrorHandler sets the function which writes the error responses.
+//
+// By default, the status text is written for all the errors except the invalid query parameters.
+func WithErrorHandler(handler func(w http.ResponseWriter, r *http.Request, status int, err error)) Option {
+	return func(h *Handler) {
+		if handler != nil {
+			h.errorHandler = handler
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package subscribers
+
+// API is an interface that wraps subscriber related operations.
+//
+// The API gives you access to the details of the subscribers of a list, and allows removing them from the list.
+type API interface {
+	// Get returns the details of the subscriber with the specified email address, including the custom fields.
+	Get(listID, emailAddress string) (*Details, error)
+	// Delete changes the state of the subscriber with the specified email address to deleted.
+	//
+	// Deleted subscribers are removed from the list, and are not emailed again unless they are added back to the list.
+	Delete(listID, emailAddress string) error
+}
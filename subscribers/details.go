@@ -0,0 +1,36 @@
+package subscribers
+
+import (
+	"time"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/transactional"
+)
+
+// CustomField represents the value of a custom field of a subscriber.
+type CustomField struct {
+	// Key the custom field key.
+	Key string
+	// Value the custom field value.
+	Value string
+}
+
+// Details represents the details of a subscriber.
+type Details struct {
+	// EmailAddress the email address.
+	EmailAddress string
+	// Name the name.
+	Name string
+	// Date the date the subscriber's state has last changed.
+	Date time.Time
+	// ListJoinedDate the date the subscriber has joined the list.
+	ListJoinedDate time.Time
+	// State the subscription state.
+	State clients.SubscriberState
+	// CustomFields the custom field values.
+	CustomFields []*CustomField
+	// ReadsEmailWith the email client the subscriber reads the emails with (eg. Gmail).
+	ReadsEmailWith string
+	// ConsentToTrack the subscriber's consent to track.
+	ConsentToTrack transactional.ConsentToTrack
+}
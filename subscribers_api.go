@@ -0,0 +1,42 @@
+package createsend
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/xitonix/createsend/internal"
+	"github.com/xitonix/createsend/subscribers"
+)
+
+type subscribersAPI struct {
+	client internal.Client
+}
+
+func newSubscribersAPI(client internal.Client) *subscribersAPI {
+	return &subscribersAPI{client: client}
+}
+
+func (s *subscribersAPI) Get(listID, emailAddress string) (*subscribers.Details, error) {
+	path := fmt.Sprintf("subscribers/%s.json?email=%s&includetrackingpreference=true", url.QueryEscape(listID), url.QueryEscape(emailAddress))
+	var details internal.SubscriberDetails
+	err := s.client.Get(path, &details)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := s.client.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := details.ToSubscriberDetails(location)
+	if err != nil {
+		return nil, newWrappedClientError("Failed to parse the subscriber details", err, ErrCodeDataProcessing)
+	}
+	return result, nil
+}
+
+func (s *subscribersAPI) Delete(listID, emailAddress string) error {
+	path := fmt.Sprintf("subscribers/%s.json?email=%s", url.QueryEscape(listID), url.QueryEscape(emailAddress))
+	return s.client.Delete(path)
+}
@@ -0,0 +1,179 @@
+package createsend
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/mock"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+func TestSubscribersAPI_Get(t *testing.T) {
+	testCases := []struct {
+		title                 string
+		forceHTTPClientError  bool
+		expectClientSideError bool
+		response              *http.Response
+		expected              *subscribers.Details
+		expectedError         error
+		oAuthAuthentication   bool
+	}{
+		{
+			title: "subscriber with custom fields",
+			response: &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`{
+					"EmailAddress": "a@b.com",
+					"Name": "Subscriber",
+					"Date": "2020-12-01 20:21:22",
+					"ListJoinedDate": "2020-11-01 10:11:12",
+					"State": "Active",
+					"CustomFields": [{"Key": "website", "Value": "https://example.com"}],
+					"ReadsEmailWith": "Gmail",
+					"ConsentToTrack": "Yes"
+				}`)),
+			},
+			expected: &subscribers.Details{
+				EmailAddress:   "a@b.com",
+				Name:           "Subscriber",
+				Date:           time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC),
+				ListJoinedDate: time.Date(2020, 11, 1, 10, 11, 12, 0, time.UTC),
+				State:          clients.ActiveSubscriber,
+				CustomFields:   []*subscribers.CustomField{{Key: "website", Value: "https://example.com"}},
+				ReadsEmailWith: "Gmail",
+				ConsentToTrack: transactional.ConsentGiven,
+			},
+		},
+		{
+			title: "subscriber without custom fields and list joined date",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress": "a@b.com", "Date": "2020-12-01 20:21:22", "State": "Deleted"}`)),
+			},
+			expected: &subscribers.Details{
+				EmailAddress: "a@b.com",
+				Date:         time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC),
+				State:        clients.DeletedSubscriber,
+				CustomFields: []*subscribers.CustomField{},
+			},
+			oAuthAuthentication: true,
+		},
+		{
+			title: "invalid date value",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress": "a@b.com", "Date": "invalid"}`)),
+			},
+			expectedError:         newClientError(ErrCodeDataProcessing),
+			expectClientSideError: true,
+		},
+		{
+			title: "invalid list joined date value",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"EmailAddress": "a@b.com", "Date": "2020-12-01 20:21:22", "ListJoinedDate": "invalid"}`)),
+			},
+			expectedError:         newClientError(ErrCodeDataProcessing),
+			expectClientSideError: true,
+		},
+		{
+			title:                "simulate remote call failure",
+			response:             &http.Response{},
+			forceHTTPClientError: true,
+			expectedError:        mock.ErrDeliberate,
+		},
+		{
+			title: "simulate server side error",
+			response: &http.Response{
+				StatusCode: 500,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Message":"msg", "Code":500}`)),
+			},
+			expectedError: &Error{Code: 500},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient := createClient(t, tC.oAuthAuthentication, tC.forceHTTPClientError)
+			httpClient.SetResponse("subscribers/list_id.json", tC.response)
+			actual, err := client.Subscribers().Get("list_id", "a@b.com")
+			if err != nil {
+				if !checkError(err, tC.expectedError) {
+					t.Errorf("Expected '%v' error, actual: '%v'", tC.expectedError, err)
+				}
+				checkErrorType(t, err, !tC.expectClientSideError && !tC.forceHTTPClientError)
+			}
+
+			checkQueryStringParameters(t, httpClient.LastRequest(), map[string]string{
+				"email":                     "a@b.com",
+				"includetrackingpreference": "true",
+			})
+
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSubscribersAPI_Delete(t *testing.T) {
+	testCases := []struct {
+		title                string
+		forceHTTPClientError bool
+		response             *http.Response
+		expectedError        error
+		oAuthAuthentication  bool
+	}{
+		{
+			title: "successful deletion",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(&bytes.Buffer{}),
+			},
+		},
+		{
+			title: "oAuth authentication",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(&bytes.Buffer{}),
+			},
+			oAuthAuthentication: true,
+		},
+		{
+			title:                "simulate remote call failure",
+			response:             &http.Response{},
+			forceHTTPClientError: true,
+			expectedError:        mock.ErrDeliberate,
+		},
+		{
+			title: "simulate server side error",
+			response: &http.Response{
+				StatusCode: 500,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Message":"msg", "Code":500}`)),
+			},
+			expectedError: &Error{Code: 500},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient := createClient(t, tC.oAuthAuthentication, tC.forceHTTPClientError)
+			httpClient.SetResponse("subscribers/list_id.json", tC.response)
+			err := client.Subscribers().Delete("list_id", "a@b.com")
+			if err != nil {
+				if !checkError(err, tC.expectedError) {
+					t.Errorf("Expected '%v' error, actual: '%v'", tC.expectedError, err)
+				}
+				checkErrorType(t, err, !tC.forceHTTPClientError)
+			}
+			checkQueryStringParameters(t, httpClient.LastRequest(), map[string]string{"email": "a@b.com"})
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package timezone maps the Campaign Monitor timezone names to the IANA time zone database.
+package timezone
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownTimezone occurs when the value is not a Campaign Monitor timezone.
+	ErrUnknownTimezone = errors.New("unknown Campaign Monitor timezone")
+	// ErrNoMatchingTimezone occurs when none of the Campaign Monitor timezones matches a location.
+	ErrNoMatchingTimezone = errors.New("no matching Campaign Monitor timezone")
+)
+
+// Timezone represents a Campaign Monitor timezone (eg. "(GMT+10:00) Canberra, Melbourne, Sydney").
+type Timezone string
+
+// Parse returns the Campaign Monitor timezone of the value.
+//
+// The value must be one of the timezones returned by All. Leading and trailing spaces are ignored.
+func Parse(value string) (Timezone, error) {
+	tz := Timezone(strings.TrimSpace(value))
+	if _, ok := ianaNames[tz]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownTimezone, value)
+	}
+	return tz, nil
+}
+
+// FromLocation returns the Campaign Monitor timezone of the location.
+//
+// If the location is not explicitly mapped to a Campaign Monitor timezone, the first timezone
+// which has the same UTC offsets as the location in January and July of the current year will be returned.
+func FromLocation(location *time.Location) (Timezone, error) {
+	if location == nil {
+		return "", fmt.Errorf("%w: nil location", ErrNoMatchingTimezone)
+	}
+	if tz, ok := fromIANAName[location.String()]; ok {
+		return tz, nil
+	}
+	if tz, ok := aliases[location.String()]; ok {
+		return tz, nil
+	}
+
+	year := time.Now().Year()
+	january, july := offsets(location, year)
+	for _, tz := range all {
+		loc, err := tz.Location()
+		if err != nil {
+			continue
+		}
+		jan, jul := offsets(loc, year)
+		if jan == january && jul == july {
+			return tz, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrNoMatchingTimezone, location)
+}
+
+// All returns all the known Campaign Monitor timezones, ordered by their UTC offsets.
+func All() []Timezone {
+	result := make([]Timezone, len(all))
+	copy(result, all)
+	return result
+}
+
+// String returns the Campaign Monitor name of the timezone.
+func (t Timezone) String() string {
+	return string(t)
+}
+
+// IANAName returns the IANA time zone database name of the timezone (eg. "Australia/Sydney").
+func (t Timezone) IANAName() (string, bool) {
+	name, ok := ianaNames[t]
+	return name, ok
+}
+
+// Location loads the IANA location of the timezone.
+func (t Timezone) Location() (*time.Location, error) {
+	name, ok := ianaNames[t]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTimezone, string(t))
+	}
+	return time.LoadLocation(name)
+}
+
+func offsets(location *time.Location, year int) (january, july int) {
+	_, january = time.Date(year, time.January, 1, 12, 0, 0, 0, location).Zone()
+	_, july = time.Date(year, time.July, 1, 12, 0, 0, 0, location).Zone()
+	return january, july
+}
@@ -0,0 +1,165 @@
+package timezone
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTimezone_Location(t *testing.T) {
+	for _, tz := range All() {
+		t.Run(tz.String(), func(t *testing.T) {
+			location, err := tz.Location()
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			name, ok := tz.IANAName()
+			if !ok || location.String() != name {
+				t.Errorf("Expected location: %s, Actual: %s", name, location)
+			}
+			back, err := FromLocation(location)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if back != tz {
+				t.Errorf("Expected timezone: %s, Actual: %s", tz, back)
+			}
+		})
+	}
+}
+
+func TestTimezone_LocationOfUnknownTimezone(t *testing.T) {
+	location, err := Timezone("(GMT+10:00) Narnia").Location()
+	if !errors.Is(err, ErrUnknownTimezone) {
+		t.Errorf("Expected '%v' error, Actual: '%v'", ErrUnknownTimezone, err)
+	}
+	if location != nil {
+		t.Error("The location must be nil on error")
+	}
+	if _, ok := Timezone("").IANAName(); ok {
+		t.Error("An empty timezone must not have an IANA name")
+	}
+}
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		title         string
+		input         string
+		expected      Timezone
+		expectedError error
+	}{
+		{
+			title:    "known timezone",
+			input:    "(GMT+10:00) Canberra, Melbourne, Sydney",
+			expected: "(GMT+10:00) Canberra, Melbourne, Sydney",
+		},
+		{
+			title:    "known timezone with surrounding spaces",
+			input:    "  (GMT) Dublin, Edinburgh, Lisbon, London ",
+			expected: "(GMT) Dublin, Edinburgh, Lisbon, London",
+		},
+		{
+			title:         "unknown timezone",
+			input:         "Australia/Sydney",
+			expectedError: ErrUnknownTimezone,
+		},
+		{
+			title:         "empty value",
+			expectedError: ErrUnknownTimezone,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := Parse(tC.input)
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+			}
+			if actual != tC.expected {
+				t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromLocation(t *testing.T) {
+	testCases := []struct {
+		title         string
+		location      *time.Location
+		expected      Timezone
+		expectedError error
+	}{
+		{
+			title:    "mapped location",
+			location: mustLoad(t, "Australia/Sydney"),
+			expected: "(GMT+10:00) Canberra, Melbourne, Sydney",
+		},
+		{
+			title:    "aliased location",
+			location: mustLoad(t, "Australia/Melbourne"),
+			expected: "(GMT+10:00) Canberra, Melbourne, Sydney",
+		},
+		{
+			title:    "UTC",
+			location: time.UTC,
+			expected: "(GMT) Coordinated Universal Time",
+		},
+		{
+			title:    "location with the same offsets",
+			location: mustLoad(t, "America/Detroit"),
+			expected: "(GMT-05:00) Eastern Time (US & Canada)",
+		},
+		{
+			title:    "fixed zone",
+			location: time.FixedZone("custom", 9*60*60),
+			expected: "(GMT+09:00) Osaka, Sapporo, Tokyo",
+		},
+		{
+			title:         "location without matching offsets",
+			location:      time.FixedZone("custom", 14*60*60+17*60),
+			expectedError: ErrNoMatchingTimezone,
+		},
+		{
+			title:         "nil location",
+			expectedError: ErrNoMatchingTimezone,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := FromLocation(tC.location)
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+			}
+			if actual != tC.expected {
+				t.Errorf("Expected: %q, Actual: %q", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAliases(t *testing.T) {
+	for name, tz := range aliases {
+		if _, ok := ianaNames[tz]; !ok {
+			t.Errorf("The %s alias refers to an unknown timezone %q", name, tz)
+		}
+	}
+}
+
+func TestAll(t *testing.T) {
+	timezones := All()
+	if len(timezones) != len(mapping) {
+		t.Fatalf("Expected %d timezones, Actual: %d", len(mapping), len(timezones))
+	}
+	timezones[0] = "modified"
+	if All()[0] == "modified" {
+		t.Error("All must return a copy of the timezones")
+	}
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("Failed to load %s: %v", name, err)
+	}
+	return location
+}
@@ -0,0 +1,184 @@
+package timezone
+
+// mapping the Campaign Monitor timezones and their IANA names, ordered by UTC offset.
+var mapping = []struct {
+	timezone Timezone
+	iana     string
+}{
+	{"(GMT-12:00) International Date Line West", "Etc/GMT+12"},
+	{"(GMT-11:00) Coordinated Universal Time-11", "Etc/GMT+11"},
+	{"(GMT-10:00) Hawaii", "Pacific/Honolulu"},
+	{"(GMT-09:00) Alaska", "America/Anchorage"},
+	{"(GMT-08:00) Baja California", "America/Tijuana"},
+	{"(GMT-08:00) Pacific Time (US & Canada)", "America/Los_Angeles"},
+	{"(GMT-07:00) Arizona", "America/Phoenix"},
+	{"(GMT-07:00) Chihuahua, La Paz, Mazatlan", "America/Chihuahua"},
+	{"(GMT-07:00) Mountain Time (US & Canada)", "America/Denver"},
+	{"(GMT-06:00) Central America", "America/Guatemala"},
+	{"(GMT-06:00) Central Time (US & Canada)", "America/Chicago"},
+	{"(GMT-06:00) Guadalajara, Mexico City, Monterrey", "America/Mexico_City"},
+	{"(GMT-06:00) Saskatchewan", "America/Regina"},
+	{"(GMT-05:00) Bogota, Lima, Quito", "America/Bogota"},
+	{"(GMT-05:00) Eastern Time (US & Canada)", "America/New_York"},
+	{"(GMT-05:00) Indiana (East)", "America/Indiana/Indianapolis"},
+	{"(GMT-04:30) Caracas", "America/Caracas"},
+	{"(GMT-04:00) Asuncion", "America/Asuncion"},
+	{"(GMT-04:00) Atlantic Time (Canada)", "America/Halifax"},
+	{"(GMT-04:00) Cuiaba", "America/Cuiaba"},
+	{"(GMT-04:00) Georgetown, La Paz, Manaus, San Juan", "America/La_Paz"},
+	{"(GMT-04:00) Santiago", "America/Santiago"},
+	{"(GMT-03:30) Newfoundland", "America/St_Johns"},
+	{"(GMT-03:00) Brasilia", "America/Sao_Paulo"},
+	{"(GMT-03:00) Buenos Aires", "America/Argentina/Buenos_Aires"},
+	{"(GMT-03:00) Cayenne, Fortaleza", "America/Cayenne"},
+	{"(GMT-03:00) Greenland", "America/Godthab"},
+	{"(GMT-03:00) Montevideo", "America/Montevideo"},
+	{"(GMT-03:00) Salvador", "America/Bahia"},
+	{"(GMT-02:00) Coordinated Universal Time-02", "Etc/GMT+2"},
+	{"(GMT-02:00) Mid-Atlantic", "Atlantic/South_Georgia"},
+	{"(GMT-01:00) Azores", "Atlantic/Azores"},
+	{"(GMT-01:00) Cape Verde Is.", "Atlantic/Cape_Verde"},
+	{"(GMT) Casablanca", "Africa/Casablanca"},
+	{"(GMT) Coordinated Universal Time", "UTC"},
+	{"(GMT) Dublin, Edinburgh, Lisbon, London", "Europe/London"},
+	{"(GMT) Monrovia, Reykjavik", "Atlantic/Reykjavik"},
+	{"(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna", "Europe/Berlin"},
+	{"(GMT+01:00) Belgrade, Bratislava, Budapest, Ljubljana, Prague", "Europe/Budapest"},
+	{"(GMT+01:00) Brussels, Copenhagen, Madrid, Paris", "Europe/Paris"},
+	{"(GMT+01:00) Sarajevo, Skopje, Warsaw, Zagreb", "Europe/Warsaw"},
+	{"(GMT+01:00) West Central Africa", "Africa/Lagos"},
+	{"(GMT+01:00) Windhoek", "Africa/Windhoek"},
+	{"(GMT+02:00) Amman", "Asia/Amman"},
+	{"(GMT+02:00) Athens, Bucharest", "Europe/Bucharest"},
+	{"(GMT+02:00) Beirut", "Asia/Beirut"},
+	{"(GMT+02:00) Cairo", "Africa/Cairo"},
+	{"(GMT+02:00) Damascus", "Asia/Damascus"},
+	{"(GMT+02:00) Harare, Pretoria", "Africa/Johannesburg"},
+	{"(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius", "Europe/Kiev"},
+	{"(GMT+02:00) Istanbul", "Europe/Istanbul"},
+	{"(GMT+02:00) Jerusalem", "Asia/Jerusalem"},
+	{"(GMT+02:00) Kaliningrad", "Europe/Kaliningrad"},
+	{"(GMT+03:00) Baghdad", "Asia/Baghdad"},
+	{"(GMT+03:00) Kuwait, Riyadh", "Asia/Riyadh"},
+	{"(GMT+03:00) Minsk", "Europe/Minsk"},
+	{"(GMT+03:00) Moscow, St. Petersburg, Volgograd", "Europe/Moscow"},
+	{"(GMT+03:00) Nairobi", "Africa/Nairobi"},
+	{"(GMT+03:30) Tehran", "Asia/Tehran"},
+	{"(GMT+04:00) Abu Dhabi, Muscat", "Asia/Dubai"},
+	{"(GMT+04:00) Baku", "Asia/Baku"},
+	{"(GMT+04:00) Port Louis", "Indian/Mauritius"},
+	{"(GMT+04:00) Tbilisi", "Asia/Tbilisi"},
+	{"(GMT+04:00) Yerevan", "Asia/Yerevan"},
+	{"(GMT+04:30) Kabul", "Asia/Kabul"},
+	{"(GMT+05:00) Ekaterinburg", "Asia/Yekaterinburg"},
+	{"(GMT+05:00) Islamabad, Karachi", "Asia/Karachi"},
+	{"(GMT+05:00) Tashkent", "Asia/Tashkent"},
+	{"(GMT+05:30) Chennai, Kolkata, Mumbai, New Delhi", "Asia/Kolkata"},
+	{"(GMT+05:30) Sri Jayawardenepura", "Asia/Colombo"},
+	{"(GMT+05:45) Kathmandu", "Asia/Kathmandu"},
+	{"(GMT+06:00) Astana", "Asia/Almaty"},
+	{"(GMT+06:00) Dhaka", "Asia/Dhaka"},
+	{"(GMT+06:00) Novosibirsk", "Asia/Novosibirsk"},
+	{"(GMT+06:30) Yangon (Rangoon)", "Asia/Rangoon"},
+	{"(GMT+07:00) Bangkok, Hanoi, Jakarta", "Asia/Bangkok"},
+	{"(GMT+07:00) Krasnoyarsk", "Asia/Krasnoyarsk"},
+	{"(GMT+08:00) Beijing, Chongqing, Hong Kong, Urumqi", "Asia/Shanghai"},
+	{"(GMT+08:00) Irkutsk", "Asia/Irkutsk"},
+	{"(GMT+08:00) Kuala Lumpur, Singapore", "Asia/Singapore"},
+	{"(GMT+08:00) Perth", "Australia/Perth"},
+	{"(GMT+08:00) Taipei", "Asia/Taipei"},
+	{"(GMT+08:00) Ulaanbaatar", "Asia/Ulaanbaatar"},
+	{"(GMT+09:00) Osaka, Sapporo, Tokyo", "Asia/Tokyo"},
+	{"(GMT+09:00) Seoul", "Asia/Seoul"},
+	{"(GMT+09:00) Yakutsk", "Asia/Yakutsk"},
+	{"(GMT+09:30) Adelaide", "Australia/Adelaide"},
+	{"(GMT+09:30) Darwin", "Australia/Darwin"},
+	{"(GMT+10:00) Brisbane", "Australia/Brisbane"},
+	{"(GMT+10:00) Canberra, Melbourne, Sydney", "Australia/Sydney"},
+	{"(GMT+10:00) Guam, Port Moresby", "Pacific/Port_Moresby"},
+	{"(GMT+10:00) Hobart", "Australia/Hobart"},
+	{"(GMT+10:00) Vladivostok", "Asia/Vladivostok"},
+	{"(GMT+11:00) Magadan", "Asia/Magadan"},
+	{"(GMT+11:00) Solomon Is., New Caledonia", "Pacific/Guadalcanal"},
+	{"(GMT+12:00) Auckland, Wellington", "Pacific/Auckland"},
+	{"(GMT+12:00) Coordinated Universal Time+12", "Etc/GMT-12"},
+	{"(GMT+12:00) Fiji", "Pacific/Fiji"},
+	{"(GMT+13:00) Nuku'alofa", "Pacific/Tongatapu"},
+	{"(GMT+13:00) Samoa", "Pacific/Apia"},
+}
+
+// aliases the IANA names of the cities and regions which are explicitly listed by the Campaign Monitor timezones.
+var aliases = map[string]Timezone{
+	"Etc/UTC":              "(GMT) Coordinated Universal Time",
+	"Etc/GMT":              "(GMT) Coordinated Universal Time",
+	"Europe/Dublin":        "(GMT) Dublin, Edinburgh, Lisbon, London",
+	"Europe/Lisbon":        "(GMT) Dublin, Edinburgh, Lisbon, London",
+	"Africa/Monrovia":      "(GMT) Monrovia, Reykjavik",
+	"Europe/Amsterdam":     "(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna",
+	"Europe/Zurich":        "(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna",
+	"Europe/Rome":          "(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna",
+	"Europe/Stockholm":     "(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna",
+	"Europe/Vienna":        "(GMT+01:00) Amsterdam, Berlin, Bern, Rome, Stockholm, Vienna",
+	"Europe/Belgrade":      "(GMT+01:00) Belgrade, Bratislava, Budapest, Ljubljana, Prague",
+	"Europe/Bratislava":    "(GMT+01:00) Belgrade, Bratislava, Budapest, Ljubljana, Prague",
+	"Europe/Ljubljana":     "(GMT+01:00) Belgrade, Bratislava, Budapest, Ljubljana, Prague",
+	"Europe/Prague":        "(GMT+01:00) Belgrade, Bratislava, Budapest, Ljubljana, Prague",
+	"Europe/Brussels":      "(GMT+01:00) Brussels, Copenhagen, Madrid, Paris",
+	"Europe/Copenhagen":    "(GMT+01:00) Brussels, Copenhagen, Madrid, Paris",
+	"Europe/Madrid":        "(GMT+01:00) Brussels, Copenhagen, Madrid, Paris",
+	"Europe/Sarajevo":      "(GMT+01:00) Sarajevo, Skopje, Warsaw, Zagreb",
+	"Europe/Skopje":        "(GMT+01:00) Sarajevo, Skopje, Warsaw, Zagreb",
+	"Europe/Zagreb":        "(GMT+01:00) Sarajevo, Skopje, Warsaw, Zagreb",
+	"Europe/Athens":        "(GMT+02:00) Athens, Bucharest",
+	"Africa/Harare":        "(GMT+02:00) Harare, Pretoria",
+	"Europe/Kyiv":          "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Europe/Helsinki":      "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Europe/Riga":          "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Europe/Sofia":         "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Europe/Tallinn":       "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Europe/Vilnius":       "(GMT+02:00) Helsinki, Kyiv, Riga, Sofia, Tallinn, Vilnius",
+	"Asia/Kuwait":          "(GMT+03:00) Kuwait, Riyadh",
+	"Europe/Volgograd":     "(GMT+03:00) Moscow, St. Petersburg, Volgograd",
+	"Asia/Muscat":          "(GMT+04:00) Abu Dhabi, Muscat",
+	"Asia/Calcutta":        "(GMT+05:30) Chennai, Kolkata, Mumbai, New Delhi",
+	"Asia/Katmandu":        "(GMT+05:45) Kathmandu",
+	"Asia/Yangon":          "(GMT+06:30) Yangon (Rangoon)",
+	"Asia/Ho_Chi_Minh":     "(GMT+07:00) Bangkok, Hanoi, Jakarta",
+	"Asia/Jakarta":         "(GMT+07:00) Bangkok, Hanoi, Jakarta",
+	"Asia/Chongqing":       "(GMT+08:00) Beijing, Chongqing, Hong Kong, Urumqi",
+	"Asia/Hong_Kong":       "(GMT+08:00) Beijing, Chongqing, Hong Kong, Urumqi",
+	"Asia/Urumqi":          "(GMT+08:00) Beijing, Chongqing, Hong Kong, Urumqi",
+	"Asia/Kuala_Lumpur":    "(GMT+08:00) Kuala Lumpur, Singapore",
+	"Australia/Canberra":   "(GMT+10:00) Canberra, Melbourne, Sydney",
+	"Australia/Melbourne":  "(GMT+10:00) Canberra, Melbourne, Sydney",
+	"Australia/ACT":        "(GMT+10:00) Canberra, Melbourne, Sydney",
+	"Pacific/Guam":         "(GMT+10:00) Guam, Port Moresby",
+	"Pacific/Noumea":       "(GMT+11:00) Solomon Is., New Caledonia",
+	"America/Nuuk":         "(GMT-03:00) Greenland",
+	"America/Indianapolis": "(GMT-05:00) Indiana (East)",
+	"America/Lima":         "(GMT-05:00) Bogota, Lima, Quito",
+	"America/Mazatlan":     "(GMT-07:00) Chihuahua, La Paz, Mazatlan",
+	"America/Monterrey":    "(GMT-06:00) Guadalajara, Mexico City, Monterrey",
+	"America/Manaus":       "(GMT-04:00) Georgetown, La Paz, Manaus, San Juan",
+	"America/Guyana":       "(GMT-04:00) Georgetown, La Paz, Manaus, San Juan",
+	"America/Puerto_Rico":  "(GMT-04:00) Georgetown, La Paz, Manaus, San Juan",
+	"America/Fortaleza":    "(GMT-03:00) Cayenne, Fortaleza",
+	"Pacific/Midway":       "(GMT-11:00) Coordinated Universal Time-11",
+}
+
+var (
+	all          []Timezone
+	ianaNames    map[Timezone]string
+	fromIANAName map[string]Timezone
+)
+
+func init() {
+	all = make([]Timezone, len(mapping))
+	ianaNames = make(map[Timezone]string, len(mapping))
+	fromIANAName = make(map[string]Timezone, len(mapping))
+	for i, m := range mapping {
+		all[i] = m.timezone
+		ianaNames[m.timezone] = m.iana
+		fromIANAName[m.iana] = m.timezone
+	}
+}
@@ -10,4 +10,11 @@ type API interface {
 	SmartEmails(options ...Option) ([]*SmartEmailBasicDetails, error)
 	// SmartEmail returns the details of a smart transactional email.
 	SmartEmail(smartEmailID string) (*SmartEmailDetails, error)
+	// MessageTimeline returns the most recent transactional messages, newest first.
+	//
+	// Use WithClientID, WithMessageStatus, WithSmartEmailID, WithGroup and WithCount to filter the messages,
+	// and WithSentBeforeID or WithSentAfterID to page through the timeline.
+	MessageTimeline(options ...Option) ([]*Message, error)
+	// SendSmartEmail sends a smart transactional email, and returns the result for each recipient.
+	SendSmartEmail(smartEmailID string, message SmartEmailMessage) ([]*SendResult, error)
 }
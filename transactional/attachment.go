@@ -0,0 +1,197 @@
+package transactional
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MaxTotalAttachmentSize the maximum total size of the attachments of an email in bytes, before encoding.
+	//
+	// Campaign Monitor rejects the transactional emails whose attachments exceed 4.5 MB in total.
+	MaxTotalAttachmentSize = 4718592
+
+	sniffLength = 512
+)
+
+var (
+	// ErrAttachmentTooLarge occurs when the size of an attachment exceeds the maximum attachment size.
+	//
+	// The maximum attachment size is MaxTotalAttachmentSize, unless a lower limit is set using WithMaxAttachmentSize.
+	ErrAttachmentTooLarge = errors.New("the attachment is too large")
+	// ErrAttachmentsTooLarge occurs when the total size of the attachments exceeds MaxTotalAttachmentSize.
+	ErrAttachmentsTooLarge = errors.New("the total size of the attachments is too large")
+	// ErrEmptyAttachmentName occurs when the name of an attachment is empty.
+	ErrEmptyAttachmentName = errors.New("the attachment name must not be empty")
+)
+
+// Attachment represents a base64 encoded email attachment.
+type Attachment struct {
+	// Name the file name of the attachment (eg. invoice.pdf).
+	Name string
+	// Type the MIME type of the attachment (eg. application/pdf).
+	Type string
+	// Content the base64 encoded content.
+	Content string
+}
+
+// Size returns the size of the content in bytes, before encoding.
+func (a *Attachment) Size() int64 {
+	size := int64(base64.StdEncoding.DecodedLen(len(a.Content)))
+	return size - int64(len(a.Content)-len(strings.TrimRight(a.Content, "=")))
+}
+
+type attachmentOptions struct {
+	contentType string
+	maxSize     int64
+}
+
+// AttachmentOption represents an attachment option.
+type AttachmentOption func(options *attachmentOptions)
+
+// WithContentType sets the MIME type of the attachment instead of detecting it.
+func WithContentType(contentType string) AttachmentOption {
+	return func(options *attachmentOptions) {
+		options.contentType = contentType
+	}
+}
+
+// WithMaxAttachmentSize sets the maximum size of the attachment in bytes (default MaxTotalAttachmentSize).
+//
+// The size cannot be raised above MaxTotalAttachmentSize.
+func WithMaxAttachmentSize(size int64) AttachmentOption {
+	return func(options *attachmentOptions) {
+		if size > 0 && size <= MaxTotalAttachmentSize {
+			options.maxSize = size
+		}
+	}
+}
+
+// NewAttachment reads the content of the attachment from r, and encodes it on the fly so that
+// only the encoded content is held in memory.
+//
+// If r has a Stat method (eg. *os.File or fs.File), the encoded content is allocated upfront using the file size.
+//
+// The MIME type is detected from the file extension of the name, or from the content if the extension is not known.
+// Reading stops as soon as the attachment exceeds the maximum size, in which case ErrAttachmentTooLarge is returned.
+func NewAttachment(name string, r io.Reader, options ...AttachmentOption) (*Attachment, error) {
+	if len(strings.TrimSpace(name)) == 0 {
+		return nil, ErrEmptyAttachmentName
+	}
+	ops := &attachmentOptions{maxSize: MaxTotalAttachmentSize}
+	for _, op := range options {
+		op(ops)
+	}
+
+	var encoded strings.Builder
+	if size, ok := sizeOf(r); ok && size <= ops.maxSize {
+		encoded.Grow(base64.StdEncoding.EncodedLen(int(size)))
+	}
+	encoder := base64.NewEncoder(base64.StdEncoding, &encoded)
+	head := &sniffer{}
+	size, err := io.Copy(encoder, io.TeeReader(io.LimitReader(r, ops.maxSize+1), head))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the attachment %q: %w", name, err)
+	}
+	if size > ops.maxSize {
+		return nil, fmt.Errorf("%w: %q is larger than %d bytes", ErrAttachmentTooLarge, name, ops.maxSize)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode the attachment %q: %w", name, err)
+	}
+
+	contentType := ops.contentType
+	if contentType == "" {
+		contentType = detectContentType(name, head.bytes)
+	}
+	return &Attachment{
+		Name:    name,
+		Type:    contentType,
+		Content: encoded.String(),
+	}, nil
+}
+
+// AttachmentFromFile reads the attachment from the file at the specified path.
+//
+// The base name of the file is used as the attachment name.
+func AttachmentFromFile(filePath string, options ...AttachmentOption) (*Attachment, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewAttachment(filepath.Base(filePath), f, options...)
+}
+
+// AttachmentFromFS reads the attachment from the named file of the file system (eg. an embed.FS).
+//
+// The base name of the file is used as the attachment name.
+func AttachmentFromFS(fsys fs.FS, name string, options ...AttachmentOption) (*Attachment, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return NewAttachment(path.Base(name), f, options...)
+}
+
+// ValidateAttachments returns an error if any of the attachments is nil, or the attachments exceed
+// MaxTotalAttachmentSize in total.
+func ValidateAttachments(attachments ...*Attachment) error {
+	var total int64
+	for i, attachment := range attachments {
+		if attachment == nil {
+			return fmt.Errorf("attachment %d must not be nil", i)
+		}
+		total += attachment.Size()
+	}
+	if total > MaxTotalAttachmentSize {
+		return fmt.Errorf("%w: %d bytes exceeds %d bytes", ErrAttachmentsTooLarge, total, MaxTotalAttachmentSize)
+	}
+	return nil
+}
+
+// sizeOf returns the size of the content if r can report it.
+func sizeOf(r io.Reader) (int64, bool) {
+	s, ok := r.(interface{ Stat() (fs.FileInfo, error) })
+	if !ok {
+		return 0, false
+	}
+	info, err := s.Stat()
+	if err != nil || !info.Mode().IsRegular() {
+		return 0, false
+	}
+	return info.Size(), true
+}
+
+// detectContentType detects the MIME type from the file extension, or from the first 512 bytes of the content.
+func detectContentType(name string, head []byte) string {
+	if contentType := mime.TypeByExtension(path.Ext(name)); contentType != "" {
+		return contentType
+	}
+	return http.DetectContentType(head)
+}
+
+// sniffer keeps the first 512 bytes written to it.
+type sniffer struct {
+	bytes []byte
+}
+
+func (s *sniffer) Write(p []byte) (int, error) {
+	if remaining := sniffLength - len(s.bytes); remaining > 0 {
+		if len(p) < remaining {
+			remaining = len(p)
+		}
+		s.bytes = append(s.bytes, p[:remaining]...)
+	}
+	return len(p), nil
+}
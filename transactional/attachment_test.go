@@ -0,0 +1,226 @@
+package transactional_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+var errRead = errors.New("read error")
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errRead
+}
+
+func encode(content string) string {
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+func TestNewAttachment(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 600)
+	testCases := []struct {
+		title         string
+		name          string
+		content       string
+		options       []transactional.AttachmentOption
+		expected      *transactional.Attachment
+		expectedSize  int64
+		expectedError error
+	}{
+		{
+			title:        "type from the extension",
+			name:         "invoice.pdf",
+			content:      "%PDF-1.4",
+			expected:     &transactional.Attachment{Name: "invoice.pdf", Type: "application/pdf", Content: encode("%PDF-1.4")},
+			expectedSize: 8,
+		},
+		{
+			title:        "type from the content",
+			name:         "image",
+			content:      png,
+			expected:     &transactional.Attachment{Name: "image", Type: "image/png", Content: encode(png)},
+			expectedSize: int64(len(png)),
+		},
+		{
+			title:        "explicit type",
+			name:         "data.bin",
+			content:      "ab",
+			options:      []transactional.AttachmentOption{transactional.WithContentType("application/x-custom")},
+			expected:     &transactional.Attachment{Name: "data.bin", Type: "application/x-custom", Content: encode("ab")},
+			expectedSize: 2,
+		},
+		{
+			title:        "empty content",
+			name:         "empty",
+			expected:     &transactional.Attachment{Name: "empty", Type: "text/plain; charset=utf-8"},
+			expectedSize: 0,
+		},
+		{
+			title:        "exactly the maximum size",
+			name:         "notes",
+			content:      "abcd",
+			options:      []transactional.AttachmentOption{transactional.WithMaxAttachmentSize(4), transactional.WithMaxAttachmentSize(0)},
+			expected:     &transactional.Attachment{Name: "notes", Type: "text/plain; charset=utf-8", Content: encode("abcd")},
+			expectedSize: 4,
+		},
+		{
+			title:         "too large",
+			name:          "notes",
+			content:       "abcde",
+			options:       []transactional.AttachmentOption{transactional.WithMaxAttachmentSize(4)},
+			expectedError: transactional.ErrAttachmentTooLarge,
+		},
+		{
+			title:         "empty name",
+			name:          " ",
+			content:       "abc",
+			expectedError: transactional.ErrEmptyAttachmentName,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := transactional.NewAttachment(tC.name, strings.NewReader(tC.content), tC.options...)
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Attachment mismatch (-want +got):\n%s", diff)
+			}
+			if actual != nil && actual.Size() != tC.expectedSize {
+				t.Errorf("Expected size: %d, Actual: %d", tC.expectedSize, actual.Size())
+			}
+		})
+	}
+}
+
+func TestNewAttachment_ReadFailure(t *testing.T) {
+	_, err := transactional.NewAttachment("name", failingReader{})
+	if !errors.Is(err, errRead) {
+		t.Errorf("Expected error: %v, Actual: %v", errRead, err)
+	}
+}
+
+func TestNewAttachment_LargeContent(t *testing.T) {
+	content := bytes.Repeat([]byte{1, 2, 3}, transactional.MaxTotalAttachmentSize/3)
+	actual, err := transactional.NewAttachment("large.bin", bytes.NewReader(content), transactional.WithMaxAttachmentSize(transactional.MaxTotalAttachmentSize))
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if actual.Size() != int64(len(content)) {
+		t.Errorf("Expected size: %d, Actual: %d", len(content), actual.Size())
+	}
+	if actual.Content != base64.StdEncoding.EncodeToString(content) {
+		t.Error("The content has not been encoded correctly")
+	}
+
+	tooLarge := append(content, bytes.Repeat([]byte{0}, 10)...)
+	_, err = transactional.NewAttachment("large.bin", bytes.NewReader(tooLarge), transactional.WithMaxAttachmentSize(transactional.MaxTotalAttachmentSize+1))
+	if !errors.Is(err, transactional.ErrAttachmentTooLarge) {
+		t.Errorf("Expected error: %v, Actual: %v", transactional.ErrAttachmentTooLarge, err)
+	}
+}
+
+func TestAttachmentFromFile(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "report.pdf")
+	if err := os.WriteFile(filePath, []byte("%PDF-1.4"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	actual, err := transactional.AttachmentFromFile(filePath)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expected := &transactional.Attachment{Name: "report.pdf", Type: "application/pdf", Content: encode("%PDF-1.4")}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Attachment mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = transactional.AttachmentFromFile(filepath.Join(dir, "missing.pdf"))
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected error: %v, Actual: %v", fs.ErrNotExist, err)
+	}
+}
+
+func TestAttachmentFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/terms.html": {Data: []byte("<html><body>Terms</body></html>")},
+	}
+
+	actual, err := transactional.AttachmentFromFS(fsys, "docs/terms.html")
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	expected := &transactional.Attachment{Name: "terms.html", Type: "text/html; charset=utf-8", Content: encode("<html><body>Terms</body></html>")}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("Attachment mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = transactional.AttachmentFromFS(fsys, "missing.html")
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected error: %v, Actual: %v", fs.ErrNotExist, err)
+	}
+}
+
+func TestValidateAttachments(t *testing.T) {
+	half := &transactional.Attachment{Name: "half", Content: base64.StdEncoding.EncodeToString(make([]byte, transactional.MaxTotalAttachmentSize/2))}
+	testCases := []struct {
+		title         string
+		attachments   []*transactional.Attachment
+		expectedError error
+		expectedMsg   string
+	}{
+		{title: "no attachments"},
+		{title: "within the limit", attachments: []*transactional.Attachment{half, half}},
+		{
+			title:         "exceeding the limit",
+			attachments:   []*transactional.Attachment{half, half, {Name: "one", Content: encode("1")}},
+			expectedError: transactional.ErrAttachmentsTooLarge,
+		},
+		{
+			title:         "single attachment exceeding the limit",
+			attachments:   []*transactional.Attachment{{Name: "large", Content: base64.StdEncoding.EncodeToString(make([]byte, transactional.MaxTotalAttachmentSize+1))}},
+			expectedError: transactional.ErrAttachmentsTooLarge,
+		},
+		{
+			title:       "nil attachment",
+			attachments: []*transactional.Attachment{half, nil},
+			expectedMsg: "attachment 1 must not be nil",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			err := transactional.ValidateAttachments(tC.attachments...)
+			if tC.expectedMsg != "" {
+				if err == nil || err.Error() != tC.expectedMsg {
+					t.Errorf("Expected error: %q, Actual: %v", tC.expectedMsg, err)
+				}
+				return
+			}
+			if !errors.Is(err, tC.expectedError) {
+				t.Errorf("Expected error: %v, Actual: %v", tC.expectedError, err)
+			}
+		})
+	}
+}
+
+func TestAttachment_Size(t *testing.T) {
+	for _, content := range []string{"", "a", "ab", "abc", "abcd", "abcde"} {
+		attachment := &transactional.Attachment{Content: encode(content)}
+		if actual := attachment.Size(); actual != int64(len(content)) {
+			t.Errorf("%q: Expected size: %d, Actual: %d", content, len(content), actual)
+		}
+	}
+}
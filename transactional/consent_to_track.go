@@ -0,0 +1,72 @@
+package transactional
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ConsentToTrack represents the recipient's consent to have their email opens and clicks tracked.
+type ConsentToTrack uint8
+
+const (
+	// UnknownConsent unknown consent.
+	UnknownConsent ConsentToTrack = iota
+	// ConsentGiven the recipient has consented to tracking.
+	ConsentGiven
+	// ConsentRefused the recipient has not consented to tracking.
+	ConsentRefused
+	// ConsentUnchanged keeps the recipient's current consent to tracking.
+	ConsentUnchanged
+)
+
+const (
+	unknownConsentStr   = `unknown`
+	consentGivenStr     = `Yes`
+	consentRefusedStr   = `No`
+	consentUnchangedStr = `Unchanged`
+)
+
+var (
+	consentToTrackToValue = map[string]ConsentToTrack{
+		strings.ToLower(consentGivenStr):     ConsentGiven,
+		strings.ToLower(consentRefusedStr):   ConsentRefused,
+		strings.ToLower(consentUnchangedStr): ConsentUnchanged,
+	}
+
+	consentToTrackFromValue = map[ConsentToTrack]string{
+		ConsentGiven:     consentGivenStr,
+		ConsentRefused:   consentRefusedStr,
+		ConsentUnchanged: consentUnchangedStr,
+	}
+)
+
+// IsValid returns true if the consent is one of Yes, No or Unchanged.
+func (c ConsentToTrack) IsValid() bool {
+	_, ok := consentToTrackFromValue[c]
+	return ok
+}
+
+// MarshalJSON marshal the object into json bytes.
+func (c ConsentToTrack) MarshalJSON() ([]byte, error) {
+	typeStr, ok := consentToTrackFromValue[c]
+	if !ok {
+		return json.Marshal(unknownConsentStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (c *ConsentToTrack) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	consent, ok := consentToTrackToValue[value]
+	if !ok {
+		consent = UnknownConsent
+	}
+	*c = consent
+	return nil
+}
+
+// String Stringer implementation
+func (c ConsentToTrack) String() string {
+	return consentToTrackFromValue[c]
+}
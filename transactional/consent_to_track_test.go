@@ -0,0 +1,66 @@
+package transactional
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestConsentToTrack_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		consent  ConsentToTrack
+		expected string
+	}{
+		{title: "Unknown", expected: fmt.Sprintf("%q", unknownConsentStr)},
+		{title: "Given", consent: ConsentGiven, expected: `"Yes"`},
+		{title: "Refused", consent: ConsentRefused, expected: `"No"`},
+		{title: "Unchanged", consent: ConsentUnchanged, expected: `"Unchanged"`},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.consent.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestConsentToTrack_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		consent       string
+		expected      ConsentToTrack
+		expectedValid bool
+	}{
+		{consent: "random", expected: UnknownConsent},
+		{consent: "yes", expected: ConsentGiven, expectedValid: true},
+		{consent: "No", expected: ConsentRefused, expectedValid: true},
+		{consent: "UNCHANGED", expected: ConsentUnchanged, expectedValid: true},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.consent, func(t *testing.T) {
+			var actual ConsentToTrack
+			err := actual.UnmarshalJSON([]byte(fmt.Sprintf("%q", tC.consent)))
+			if err != nil {
+				t.Errorf("Expected no errors, but received %s", err)
+			}
+			if tC.expected != actual {
+				t.Errorf("Expected %v, Actual: %v", tC.expected, actual)
+			}
+			if actual.IsValid() != tC.expectedValid {
+				t.Errorf("Expected valid: %v, Actual: %v", tC.expectedValid, actual.IsValid())
+			}
+		})
+	}
+}
+
+func TestConsentToTrack_String(t *testing.T) {
+	if actual := UnknownConsent.String(); actual != "" {
+		t.Errorf("Expected an empty string, Actual: %q", actual)
+	}
+	if actual := ConsentGiven.String(); actual != consentGivenStr {
+		t.Errorf("Expected %q, Actual: %q", consentGivenStr, actual)
+	}
+}
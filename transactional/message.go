@@ -0,0 +1,29 @@
+package transactional
+
+import "time"
+
+// Message represents a sent transactional message.
+type Message struct {
+	// ID message ID.
+	ID string
+	// Status the delivery status of the message.
+	Status MessageStatus
+	// SentAt the time when the message was sent.
+	SentAt time.Time
+	// Recipient the recipient of the message (eg. "Joe Smith <joe@example.com>").
+	Recipient string
+	// From the sender of the message.
+	From string
+	// Subject subject line.
+	Subject string
+	// TotalOpens the number of times the message has been opened.
+	TotalOpens int
+	// TotalClicks the number of times the links of the message have been clicked.
+	TotalClicks int
+	// CanBeResent is true if the message can be resent.
+	CanBeResent bool
+	// SmartEmailID the ID of the smart email the message was sent from, if any.
+	SmartEmailID string
+	// Group the group of the classic email, if any.
+	Group string
+}
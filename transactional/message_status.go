@@ -0,0 +1,66 @@
+package transactional
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// MessageStatus represents the delivery status of a transactional message.
+type MessageStatus uint8
+
+const (
+	// UnknownMessage unknown status.
+	UnknownMessage MessageStatus = iota
+	// DeliveredMessage the message has been delivered.
+	DeliveredMessage
+	// BouncedMessage the message has bounced.
+	BouncedMessage
+	// SpamMessage the message has been marked as spam by the recipient.
+	SpamMessage
+)
+
+const (
+	unknownMessageStr   = `unknown`
+	deliveredMessageStr = `delivered`
+	bouncedMessageStr   = `bounced`
+	spamMessageStr      = `spam`
+)
+
+var (
+	messageStatusToValue = map[string]MessageStatus{
+		deliveredMessageStr: DeliveredMessage,
+		bouncedMessageStr:   BouncedMessage,
+		spamMessageStr:      SpamMessage,
+	}
+
+	messageStatusFromValue = map[MessageStatus]string{
+		DeliveredMessage: deliveredMessageStr,
+		BouncedMessage:   bouncedMessageStr,
+		SpamMessage:      spamMessageStr,
+	}
+)
+
+// MarshalJSON marshal the object into json bytes.
+func (m MessageStatus) MarshalJSON() ([]byte, error) {
+	typeStr, ok := messageStatusFromValue[m]
+	if !ok {
+		return json.Marshal(unknownMessageStr)
+	}
+	return json.Marshal(typeStr)
+}
+
+// UnmarshalJSON unmarshal json bytes back to object.
+func (m *MessageStatus) UnmarshalJSON(b []byte) error {
+	value := strings.ToLower(strings.Trim(string(b), "\""))
+	ms, ok := messageStatusToValue[value]
+	if !ok {
+		ms = UnknownMessage
+	}
+	*m = ms
+	return nil
+}
+
+// String Stringer implementation
+func (m MessageStatus) String() string {
+	return messageStatusFromValue[m]
+}
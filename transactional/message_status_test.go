@@ -0,0 +1,74 @@
+package transactional
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMessageStatus_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		status   MessageStatus
+		expected string
+	}{
+		{title: "Unknown", expected: fmt.Sprintf("%q", unknownMessageStr)},
+		{title: "Delivered", status: DeliveredMessage, expected: fmt.Sprintf("%q", deliveredMessageStr)},
+		{title: "Bounced", status: BouncedMessage, expected: fmt.Sprintf("%q", bouncedMessageStr)},
+		{title: "Spam", status: SpamMessage, expected: fmt.Sprintf("%q", spamMessageStr)},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			marshalled, _ := tC.status.MarshalJSON()
+			if tC.expected != string(marshalled) {
+				t.Errorf("Expected %s, Actual: %s", tC.expected, string(marshalled))
+			}
+		})
+	}
+}
+
+func TestMessageStatus_UnmarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		expected MessageStatus
+		status   string
+	}{
+		{title: "Unknown", status: "Unknown", expected: UnknownMessage},
+		{title: "random string", status: "random", expected: UnknownMessage},
+		{title: "delivered lowercase", status: "delivered", expected: DeliveredMessage},
+		{title: "delivered mixed case", status: "Delivered", expected: DeliveredMessage},
+		{title: "bounced", status: "Bounced", expected: BouncedMessage},
+		{title: "spam", status: "SPAM", expected: SpamMessage},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			var actual MessageStatus
+			err := actual.UnmarshalJSON([]byte(fmt.Sprintf("%q", tC.status)))
+			if err != nil {
+				t.Errorf("Expected no errors, but received %s", err)
+			}
+			if tC.expected != actual {
+				t.Errorf("Expected %v, Actual: %v", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMessageStatus_String(t *testing.T) {
+	testCases := []struct {
+		status   MessageStatus
+		expected string
+	}{
+		{status: UnknownMessage, expected: ""},
+		{status: DeliveredMessage, expected: deliveredMessageStr},
+		{status: BouncedMessage, expected: bouncedMessageStr},
+		{status: SpamMessage, expected: spamMessageStr},
+	}
+
+	for _, tC := range testCases {
+		if actual := tC.status.String(); actual != tC.expected {
+			t.Errorf("Expected %q, Actual: %q", tC.expected, actual)
+		}
+	}
+}
@@ -4,6 +4,12 @@ package transactional
 type Options struct {
 	clientID         string
 	smartEmailStatus SmartEmailStatus
+	messageStatus    MessageStatus
+	smartEmailID     string
+	group            string
+	sentBeforeID     string
+	sentAfterID      string
+	count            int
 }
 
 // Option represents a Transactional API option.
@@ -32,3 +38,75 @@ func WithSmartEmailStatus(status SmartEmailStatus) Option {
 func (o *Options) SmartEmailStatus() SmartEmailStatus {
 	return o.smartEmailStatus
 }
+
+// WithMessageStatus sets the optional message status to filter the message timeline by.
+func WithMessageStatus(status MessageStatus) Option {
+	return func(options *Options) {
+		options.messageStatus = status
+	}
+}
+
+// MessageStatus returns the optional message status.
+func (o *Options) MessageStatus() MessageStatus {
+	return o.messageStatus
+}
+
+// WithSmartEmailID sets the optional smart email ID to filter the message timeline by.
+func WithSmartEmailID(smartEmailID string) Option {
+	return func(options *Options) {
+		options.smartEmailID = smartEmailID
+	}
+}
+
+// SmartEmailID returns the optional smart email ID.
+func (o *Options) SmartEmailID() string {
+	return o.smartEmailID
+}
+
+// WithGroup sets the optional classic email group to filter the message timeline by.
+func WithGroup(group string) Option {
+	return func(options *Options) {
+		options.group = group
+	}
+}
+
+// Group returns the optional classic email group.
+func (o *Options) Group() string {
+	return o.group
+}
+
+// WithSentBeforeID limits the message timeline to the messages sent before the specified message.
+func WithSentBeforeID(messageID string) Option {
+	return func(options *Options) {
+		options.sentBeforeID = messageID
+	}
+}
+
+// SentBeforeID returns the optional ID of the message the timeline must end before.
+func (o *Options) SentBeforeID() string {
+	return o.sentBeforeID
+}
+
+// WithSentAfterID limits the message timeline to the messages sent after the specified message.
+func WithSentAfterID(messageID string) Option {
+	return func(options *Options) {
+		options.sentAfterID = messageID
+	}
+}
+
+// SentAfterID returns the optional ID of the message the timeline must start after.
+func (o *Options) SentAfterID() string {
+	return o.sentAfterID
+}
+
+// WithCount sets the maximum number of messages to return from the message timeline (between 1 and 200).
+func WithCount(count int) Option {
+	return func(options *Options) {
+		options.count = count
+	}
+}
+
+// Count returns the optional maximum number of messages.
+func (o *Options) Count() int {
+	return o.count
+}
@@ -38,3 +38,35 @@ func TestWithSmartEmailStatus(t *testing.T) {
 		t.Errorf("Expected smart email status: %s, Actual: %s", expected, actual)
 	}
 }
+
+func TestMessageTimelineOptions(t *testing.T) {
+	ops := &transactional.Options{}
+	for _, option := range []transactional.Option{
+		transactional.WithMessageStatus(transactional.BouncedMessage),
+		transactional.WithSmartEmailID("smart_email_id"),
+		transactional.WithGroup("group"),
+		transactional.WithSentBeforeID("before"),
+		transactional.WithSentAfterID("after"),
+		transactional.WithCount(10),
+	} {
+		option(ops)
+	}
+	if ops.MessageStatus() != transactional.BouncedMessage {
+		t.Errorf("Expected message status: %s, Actual: %s", transactional.BouncedMessage, ops.MessageStatus())
+	}
+	if ops.SmartEmailID() != "smart_email_id" {
+		t.Errorf("Expected smart email ID: smart_email_id, Actual: %s", ops.SmartEmailID())
+	}
+	if ops.Group() != "group" {
+		t.Errorf("Expected group: group, Actual: %s", ops.Group())
+	}
+	if ops.SentBeforeID() != "before" {
+		t.Errorf("Expected sent before ID: before, Actual: %s", ops.SentBeforeID())
+	}
+	if ops.SentAfterID() != "after" {
+		t.Errorf("Expected sent after ID: after, Actual: %s", ops.SentAfterID())
+	}
+	if ops.Count() != 10 {
+		t.Errorf("Expected count: 10, Actual: %d", ops.Count())
+	}
+}
@@ -0,0 +1,32 @@
+package transactional
+
+// SmartEmailMessage represents a smart transactional email to send.
+type SmartEmailMessage struct {
+	// To the recipients (eg. "Joe Smith <joe@example.com>").
+	To []string
+	// CC the optional carbon copy recipients.
+	CC []string `json:",omitempty"`
+	// BCC the optional blind carbon copy recipients.
+	BCC []string `json:",omitempty"`
+	// Attachments the optional attachments.
+	Attachments []*Attachment `json:",omitempty"`
+	// Data the values of the email variables, keyed by variable name.
+	Data map[string]interface{} `json:",omitempty"`
+	// AddRecipientsToList adds the recipients to the subscriber list of the smart email, if any.
+	AddRecipientsToList bool
+	// ConsentToTrack the recipients' consent to tracking.
+	//
+	// Campaign Monitor requires the consent to be specified. UnknownConsent is omitted from the request,
+	// so that the server rejects the email instead of receiving a made-up value.
+	ConsentToTrack ConsentToTrack `json:",omitempty"`
+}
+
+// SendResult represents the outcome of sending a transactional email to a recipient.
+type SendResult struct {
+	// MessageID the ID of the sent message.
+	MessageID string
+	// Status the status of the message (eg. Accepted).
+	Status string
+	// Recipient the recipient of the message.
+	Recipient string
+}
@@ -0,0 +1,58 @@
+package transactional_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend/transactional"
+)
+
+func TestSmartEmailMessage_MarshalJSON(t *testing.T) {
+	testCases := []struct {
+		title    string
+		message  transactional.SmartEmailMessage
+		expected string
+	}{
+		{
+			title: "minimal",
+			message: transactional.SmartEmailMessage{
+				To:             []string{"joe@example.com"},
+				ConsentToTrack: transactional.ConsentUnchanged,
+			},
+			expected: `{"To":["joe@example.com"],"AddRecipientsToList":false,"ConsentToTrack":"Unchanged"}`,
+		},
+		{
+			title:    "unknown consent",
+			message:  transactional.SmartEmailMessage{To: []string{"joe@example.com"}},
+			expected: `{"To":["joe@example.com"],"AddRecipientsToList":false}`,
+		},
+		{
+			title: "full",
+			message: transactional.SmartEmailMessage{
+				To:                  []string{"Joe <joe@example.com>"},
+				CC:                  []string{"cc@example.com"},
+				BCC:                 []string{"bcc@example.com"},
+				Attachments:         []*transactional.Attachment{{Name: "a.txt", Type: "text/plain", Content: "YQ=="}},
+				Data:                map[string]interface{}{"name": "Joe"},
+				AddRecipientsToList: true,
+				ConsentToTrack:      transactional.ConsentGiven,
+			},
+			expected: `{"To":["Joe \u003cjoe@example.com\u003e"],"CC":["cc@example.com"],"BCC":["bcc@example.com"],` +
+				`"Attachments":[{"Name":"a.txt","Type":"text/plain","Content":"YQ=="}],"Data":{"name":"Joe"},` +
+				`"AddRecipientsToList":true,"ConsentToTrack":"Yes"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			actual, err := json.Marshal(tC.message)
+			if err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if diff := cmp.Diff(tC.expected, string(actual)); diff != "" {
+				t.Errorf("Body mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -35,6 +35,11 @@ var (
 	}
 )
 
+// ParseSmartEmailStatus returns the smart email status with the specified name (case-insensitive), or UnknownSmartEmail.
+func ParseSmartEmailStatus(value string) SmartEmailStatus {
+	return smartEmailStatusToValue[strings.ToLower(strings.TrimSpace(value))]
+}
+
 // MarshalJSON marshal the object into json bytes.
 func (r SmartEmailStatus) MarshalJSON() ([]byte, error) {
 	typeStr, ok := smartEmailStatusFromValue[r]
@@ -46,12 +51,7 @@ func (r SmartEmailStatus) MarshalJSON() ([]byte, error) {
 
 // UnmarshalJSON unmarshal json bytes back to object.
 func (r *SmartEmailStatus) UnmarshalJSON(b []byte) error {
-	value := strings.ToLower(strings.Trim(string(b), "\""))
-	rt, ok := smartEmailStatusToValue[value]
-	if !ok {
-		rt = UnknownSmartEmail
-	}
-	*r = rt
+	*r = ParseSmartEmailStatus(strings.Trim(string(b), "\""))
 	return nil
 }
 
@@ -88,3 +88,24 @@ func TestSmartEmailStatus_UnmarshalJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSmartEmailStatus(t *testing.T) {
+	testCases := []struct {
+		value    string
+		expected SmartEmailStatus
+	}{
+		{value: "", expected: UnknownSmartEmail},
+		{value: "unknown", expected: UnknownSmartEmail},
+		{value: "random", expected: UnknownSmartEmail},
+		{value: "active", expected: ActiveSmartEmail},
+		{value: " Active ", expected: ActiveSmartEmail},
+		{value: "draft", expected: DraftSmartEmail},
+		{value: "DRAFT", expected: DraftSmartEmail},
+	}
+
+	for _, tC := range testCases {
+		if actual := ParseSmartEmailStatus(tC.value); actual != tC.expected {
+			t.Errorf("Expected %q to be parsed as %v, Actual: %v", tC.value, tC.expected, actual)
+		}
+	}
+}
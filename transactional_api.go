@@ -3,6 +3,7 @@ package createsend
 import (
 	"fmt"
 	"net/url"
+	"strconv"
 
 	"github.com/xitonix/createsend/internal"
 	"github.com/xitonix/createsend/transactional"
@@ -33,7 +34,12 @@ func (t *transactionalAPI) SmartEmail(smartEmailID string) (*transactional.Smart
 		return nil, err
 	}
 
-	result, err := smartEmail.ToSmartEmailDetails()
+	location, err := t.client.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := smartEmail.ToSmartEmailDetails(location)
 	if err != nil {
 		return nil, newWrappedClientError("Failed to parse the smart email details", err, ErrCodeDataProcessing)
 	}
@@ -41,6 +47,67 @@ func (t *transactionalAPI) SmartEmail(smartEmailID string) (*transactional.Smart
 	return result, nil
 }
 
+func (t *transactionalAPI) MessageTimeline(options ...transactional.Option) ([]*transactional.Message, error) {
+	ops := &transactional.Options{}
+	for _, op := range options {
+		op(ops)
+	}
+
+	status := "all"
+	if ops.MessageStatus() != transactional.UnknownMessage {
+		status = ops.MessageStatus().String()
+	}
+	query := url.Values{}
+	query.Set("status", status)
+	optional := map[string]string{
+		"clientID":     ops.ClientID(),
+		"smartEmailID": ops.SmartEmailID(),
+		"group":        ops.Group(),
+		"sentBeforeID": ops.SentBeforeID(),
+		"sentAfterID":  ops.SentAfterID(),
+	}
+	for key, value := range optional {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if ops.Count() > 0 {
+		query.Set("count", strconv.Itoa(ops.Count()))
+	}
+
+	var messages []internal.Message
+	err := t.client.Get("transactional/messages?"+query.Encode(), &messages)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := t.client.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*transactional.Message, len(messages))
+	for i, raw := range messages {
+		message, err := raw.ToMessage(location)
+		if err != nil {
+			return nil, newWrappedClientError("Failed to parse the transactional message", err, ErrCodeDataProcessing)
+		}
+		result[i] = message
+	}
+
+	return result, nil
+}
+
+func (t *transactionalAPI) SendSmartEmail(smartEmailID string, message transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+	path := fmt.Sprintf("transactional/smartEmail/%s/send", url.QueryEscape(smartEmailID))
+	result := make([]*transactional.SendResult, 0)
+	err := t.client.Post(path, &result, message)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (t *transactionalAPI) smartEmailsByStatus(status transactional.SmartEmailStatus, clientID string) ([]*transactional.SmartEmailBasicDetails, error) {
 	var statusParam string
 	switch status {
@@ -60,9 +127,14 @@ func (t *transactionalAPI) smartEmailsByStatus(status transactional.SmartEmailSt
 		return nil, err
 	}
 
+	location, err := t.client.Location()
+	if err != nil {
+		return nil, err
+	}
+
 	result := make([]*transactional.SmartEmailBasicDetails, len(smartEmails))
 	for i, raw := range smartEmails {
-		smartEmail, err := raw.ToSmartEmailBasicDetails()
+		smartEmail, err := raw.ToSmartEmailBasicDetails(location)
 		if err != nil {
 			return nil, newWrappedClientError("Failed to parse the smart email basic details", err, ErrCodeDataProcessing)
 		}
@@ -654,3 +654,222 @@ func TestTransactionalAPI_SmartEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionalAPI_MessageTimeline(t *testing.T) {
+	testCases := []struct {
+		title                 string
+		options               []transactional.Option
+		forceHTTPClientError  bool
+		expectClientSideError bool
+		response              *http.Response
+		expected              []*transactional.Message
+		expectedQuery         map[string]string
+		expectedError         error
+		oAuthAuthentication   bool
+	}{
+		{
+			title: "no messages",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			expected:      []*transactional.Message{},
+			expectedQuery: map[string]string{"status": "all"},
+		},
+		{
+			title: "with messages",
+			response: &http.Response{
+				StatusCode: 200,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`[
+				{
+					"MessageID": "id1",
+					"Status": "Delivered",
+					"SentAt": "2020-12-01T20:21:22+10:00",
+					"Recipient": "Joe Smith <joe@example.com>",
+					"From": "Team <team@example.com>",
+					"Subject": "Welcome",
+					"TotalOpens": 2,
+					"TotalClicks": 1,
+					"CanBeResent": true,
+					"SmartEmailID": "smart_email_id"
+				},
+				{
+					"MessageID": "id2",
+					"Status": "Bounced",
+					"SentAt": "2020-12-01T20:21:22",
+					"Recipient": "joe@example.com",
+					"From": "team@example.com",
+					"Subject": "Sign up",
+					"Group": "group"
+				}
+			]`)),
+			},
+			expected: []*transactional.Message{
+				{
+					ID:           "id1",
+					Status:       transactional.DeliveredMessage,
+					SentAt:       time.Date(2020, 12, 1, 20, 21, 22, 0, time.FixedZone("", 10*60*60)),
+					Recipient:    "Joe Smith <joe@example.com>",
+					From:         "Team <team@example.com>",
+					Subject:      "Welcome",
+					TotalOpens:   2,
+					TotalClicks:  1,
+					CanBeResent:  true,
+					SmartEmailID: "smart_email_id",
+				},
+				{
+					ID:        "id2",
+					Status:    transactional.BouncedMessage,
+					SentAt:    time.Date(2020, 12, 1, 20, 21, 22, 0, time.UTC),
+					Recipient: "joe@example.com",
+					From:      "team@example.com",
+					Subject:   "Sign up",
+					Group:     "group",
+				},
+			},
+			expectedQuery: map[string]string{"status": "all"},
+		},
+		{
+			title: "filtered",
+			options: []transactional.Option{
+				transactional.WithClientID("client_id"),
+				transactional.WithMessageStatus(transactional.SpamMessage),
+				transactional.WithSmartEmailID("smart_email_id"),
+				transactional.WithGroup("group"),
+				transactional.WithSentBeforeID("before"),
+				transactional.WithSentAfterID("after"),
+				transactional.WithCount(50),
+			},
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[]`)),
+			},
+			expected: []*transactional.Message{},
+			expectedQuery: map[string]string{
+				"status":       "spam",
+				"clientID":     "client_id",
+				"smartEmailID": "smart_email_id",
+				"group":        "group",
+				"sentBeforeID": "before",
+				"sentAfterID":  "after",
+				"count":        "50",
+			},
+			oAuthAuthentication: true,
+		},
+		{
+			title: "invalid date value",
+			response: &http.Response{
+				StatusCode: 200,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"MessageID": "id", "SentAt": "invalid date"}]`)),
+			},
+			expectedQuery:         map[string]string{"status": "all"},
+			expectedError:         newClientError(ErrCodeDataProcessing),
+			expectClientSideError: true,
+		},
+		{
+			title:                "simulate remote call failure",
+			response:             &http.Response{},
+			forceHTTPClientError: true,
+			expectedQuery:        map[string]string{"status": "all"},
+			expectedError:        mock.ErrDeliberate,
+		},
+		{
+			title: "simulate server side error",
+			response: &http.Response{
+				StatusCode: 500,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Message":"msg", "Code":500}`)),
+			},
+			expectedQuery: map[string]string{"status": "all"},
+			expectedError: &Error{Code: 500},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient := createClient(t, tC.oAuthAuthentication, tC.forceHTTPClientError)
+			httpClient.SetResponse("transactional/messages", tC.response)
+			actual, err := client.Transactional().MessageTimeline(tC.options...)
+			if err != nil {
+				if !checkError(err, tC.expectedError) {
+					t.Errorf("Expected '%v' error, actual: '%v'", tC.expectedError, err)
+				}
+				checkErrorType(t, err, !tC.expectClientSideError && !tC.forceHTTPClientError)
+			}
+
+			checkQueryStringParameters(t, httpClient.LastRequest(), tC.expectedQuery)
+
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTransactionalAPI_SendSmartEmail(t *testing.T) {
+	testCases := []struct {
+		title                string
+		forceHTTPClientError bool
+		response             *http.Response
+		expected             []*transactional.SendResult
+		expectedError        error
+		oAuthAuthentication  bool
+	}{
+		{
+			title: "accepted",
+			response: &http.Response{
+				StatusCode: 202,
+				Body: ioutil.NopCloser(bytes.NewBufferString(`[
+					{"Status": "Accepted", "MessageID": "m1", "Recipient": "Joe <joe@example.com>"},
+					{"Status": "Accepted", "MessageID": "m2", "Recipient": "jane@example.com"}
+				]`)),
+			},
+			expected: []*transactional.SendResult{
+				{Status: "Accepted", MessageID: "m1", Recipient: "Joe <joe@example.com>"},
+				{Status: "Accepted", MessageID: "m2", Recipient: "jane@example.com"},
+			},
+		},
+		{
+			title: "oAuth authentication",
+			response: &http.Response{
+				StatusCode: 202,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`[{"Status": "Accepted", "MessageID": "m1", "Recipient": "joe@example.com"}]`)),
+			},
+			expected:            []*transactional.SendResult{{Status: "Accepted", MessageID: "m1", Recipient: "joe@example.com"}},
+			oAuthAuthentication: true,
+		},
+		{
+			title:                "simulate remote call failure",
+			response:             &http.Response{},
+			forceHTTPClientError: true,
+			expectedError:        mock.ErrDeliberate,
+		},
+		{
+			title: "simulate server side error",
+			response: &http.Response{
+				StatusCode: 400,
+				Body:       ioutil.NopCloser(bytes.NewBufferString(`{"Message":"msg", "Code":1}`)),
+			},
+			expectedError: &Error{Code: 1},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, httpClient := createClient(t, tC.oAuthAuthentication, tC.forceHTTPClientError)
+			httpClient.SetResponse("transactional/smartEmail/smart_email_id/send", tC.response)
+			actual, err := client.Transactional().SendSmartEmail("smart_email_id", transactional.SmartEmailMessage{
+				To:             []string{"joe@example.com"},
+				ConsentToTrack: transactional.ConsentUnchanged,
+			})
+			if err != nil {
+				if !checkError(err, tC.expectedError) {
+					t.Errorf("Expected '%v' error, actual: '%v'", tC.expectedError, err)
+				}
+				checkErrorType(t, err, !tC.forceHTTPClientError)
+			}
+			if diff := cmp.Diff(tC.expected, actual); diff != "" {
+				t.Errorf("Expectations failed (-expected +actual):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,469 @@
+package createsend
+
+import (
+	"fmt"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/subscribers"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const (
+	minSuppressionListPageSize = 10
+	maxSuppressionListPageSize = 1000
+	minMessageTimelineCount    = 1
+	maxMessageTimelineCount    = 200
+)
+
+// referenceData validates the country and timezone values against the reference data returned by the accounts API.
+type referenceData struct {
+	accounts accounts.API
+}
+
+func (r *referenceData) validate(v *validator, field string, details clients.BasicDetails) error {
+	if r == nil {
+		return nil
+	}
+	if len(details.Country) > 0 {
+		countries, err := r.accounts.Countries()
+		if err != nil {
+			return err
+		}
+		if !contains(countries, details.Country) {
+			v.add(field+".Country", details.Country, "must be one of the values returned by accounts.Countries")
+		}
+	}
+	if len(details.Timezone) > 0 {
+		timezones, err := r.accounts.Timezones()
+		if err != nil {
+			return err
+		}
+		if !contains(timezones, details.Timezone.String()) {
+			v.add(field+".Timezone", details.Timezone, "must be one of the values returned by accounts.Timezones")
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func validateAdministrator(v *validator, field string, administrator accounts.Administrator) {
+	v.email(field+".EmailAddress", administrator.EmailAddress)
+	v.required(field+".Name", administrator.Name)
+}
+
+func validateBasicDetails(v *validator, field string, details clients.BasicDetails) {
+	v.required(field+".Company", details.Company)
+	v.required(field+".Country", details.Country)
+	v.required(field+".Timezone", details.Timezone.String())
+}
+
+func validatePersonBasicDetails(v *validator, field string, person clients.PersonBasicDetails) {
+	v.email(field+".EmailAddress", person.EmailAddress)
+	v.required(field+".Name", person.Name)
+	if !person.AccessLevel.IsValid() {
+		v.add(field+".AccessLevel", person.AccessLevel, "must be a combination of the access flags")
+	}
+}
+
+// validatedAccountsAPI validates the requests of the accounts API before sending them to the server.
+type validatedAccountsAPI struct {
+	accounts.API
+}
+
+func (a *validatedAccountsAPI) AddAdministrator(administrator accounts.Administrator) error {
+	v := &validator{}
+	validateAdministrator(v, "administrator", administrator)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return a.API.AddAdministrator(administrator)
+}
+
+func (a *validatedAccountsAPI) UpdateAdministrator(currentEmailAddress string, administrator accounts.Administrator) error {
+	v := &validator{}
+	v.email("currentEmailAddress", currentEmailAddress)
+	validateAdministrator(v, "administrator", administrator)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return a.API.UpdateAdministrator(currentEmailAddress, administrator)
+}
+
+func (a *validatedAccountsAPI) Administrator(emailAddress string) (*accounts.AdministratorDetails, error) {
+	v := &validator{}
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return a.API.Administrator(emailAddress)
+}
+
+func (a *validatedAccountsAPI) DeleteAdministrator(emailAddress string) error {
+	v := &validator{}
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return a.API.DeleteAdministrator(emailAddress)
+}
+
+func (a *validatedAccountsAPI) SetAsPrimaryContact(emailAddress string) error {
+	v := &validator{}
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return a.API.SetAsPrimaryContact(emailAddress)
+}
+
+func (a *validatedAccountsAPI) NewEmbeddedSession(session accounts.EmbeddedSession) (string, error) {
+	v := &validator{}
+	v.email("session.EmailAddress", session.EmailAddress)
+	v.chrome("session.Chrome", session.Chrome)
+	v.sessionURL("session.URL", session.URL)
+	v.required("session.IntegratorID", session.IntegratorID)
+	v.required("session.ClientID", session.ClientID)
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return a.API.NewEmbeddedSession(session)
+}
+
+// validatedClientsAPI validates the requests of the clients API before sending them to the server.
+type validatedClientsAPI struct {
+	clients.API
+	references *referenceData
+}
+
+// validateClientID returns an error if the client ID is empty.
+func validateClientID(clientID string) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	return v.err()
+}
+
+func (c *validatedClientsAPI) Create(details clients.BasicDetails) (string, error) {
+	v := &validator{}
+	validateBasicDetails(v, "details", details)
+	if err := c.references.validate(v, "details", details); err != nil {
+		return "", err
+	}
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return c.API.Create(details)
+}
+
+func (c *validatedClientsAPI) Get(clientID string) (*clients.ClientDetails, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.Get(clientID)
+}
+
+func (c *validatedClientsAPI) SentCampaigns(clientID string) ([]*clients.SentCampaign, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.SentCampaigns(clientID)
+}
+
+func (c *validatedClientsAPI) ScheduledCampaigns(clientID string) ([]*clients.ScheduledCampaign, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.ScheduledCampaigns(clientID)
+}
+
+func (c *validatedClientsAPI) DraftCampaigns(clientID string) ([]*clients.DraftCampaign, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.DraftCampaigns(clientID)
+}
+
+func (c *validatedClientsAPI) Lists(clientID string) ([]*clients.List, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.Lists(clientID)
+}
+
+func (c *validatedClientsAPI) ListsByEmailAddress(clientID, emailAddress string) ([]*clients.SubscriberList, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return c.API.ListsByEmailAddress(clientID, emailAddress)
+}
+
+func (c *validatedClientsAPI) Segments(clientID string) ([]*clients.Segment, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.Segments(clientID)
+}
+
+func (c *validatedClientsAPI) SuppressionList(clientID string,
+	pageSize, page int,
+	orderBy order.SuppressionListField,
+	direction order.Direction) (*clients.SuppressionList, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.between("pageSize", pageSize, minSuppressionListPageSize, maxSuppressionListPageSize)
+	if page < 1 {
+		v.add("page", page, "must be greater than zero")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return c.API.SuppressionList(clientID, pageSize, page, orderBy, direction)
+}
+
+func (c *validatedClientsAPI) Suppress(clientID string, emails ...string) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	if len(emails) == 0 {
+		v.add("emails", emails, "must not be empty")
+	}
+	for i, email := range emails {
+		v.email(fmt.Sprintf("emails[%d]", i), email)
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.Suppress(clientID, emails...)
+}
+
+func (c *validatedClientsAPI) UnSuppress(clientID string, email string) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("email", email)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.UnSuppress(clientID, email)
+}
+
+func (c *validatedClientsAPI) Templates(clientID string) ([]*clients.Template, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.Templates(clientID)
+}
+
+func (c *validatedClientsAPI) Update(clientID string, details clients.BasicDetails) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	validateBasicDetails(v, "details", details)
+	if err := c.references.validate(v, "details", details); err != nil {
+		return err
+	}
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.Update(clientID, details)
+}
+
+func (c *validatedClientsAPI) SetPAYGBilling(clientID string, rates clients.PAYGRates) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.currency("rates.Currency", rates.Currency)
+	v.notNegative("rates.MarkupPercentage", float64(rates.MarkupPercentage))
+	v.notNegativeAmount("rates.MarkupOnDelivery", rates.MarkupOnDelivery)
+	v.notNegativeAmount("rates.MarkupPerRecipient", rates.MarkupPerRecipient)
+	v.notNegativeAmount("rates.MarkupOnDesignSpamTest", rates.MarkupOnDesignSpamTest)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.SetPAYGBilling(clientID, rates)
+}
+
+func (c *validatedClientsAPI) SetMonthlyBilling(clientID string, rates clients.MonthlyRates) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.currency("rates.Currency", rates.Currency)
+	v.notNegative("rates.MarkupPercentage", float64(rates.MarkupPercentage))
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.SetMonthlyBilling(clientID, rates)
+}
+
+func (c *validatedClientsAPI) TransferCredits(clientID string, request clients.CreditTransferRequest) (*clients.CreditTransferResult, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	if request.Credits == 0 {
+		v.add("request.Credits", request.Credits, "must not be zero")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return c.API.TransferCredits(clientID, request)
+}
+
+func (c *validatedClientsAPI) Delete(clientID string) error {
+	if err := validateClientID(clientID); err != nil {
+		return err
+	}
+	return c.API.Delete(clientID)
+}
+
+func (c *validatedClientsAPI) AddPerson(clientID string, person clients.Person) (string, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	validatePersonBasicDetails(v, "person", person.PersonBasicDetails)
+	v.required("person.Password", person.Password)
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return c.API.AddPerson(clientID, person)
+}
+
+func (c *validatedClientsAPI) UpdatePerson(clientID string, emailAddress string, person clients.Person) (string, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("emailAddress", emailAddress)
+	validatePersonBasicDetails(v, "person", person.PersonBasicDetails)
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return c.API.UpdatePerson(clientID, emailAddress, person)
+}
+
+func (c *validatedClientsAPI) People(clientID string) ([]*clients.PersonDetails, error) {
+	if err := validateClientID(clientID); err != nil {
+		return nil, err
+	}
+	return c.API.People(clientID)
+}
+
+func (c *validatedClientsAPI) Person(clientID string, emailAddress string) (*clients.PersonDetails, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return c.API.Person(clientID, emailAddress)
+}
+
+func (c *validatedClientsAPI) DeletePerson(clientID string, emailAddress string) error {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return c.API.DeletePerson(clientID, emailAddress)
+}
+
+func (c *validatedClientsAPI) SetPrimaryContact(clientID string, emailAddress string) (string, error) {
+	v := &validator{}
+	v.required("clientID", clientID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return "", err
+	}
+	return c.API.SetPrimaryContact(clientID, emailAddress)
+}
+
+func (c *validatedClientsAPI) PrimaryContact(clientID string) (string, error) {
+	if err := validateClientID(clientID); err != nil {
+		return "", err
+	}
+	return c.API.PrimaryContact(clientID)
+}
+
+// validatedTransactionalAPI validates the requests of the transactional API before sending them to the server.
+type validatedTransactionalAPI struct {
+	transactional.API
+}
+
+func (t *validatedTransactionalAPI) SmartEmail(smartEmailID string) (*transactional.SmartEmailDetails, error) {
+	v := &validator{}
+	v.required("smartEmailID", smartEmailID)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return t.API.SmartEmail(smartEmailID)
+}
+
+func (t *validatedTransactionalAPI) MessageTimeline(options ...transactional.Option) ([]*transactional.Message, error) {
+	ops := &transactional.Options{}
+	for _, op := range options {
+		op(ops)
+	}
+	v := &validator{}
+	if ops.Count() != 0 {
+		v.between("count", ops.Count(), minMessageTimelineCount, maxMessageTimelineCount)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return t.API.MessageTimeline(options...)
+}
+
+func (t *validatedTransactionalAPI) SendSmartEmail(smartEmailID string, message transactional.SmartEmailMessage) ([]*transactional.SendResult, error) {
+	v := &validator{}
+	v.required("smartEmailID", smartEmailID)
+	if len(message.To) == 0 {
+		v.add("message.To", message.To, "must not be empty")
+	}
+	for i, recipient := range message.To {
+		v.recipient(fmt.Sprintf("message.To[%d]", i), recipient)
+	}
+	for i, recipient := range message.CC {
+		v.recipient(fmt.Sprintf("message.CC[%d]", i), recipient)
+	}
+	for i, recipient := range message.BCC {
+		v.recipient(fmt.Sprintf("message.BCC[%d]", i), recipient)
+	}
+	if err := transactional.ValidateAttachments(message.Attachments...); err != nil {
+		v.add("message.Attachments", len(message.Attachments), err.Error())
+	}
+	if !message.ConsentToTrack.IsValid() {
+		v.add("message.ConsentToTrack", message.ConsentToTrack, "must be one of Yes, No, Unchanged")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return t.API.SendSmartEmail(smartEmailID, message)
+}
+
+// validatedSubscribersAPI validates the requests of the subscribers API before sending them to the server.
+type validatedSubscribersAPI struct {
+	subscribers.API
+}
+
+func (s *validatedSubscribersAPI) Get(listID, emailAddress string) (*subscribers.Details, error) {
+	v := &validator{}
+	v.required("listID", listID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	return s.API.Get(listID, emailAddress)
+}
+
+func (s *validatedSubscribersAPI) Delete(listID, emailAddress string) error {
+	v := &validator{}
+	v.required("listID", listID)
+	v.email("emailAddress", emailAddress)
+	if err := v.err(); err != nil {
+		return err
+	}
+	return s.API.Delete(listID, emailAddress)
+}
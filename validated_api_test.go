@@ -0,0 +1,646 @@
+package createsend_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/xitonix/createsend"
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/cache"
+	"github.com/xitonix/createsend/clients"
+	"github.com/xitonix/createsend/mock"
+	"github.com/xitonix/createsend/money"
+	"github.com/xitonix/createsend/order"
+	"github.com/xitonix/createsend/transactional"
+)
+
+const (
+	validTimezone = "(GMT+10:00) Canberra, Melbourne, Sydney"
+	validCountry  = "Australia"
+)
+
+func newValidatedTestClient(t *testing.T, options ...createsend.Option) (*createsend.Client, *apiCallCounter, *apiCallCounter) {
+	t.Helper()
+	accountCalls := &apiCallCounter{calls: make(map[string]int)}
+	clientCalls := &apiCallCounter{calls: make(map[string]int)}
+	options = append([]createsend.Option{
+		createsend.WithAPIKey("api_key"),
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithAccountsAPI(accountsAPICounter{apiCallCounter: accountCalls}),
+		createsend.WithClientsAPI(clientsAPICounter{apiCallCounter: clientCalls}),
+	}, options...)
+	client, err := createsend.New(options...)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	return client, accountCalls, clientCalls
+}
+
+func invalidFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, &createsend.Error{Code: int(createsend.ErrCodeValidationFailed)}) {
+		t.Fatalf("Expected a validation error, Actual: '%v'", err)
+	}
+	var validationErr *createsend.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("The error must wrap a validation error, Actual: '%v'", err)
+	}
+	fields := make([]string, len(validationErr.Fields))
+	for i, field := range validationErr.Fields {
+		fields[i] = field.Field
+	}
+	return fields
+}
+
+func TestValidatedAPIs(t *testing.T) {
+	validPerson := clients.PersonBasicDetails{EmailAddress: "a@b.com", Name: "name", AccessLevel: 23}
+	validDetails := clients.BasicDetails{Company: "company", Country: validCountry, Timezone: validTimezone}
+
+	testCases := []struct {
+		title          string
+		call           func(c *createsend.Client) error
+		expectedFields []string
+		expectedCall   string
+	}{
+		{
+			title: "add administrator with invalid email",
+			call: func(c *createsend.Client) error {
+				return c.Accounts().AddAdministrator(accounts.Administrator{EmailAddress: "Name <a@b.com>"})
+			},
+			expectedFields: []string{"administrator.EmailAddress", "administrator.Name"},
+		},
+		{
+			title: "add valid administrator",
+			call: func(c *createsend.Client) error {
+				return c.Accounts().AddAdministrator(accounts.Administrator{EmailAddress: "a@b.com", Name: "name"})
+			},
+			expectedCall: "AddAdministrator",
+		},
+		{
+			title: "update administrator",
+			call: func(c *createsend.Client) error {
+				return c.Accounts().UpdateAdministrator("invalid", accounts.Administrator{EmailAddress: "a@b.com", Name: " "})
+			},
+			expectedFields: []string{"currentEmailAddress", "administrator.Name"},
+		},
+		{
+			title: "administrator details",
+			call: func(c *createsend.Client) error {
+				_, err := c.Accounts().Administrator("")
+				return err
+			},
+			expectedFields: []string{"emailAddress"},
+		},
+		{
+			title: "delete administrator",
+			call: func(c *createsend.Client) error {
+				return c.Accounts().DeleteAdministrator("a@")
+			},
+			expectedFields: []string{"emailAddress"},
+		},
+		{
+			title: "set account primary contact",
+			call: func(c *createsend.Client) error {
+				return c.Accounts().SetAsPrimaryContact("a.b.com")
+			},
+			expectedFields: []string{"emailAddress"},
+		},
+		{
+			title: "embedded session",
+			call: func(c *createsend.Client) error {
+				_, err := c.Accounts().NewEmbeddedSession(accounts.EmbeddedSession{Chrome: 100})
+				return err
+			},
+			expectedFields: []string{"session.EmailAddress", "session.Chrome", "session.URL", "session.IntegratorID", "session.ClientID"},
+		},
+		{
+			title: "embedded session with absolute url",
+			call: func(c *createsend.Client) error {
+				_, err := c.Accounts().NewEmbeddedSession(accounts.EmbeddedSession{
+					EmailAddress: "a@b.com",
+					Chrome:       accounts.NoChrome,
+					URL:          "https://example.com/subscribers",
+					IntegratorID: "integrator_id",
+					ClientID:     "client_id",
+				})
+				return err
+			},
+			expectedFields: []string{"session.URL"},
+		},
+		{
+			title: "create client",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Create(clients.BasicDetails{})
+				return err
+			},
+			expectedFields: []string{"details.Company", "details.Country", "details.Timezone"},
+		},
+		{
+			title: "create valid client",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Create(validDetails)
+				return err
+			},
+			expectedCall: "Create",
+		},
+		{
+			title: "client details",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Get("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "valid client details",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Get("client_id")
+				return err
+			},
+			expectedCall: "Get",
+		},
+		{
+			title: "sent campaigns",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().SentCampaigns("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "scheduled campaigns",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().ScheduledCampaigns("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "draft campaigns",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().DraftCampaigns("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "lists",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Lists("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "lists by email address",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().ListsByEmailAddress("", "invalid")
+				return err
+			},
+			expectedFields: []string{"clientID", "emailAddress"},
+		},
+		{
+			title: "segments",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Segments("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "suppression list",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().SuppressionList("", -1, 0, order.BySuppressedEmailAddress, order.ASC)
+				return err
+			},
+			expectedFields: []string{"clientID", "pageSize", "page"},
+		},
+		{
+			title: "suppress",
+			call: func(c *createsend.Client) error {
+				return c.Clients().Suppress("client_id", "a@b.com", "invalid")
+			},
+			expectedFields: []string{"emails[1]"},
+		},
+		{
+			title: "suppress nothing",
+			call: func(c *createsend.Client) error {
+				return c.Clients().Suppress("client_id")
+			},
+			expectedFields: []string{"emails"},
+		},
+		{
+			title: "unsuppress",
+			call: func(c *createsend.Client) error {
+				return c.Clients().UnSuppress("", "")
+			},
+			expectedFields: []string{"clientID", "email"},
+		},
+		{
+			title: "templates",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Templates("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "update client",
+			call: func(c *createsend.Client) error {
+				return c.Clients().Update("", clients.BasicDetails{Company: "company"})
+			},
+			expectedFields: []string{"clientID", "details.Country", "details.Timezone"},
+		},
+		{
+			title: "update valid client",
+			call: func(c *createsend.Client) error {
+				return c.Clients().Update("client_id", validDetails)
+			},
+			expectedCall: "Update",
+		},
+		{
+			title: "PAYG billing",
+			call: func(c *createsend.Client) error {
+				return c.Clients().SetPAYGBilling("client_id", clients.PAYGRates{
+					Currency:               money.UnknownCurrency,
+					MarkupPercentage:       -1,
+					MarkupOnDelivery:       money.MustParse("-0.01"),
+					MarkupPerRecipient:     money.NewFromInt(-1),
+					MarkupOnDesignSpamTest: money.MustParse("-1.5"),
+				})
+			},
+			expectedFields: []string{"rates.Currency", "rates.MarkupPercentage", "rates.MarkupOnDelivery", "rates.MarkupPerRecipient", "rates.MarkupOnDesignSpamTest"},
+		},
+		{
+			title: "valid PAYG billing",
+			call: func(c *createsend.Client) error {
+				return c.Clients().SetPAYGBilling("client_id", clients.PAYGRates{Currency: money.AUD, MarkupPercentage: 10, MarkupOnDelivery: money.MustParse("0.5")})
+			},
+			expectedCall: "SetPAYGBilling",
+		},
+		{
+			title: "monthly billing",
+			call: func(c *createsend.Client) error {
+				return c.Clients().SetMonthlyBilling("", clients.MonthlyRates{MarkupPercentage: -10})
+			},
+			expectedFields: []string{"clientID", "rates.Currency", "rates.MarkupPercentage"},
+		},
+		{
+			title: "transfer credits",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().TransferCredits("client_id", clients.CreditTransferRequest{})
+				return err
+			},
+			expectedFields: []string{"request.Credits"},
+		},
+		{
+			title: "valid credit transfer",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().TransferCredits("client_id", clients.CreditTransferRequest{Credits: -10})
+				return err
+			},
+			expectedCall: "TransferCredits",
+		},
+		{
+			title: "delete client",
+			call: func(c *createsend.Client) error {
+				return c.Clients().Delete(" ")
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "add person",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().AddPerson("client_id", clients.Person{
+					PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: "a@b.com", AccessLevel: 128},
+				})
+				return err
+			},
+			expectedFields: []string{"person.Name", "person.AccessLevel", "person.Password"},
+		},
+		{
+			title: "add valid person",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().AddPerson("client_id", clients.Person{PersonBasicDetails: validPerson, Password: "password"})
+				return err
+			},
+			expectedCall: "AddPerson",
+		},
+		{
+			title: "update person",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().UpdatePerson("client_id", "", clients.Person{
+					PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: "a@b.com", Name: "name", AccessLevel: -1},
+				})
+				return err
+			},
+			expectedFields: []string{"emailAddress", "person.AccessLevel"},
+		},
+		{
+			title: "update valid person without password",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().UpdatePerson("client_id", "a@b.com", clients.Person{PersonBasicDetails: validPerson})
+				return err
+			},
+			expectedCall: "UpdatePerson",
+		},
+		{
+			title: "people",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().People("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "person",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().Person("", "a@b.com")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "delete person",
+			call: func(c *createsend.Client) error {
+				return c.Clients().DeletePerson("client_id", "@b.com")
+			},
+			expectedFields: []string{"emailAddress"},
+		},
+		{
+			title: "set client primary contact",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().SetPrimaryContact("", "a@b.com")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "client primary contact",
+			call: func(c *createsend.Client) error {
+				_, err := c.Clients().PrimaryContact("")
+				return err
+			},
+			expectedFields: []string{"clientID"},
+		},
+		{
+			title: "smart email",
+			call: func(c *createsend.Client) error {
+				_, err := c.Transactional().SmartEmail("")
+				return err
+			},
+			expectedFields: []string{"smartEmailID"},
+		},
+		{
+			title: "message timeline",
+			call: func(c *createsend.Client) error {
+				_, err := c.Transactional().MessageTimeline(transactional.WithCount(201))
+				return err
+			},
+			expectedFields: []string{"count"},
+		},
+		{
+			title: "send smart email without recipients",
+			call: func(c *createsend.Client) error {
+				_, err := c.Transactional().SendSmartEmail("", transactional.SmartEmailMessage{})
+				return err
+			},
+			expectedFields: []string{"smartEmailID", "message.To", "message.ConsentToTrack"},
+		},
+		{
+			title: "send smart email with invalid recipients and attachments",
+			call: func(c *createsend.Client) error {
+				_, err := c.Transactional().SendSmartEmail("id", transactional.SmartEmailMessage{
+					To:             []string{"Joe <joe@example.com>", "joe"},
+					CC:             []string{""},
+					BCC:            []string{"a.b.com"},
+					Attachments:    []*transactional.Attachment{nil},
+					ConsentToTrack: transactional.ConsentGiven,
+				})
+				return err
+			},
+			expectedFields: []string{"message.To[1]", "message.CC[0]", "message.BCC[0]", "message.Attachments"},
+		},
+		{
+			title: "get subscriber",
+			call: func(c *createsend.Client) error {
+				_, err := c.Subscribers().Get("", "a.b.com")
+				return err
+			},
+			expectedFields: []string{"listID", "emailAddress"},
+		},
+		{
+			title: "delete subscriber",
+			call: func(c *createsend.Client) error {
+				return c.Subscribers().Delete(" ", "")
+			},
+			expectedFields: []string{"listID", "emailAddress"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, accountCalls, clientCalls := newValidatedTestClient(t, createsend.WithValidation(true))
+			err := tC.call(client)
+			if len(tC.expectedFields) == 0 && err != nil {
+				t.Fatalf("Did not expect an error but received: '%v'", err)
+			}
+			if diff := cmp.Diff(tC.expectedFields, invalidFields(t, err)); diff != "" {
+				t.Errorf("Invalid fields mismatch (-want +got):\n%s", diff)
+			}
+			calls := len(accountCalls.calls) + len(clientCalls.calls)
+			if tC.expectedCall == "" && calls != 0 {
+				t.Errorf("The invalid requests must not be sent to the server")
+			}
+			if tC.expectedCall != "" && accountCalls.calls[tC.expectedCall]+clientCalls.calls[tC.expectedCall] != 1 {
+				t.Errorf("The valid request must have been sent to the server using %s", tC.expectedCall)
+			}
+		})
+	}
+}
+
+func TestValidationDisabled(t *testing.T) {
+	client, _, clientCalls := newValidatedTestClient(t)
+	if _, err := client.Clients().Get(""); err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+	if clientCalls.calls["Get"] != 1 {
+		t.Error("The request must not be validated by default")
+	}
+}
+
+func TestReferenceDataValidation(t *testing.T) {
+	testCases := []struct {
+		title          string
+		details        clients.BasicDetails
+		referenceErr   error
+		expectedFields []string
+		expectedError  error
+	}{
+		{
+			title:   "valid country and timezone",
+			details: clients.BasicDetails{Company: "company", Country: validCountry, Timezone: validTimezone},
+		},
+		{
+			title:          "unknown country and timezone",
+			details:        clients.BasicDetails{Company: "company", Country: "Narnia", Timezone: "(GMT+10:00) Melbourne"},
+			expectedFields: []string{"details.Country", "details.Timezone"},
+		},
+		{
+			title:          "missing values are not looked up",
+			details:        clients.BasicDetails{Company: "company"},
+			expectedFields: []string{"details.Country", "details.Timezone"},
+		},
+		{
+			title:         "reference data failure",
+			details:       clients.BasicDetails{Company: "company", Country: validCountry, Timezone: validTimezone},
+			referenceErr:  mock.ErrDeliberate,
+			expectedError: mock.ErrDeliberate,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.title, func(t *testing.T) {
+			client, accountCalls, _ := newValidatedTestClient(t, createsend.WithReferenceDataValidation(true))
+			accountCalls.err = tC.referenceErr
+			for i := 0; i < 2; i++ {
+				_, err := client.Clients().Create(tC.details)
+				if tC.expectedError != nil {
+					if !errors.Is(err, tC.expectedError) {
+						t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+					}
+					err = client.Clients().Update("client_id", tC.details)
+					if !errors.Is(err, tC.expectedError) {
+						t.Errorf("Expected '%v' error, Actual: '%v'", tC.expectedError, err)
+					}
+					continue
+				}
+				if diff := cmp.Diff(tC.expectedFields, invalidFields(t, err)); diff != "" {
+					t.Errorf("Invalid fields mismatch (-want +got):\n%s", diff)
+				}
+				err = client.Clients().Update("client_id", tC.details)
+				if diff := cmp.Diff(tC.expectedFields, invalidFields(t, err)); diff != "" {
+					t.Errorf("Invalid fields mismatch (-want +got):\n%s", diff)
+				}
+			}
+			if tC.expectedError == nil && (accountCalls.calls["Countries"] > 1 || accountCalls.calls["Timezones"] > 1) {
+				t.Errorf("The reference data must have been cached. Actual: %v", accountCalls.calls)
+			}
+		})
+	}
+}
+
+func TestReferenceDataValidation_WithCache(t *testing.T) {
+	c := cache.NewLRU(10, 0)
+	client, accountCalls, _ := newValidatedTestClient(t, createsend.WithReferenceDataValidation(true), createsend.WithCache(c))
+	details := clients.BasicDetails{Company: "company", Country: validCountry, Timezone: validTimezone}
+	for i := 0; i < 2; i++ {
+		if _, err := client.Clients().Create(details); err != nil {
+			t.Fatalf("Did not expect an error but received: '%v'", err)
+		}
+	}
+	if accountCalls.calls["Countries"] != 1 || accountCalls.calls["Timezones"] != 1 {
+		t.Errorf("The reference data must have been cached. Actual: %v", accountCalls.calls)
+	}
+	if c.Len() != 2 {
+		t.Errorf("The reference data must be stored in the provided cache. Actual entries: %d", c.Len())
+	}
+}
+
+func TestValidationError_Error(t *testing.T) {
+	client, _, _ := newValidatedTestClient(t, createsend.WithValidation(true))
+	err := client.Clients().UnSuppress("", "invalid")
+	const expected = "-12: validation failed. clientID must not be empty; email must be a valid email address"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected: %q, Actual: '%v'", expected, err)
+	}
+	var validationErr *createsend.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatal("The error must wrap a validation error")
+	}
+	if value := validationErr.Fields[1].Value; value != "invalid" {
+		t.Errorf("Expected invalid value: invalid, Actual: %v", value)
+	}
+	if !strings.Contains(validationErr.Error(), "clientID must not be empty") {
+		t.Errorf("Unexpected validation error message: %s", validationErr.Error())
+	}
+}
+
+func TestValidatedAPIs_PassThrough(t *testing.T) {
+	client, err := createsend.New(
+		createsend.WithAPIKey("api_key"),
+		createsend.WithHTTPClient(mock.NewHTTPClientMock()),
+		createsend.WithValidation(true),
+	)
+	if err != nil {
+		t.Fatalf("Did not expect an error but received: '%v'", err)
+	}
+
+	admin := accounts.Administrator{EmailAddress: "a@b.com", Name: "name"}
+	person := clients.Person{PersonBasicDetails: clients.PersonBasicDetails{EmailAddress: "a@b.com", Name: "name"}}
+	calls := map[string]func() error{
+		"accounts.UpdateAdministrator": func() error { return client.Accounts().UpdateAdministrator("a@b.com", admin) },
+		"accounts.Administrator":       func() error { _, err := client.Accounts().Administrator("a@b.com"); return err },
+		"accounts.DeleteAdministrator": func() error { return client.Accounts().DeleteAdministrator("a@b.com") },
+		"accounts.SetAsPrimaryContact": func() error { return client.Accounts().SetAsPrimaryContact("a@b.com") },
+		"accounts.NewEmbeddedSession": func() error {
+			_, err := client.Accounts().NewEmbeddedSession(accounts.EmbeddedSession{
+				EmailAddress: "a@b.com",
+				Chrome:       accounts.TabsChrome,
+				URL:          "/subscribers",
+				IntegratorID: "integrator_id",
+				ClientID:     "client_id",
+			})
+			return err
+		},
+		"clients.SentCampaigns":      func() error { _, err := client.Clients().SentCampaigns("id"); return err },
+		"clients.ScheduledCampaigns": func() error { _, err := client.Clients().ScheduledCampaigns("id"); return err },
+		"clients.DraftCampaigns":     func() error { _, err := client.Clients().DraftCampaigns("id"); return err },
+		"clients.Lists":              func() error { _, err := client.Clients().Lists("id"); return err },
+		"clients.ListsByEmailAddress": func() error {
+			_, err := client.Clients().ListsByEmailAddress("id", "a@b.com")
+			return err
+		},
+		"clients.Segments": func() error { _, err := client.Clients().Segments("id"); return err },
+		"clients.SuppressionList": func() error {
+			_, err := client.Clients().SuppressionList("id", 10, 1, order.BySuppressionDate, order.DESC)
+			return err
+		},
+		"clients.Suppress":   func() error { return client.Clients().Suppress("id", "a@b.com") },
+		"clients.UnSuppress": func() error { return client.Clients().UnSuppress("id", "a@b.com") },
+		"clients.Templates":  func() error { _, err := client.Clients().Templates("id"); return err },
+		"clients.SetMonthlyBilling": func() error {
+			return client.Clients().SetMonthlyBilling("id", clients.MonthlyRates{Currency: money.USD})
+		},
+		"clients.Delete":            func() error { return client.Clients().Delete("id") },
+		"clients.People":            func() error { _, err := client.Clients().People("id"); return err },
+		"clients.Person":            func() error { _, err := client.Clients().Person("id", "a@b.com"); return err },
+		"clients.DeletePerson":      func() error { return client.Clients().DeletePerson("id", "a@b.com") },
+		"clients.SetPrimaryContact": func() error { _, err := client.Clients().SetPrimaryContact("id", "a@b.com"); return err },
+		"clients.PrimaryContact":    func() error { _, err := client.Clients().PrimaryContact("id"); return err },
+		"clients.UpdatePerson":      func() error { _, err := client.Clients().UpdatePerson("id", "a@b.com", person); return err },
+		"transactional.SmartEmail":  func() error { _, err := client.Transactional().SmartEmail("id"); return err },
+		"subscribers.Get":           func() error { _, err := client.Subscribers().Get("id", "a@b.com"); return err },
+		"subscribers.Delete":        func() error { return client.Subscribers().Delete("id", "a@b.com") },
+		"transactional.MessageTimeline": func() error {
+			_, err := client.Transactional().MessageTimeline(transactional.WithCount(200))
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			// The mocked HTTP client fails all the requests which reach the transport layer.
+			err := call()
+			if err == nil || errors.Is(err, &createsend.Error{Code: int(createsend.ErrCodeValidationFailed)}) {
+				t.Errorf("The valid request must have been sent to the server. Actual error: '%v'", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package createsend
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/xitonix/createsend/accounts"
+	"github.com/xitonix/createsend/money"
+)
+
+// FieldError represents an invalid field or argument value.
+type FieldError struct {
+	// Field the name of the invalid argument or field (eg. details.Country).
+	Field string
+	// Value the invalid value.
+	Value interface{}
+	// Reason explains why the value is invalid.
+	Reason string
+}
+
+// Error returns the string representation of the field error.
+func (f *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", f.Field, f.Reason)
+}
+
+// ValidationError lists all the invalid fields of a request which has been rejected before being sent to the server.
+//
+// The validation errors are returned wrapped within an *Error with ErrCodeValidationFailed code,
+// and can be retrieved using errors.As.
+type ValidationError struct {
+	// Fields the invalid fields.
+	Fields []*FieldError
+}
+
+// Error returns the string representation of the validation error.
+func (v *ValidationError) Error() string {
+	messages := make([]string, len(v.Fields))
+	for i, field := range v.Fields {
+		messages[i] = field.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// validator collects the field errors of a request.
+type validator struct {
+	fields []*FieldError
+}
+
+func (v *validator) add(field string, value interface{}, reason string) {
+	v.fields = append(v.fields, &FieldError{
+		Field:  field,
+		Value:  value,
+		Reason: reason,
+	})
+}
+
+func (v *validator) required(field, value string) bool {
+	if len(strings.TrimSpace(value)) == 0 {
+		v.add(field, value, "must not be empty")
+		return false
+	}
+	return true
+}
+
+func (v *validator) email(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		v.add(field, value, "must be a valid email address")
+	}
+}
+
+// recipient validates an email address with an optional display name (eg. "Joe <joe@example.com>").
+func (v *validator) recipient(field, value string) {
+	if !v.required(field, value) {
+		return
+	}
+	if _, err := mail.ParseAddress(value); err != nil {
+		v.add(field, value, "must be a valid email address")
+	}
+}
+
+func (v *validator) chrome(field string, value accounts.Chrome) {
+	if !value.IsValid() {
+		v.add(field, value, fmt.Sprintf("must be one of %s, %s, %s", accounts.AllChrome, accounts.TabsChrome, accounts.NoChrome))
+	}
+}
+
+func (v *validator) sessionURL(field, value string) {
+	if v.required(field, value) && !accounts.IsValidSessionURL(value) {
+		v.add(field, value, "must be a relative Campaign Monitor path")
+	}
+}
+
+func (v *validator) between(field string, value, min, max int) {
+	if value < min || value > max {
+		v.add(field, value, fmt.Sprintf("must be between %d and %d", min, max))
+	}
+}
+
+func (v *validator) notNegative(field string, value float64) {
+	if value < 0 {
+		v.add(field, value, "must not be negative")
+	}
+}
+
+func (v *validator) notNegativeAmount(field string, value money.Decimal) {
+	if value.Sign() < 0 {
+		v.add(field, value, "must not be negative")
+	}
+}
+
+func (v *validator) currency(field string, value money.Currency) {
+	if value.IsValid() {
+		return
+	}
+	currencies := money.Currencies()
+	codes := make([]string, len(currencies))
+	for i, c := range currencies {
+		codes[i] = c.String()
+	}
+	v.add(field, value, fmt.Sprintf("must be one of %s", strings.Join(codes, ", ")))
+}
+
+// err returns nil if all the fields are valid, or an *Error wrapping a *ValidationError otherwise.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return newWrappedClientError(ErrCodeValidationFailed.String(), &ValidationError{Fields: v.fields}, ErrCodeValidationFailed)
+}